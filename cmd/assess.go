@@ -2,54 +2,135 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
-	"time"
+	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	agentsdk "github.com/urmzd/adk"
 	"github.com/urmzd/adk/core"
-	"github.com/urmzd/adk/provider/ollama"
 	"github.com/urmzd/adk/tui"
 	"golang.org/x/term"
 
 	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/assess"
+	"github.com/urmzd/resume-generator/pkg/generators"
+	"github.com/urmzd/resume-generator/pkg/resume"
 	"github.com/urmzd/resume-generator/pkg/utils"
 	"go.uber.org/zap"
 )
 
 var (
 	assessInput     string
+	assessProvider  string
 	assessModel     string
+	assessBaseURL   string
+	assessAPIKeyEnv string
 	assessOllamaURL string
 	assessVerbose   bool
+	assessFormat    string
+	assessMinScore  float64
+	assessRetries   int
+	assessHistory   string
+	assessNoHistory bool
+	assessJob       string
+	assessWeights   string
+	assessSuggest   bool
+	assessNoCache   bool
+	assessRubric    string
 )
 
 func initAssessCmd() {
 	rootCmd.AddCommand(assessCmd)
 	assessCmd.Flags().StringVarP(&assessInput, "input", "i", "", "Path to the resume data file (e.g., resume.yml)")
-	assessCmd.Flags().StringVarP(&assessModel, "model", "m", "qwen3.5:4b", "Ollama model to use for assessment")
-	assessCmd.Flags().StringVar(&assessOllamaURL, "ollama-url", "http://localhost:11434", "Ollama server URL")
+	assessCmd.Flags().StringVar(&assessProvider, "provider", "ollama", "Inference server: ollama, or openai for any OpenAI-compatible chat completions endpoint")
+	assessCmd.Flags().StringVarP(&assessModel, "model", "m", "qwen3.5:4b", "Model to use for assessment")
+	assessCmd.Flags().StringVar(&assessBaseURL, "base-url", "", "Provider base URL (default http://localhost:11434 for ollama, http://localhost:8080/v1 for openai)")
+	assessCmd.Flags().StringVar(&assessAPIKeyEnv, "api-key-env", "OPENAI_API_KEY", "Environment variable holding the API key sent to the provider, if any")
+	assessCmd.Flags().StringVar(&assessOllamaURL, "ollama-url", "", "Ollama server URL")
+	_ = assessCmd.Flags().MarkDeprecated("ollama-url", "use --base-url instead")
 	assessCmd.Flags().BoolVarP(&assessVerbose, "verbose", "v", false, "Show full streaming output from all agents")
+	assessCmd.Flags().StringVar(&assessFormat, "format", "text", "Report format: text or json")
+	assessCmd.Flags().Float64Var(&assessMinScore, "min-score", 0, "Exit with status 1 when the overall score (1-10) is below this threshold")
+	assessCmd.Flags().IntVar(&assessRetries, "retries", 2, "Times to ask the model to correct a malformed assessment")
+	assessCmd.Flags().StringVar(&assessHistory, "history", "", "History file the scores are appended to (default: resume.assess.jsonl next to the input)")
+	assessCmd.Flags().BoolVar(&assessNoHistory, "no-history", false, "Do not append the scores to the history file")
+	assessCmd.Flags().StringVar(&assessJob, "job", "", "Plain-text job posting to assess the resume against")
+	assessCmd.Flags().StringVar(&assessWeights, "weights", "", "Relative dimension weights of the overall score, e.g. fit=40,content=20")
+	assessCmd.Flags().StringVar(&assessRubric, "rubric", assess.DefaultRubric, "Rubric naming the analysts and their weights: a name from 'assess rubrics list' or a path to a rubric file")
+	assessCmd.Flags().BoolVar(&assessNoCache, "no-cache", false, "Assess again even when a cached assessment of the same resume exists")
+	assessCmd.Flags().BoolVar(&assessSuggest, "suggest", false, "Have the writing and content analysts propose edits, review them and write the accepted ones back to the resume")
 
 	_ = assessCmd.MarkFlagRequired("input")
+
+	assessRubricsCmd.AddCommand(assessRubricsListCmd)
+	assessCmd.AddCommand(assessRubricsCmd)
 }
 
 var assessCmd = &cobra.Command{
 	Use:   "assess",
-	Short: "Rate and review a resume using specialized LLM agents via Ollama",
-	Long: `Assess a resume by delegating to four specialist sub-agents:
+	Short: "Rate and review a resume using specialized LLM agents",
+	Long: `Assess a resume by delegating to the analysts of a rubric. The default
+rubric has four specialist sub-agents:
 
   - content-analyst:  achievement quantity, metrics, specificity, impact
   - writing-analyst:  succinctness, clarity, readability, grammar
   - industry-analyst: industry-specific keywords, conventions, relevance
   - format-analyst:   structure, section ordering, length, visual hierarchy
 
-Each agent scores its dimension 1-10 with bullet-point feedback.
-A coordinator synthesizes the results into a final report.
-
-Requires Ollama running locally (https://ollama.com).`,
+With --job, every analyst reads the job posting alongside the resume and
+judges it against that opening rather than a role inferred from the resume,
+and a fifth fit-analyst scores how the resume covers each requirement of the
+posting as met, partial or missing.
+
+Each agent scores its dimension 1-10 with strengths, weaknesses and
+suggestions as JSON. A coordinator combines them into a report that is
+validated against a schema; malformed answers are sent back to the model to
+be corrected up to --retries times. The overall score is the weighted
+average of the dimension scores: content 30%, industry 25%, writing 25% and
+structure 20%, or with --job fit 30%, content 25% and 15% each for the rest.
+--weights changes the relative weights, as in --weights fit=50,writing=10.
+
+--rubric picks other analysts, weights and per-analyst models from a rubric
+file, such as one with a security clearance or academic CV reviewer; see
+'assess rubrics --help' for the format and 'assess rubrics list' for the
+rubrics available.
+
+--format json prints the validated report for scripts, and --min-score
+exits with status 1 when the overall score is below a threshold. Every
+assessment is appended to a JSON Lines history file (resume.assess.jsonl
+for resume.yml) with the time, a hash of the resume and each score, so
+scores can be charted across revisions.
+
+Any input format is accepted: the resume is validated and rendered through
+the modern-markdown template, and the agents read that rendering. Results
+are cached in the user cache directory, keyed by a hash of the rendered
+resume, the job posting, the model and the prompt version, so assessing an
+unchanged resume again returns the same report at once without adding to
+the history. --no-cache assesses it again.
+
+--suggest has the writing and content analysts propose rewrites of single
+values, each addressed by its path in the resume, such as
+experience.positions[2].highlights[1]. After the report, each proposal is
+shown with the original and replacement text to accept (y) or reject (n);
+accepted edits are written back to the YAML resume, leaving its comments
+and layout as they were.
+
+Requires a local inference server. The default is Ollama (https://ollama.com);
+--provider openai talks to any OpenAI-compatible chat completions endpoint,
+such as the llama.cpp server, vLLM or LM Studio. The API key, when the server
+needs one, is read from the environment variable named by --api-key-env.
+
+Examples:
+  resume-generator assess -i resume.yml
+  resume-generator assess -i resume.yml --provider openai --base-url http://localhost:8000/v1 -m Qwen/Qwen3-8B
+  resume-generator assess -i resume.yml --format json --min-score 7
+  resume-generator assess -i resume.yml --job posting.txt --weights fit=50
+  resume-generator assess -i resume.yml --suggest
+  resume-generator assess -i cv.yml --rubric academic`,
 	Run: func(cmd *cobra.Command, args []string) {
 		logger, _ := zap.NewProduction()
 		sugar := logger.Sugar()
@@ -62,62 +143,240 @@ Requires Ollama running locally (https://ollama.com).`,
 			sugar.Fatalf("Input file does not exist: %s", inputPath)
 		}
 
-		yamlBytes, err := os.ReadFile(inputPath)
+		if assessFormat != "text" && assessFormat != "json" {
+			sugar.Fatalf("Unsupported report format %q (supported: text, json)", assessFormat)
+		}
+		if assessMinScore < 0 || assessMinScore > 10 {
+			sugar.Fatalf("--min-score must be between 0 and 10, got %v", assessMinScore)
+		}
+		if assessRetries < 0 {
+			sugar.Fatalf("--retries must not be negative, got %d", assessRetries)
+		}
+
+		rubricDir, err := assess.RubricDir()
+		if err != nil {
+			sugar.Warnf("Only built-in rubrics are available: %v", err)
+		}
+		rubric, err := assess.FindRubric(assessRubric, rubricDir)
+		if err != nil {
+			sugar.Fatalf("Failed to load rubric: %v", err)
+		}
+
+		inputData, err := resume.LoadResumeFromFile(inputPath)
+		if err != nil {
+			sugar.Fatalf("Failed to load resume data: %v", err)
+		}
+		if err := inputData.Validate(); err != nil {
+			sugar.Fatalf("Invalid resume data: %v", err)
+		}
+		if assessSuggest && inputData.GetFormat() != "yaml" {
+			sugar.Fatalf("--suggest writes edits back to YAML resumes only, got %s", filepath.Base(inputPath))
+		}
+		// The raw file identifies the revision in the history, and is what
+		// --suggest edits.
+		rawBytes, err := os.ReadFile(inputPath)
 		if err != nil {
 			sugar.Fatalf("Error reading input file: %s", err)
 		}
-		resumeText := string(yamlBytes)
 
-		// Check Ollama is reachable
-		httpClient := &http.Client{Timeout: 5 * time.Second}
-		resp, err := httpClient.Get(assessOllamaURL)
+		r := inputData.ToResume()
+		resumeText, err := renderAssessInput(sugar, r)
 		if err != nil {
-			sugar.Fatalf("Ollama is not available at %s. Install Ollama (https://ollama.com) and start it with 'ollama serve'.\n  Error: %v", assessOllamaURL, err)
+			sugar.Fatalf("Failed to render resume: %v", err)
+		}
+		if assessSuggest {
+			resumeText += "\n\n" + editableValues(r)
 		}
-		_ = resp.Body.Close()
 
-		client := ollama.NewClient(assessOllamaURL, assessModel, "")
-		adapter := ollama.NewAdapter(client)
+		var jobText string
+		if assessJob != "" {
+			posting, err := os.ReadFile(assessJob)
+			if err != nil {
+				sugar.Fatalf("Failed to read job posting: %v", err)
+			}
+			jobText = string(posting)
+		}
+		weights := rubric.Weights(jobText != "")
+		if len(weights) == 0 {
+			sugar.Fatalf("Rubric %s has no analysts that run without --job", rubric.Name)
+		}
+		if assessWeights != "" {
+			if weights, err = assess.ParseWeights(assessWeights, weights); err != nil {
+				sugar.Fatalf("Invalid --weights: %v", err)
+			}
+		}
 
-		agent := agentsdk.NewAgent(agentsdk.AgentConfig{
-			Name:     "resume-coordinator",
-			Provider: adapter,
-			MaxIter:  10,
-			SystemPrompt: `You are a senior resume review coordinator. You have four specialist analysts available.
+		prompt := fmt.Sprintf("Assess the following resume:\n\n---\n%s\n---", resumeText)
+		if jobText != "" {
+			prompt = fmt.Sprintf("Assess the following resume against the job posting below it:\n\n---\n%s\n---\n\nJob posting:\n\n---\n%s\n---", resumeText, jobText)
+		}
 
-Your process:
-1. Read the resume carefully and identify the candidate's target industry/role.
-2. Delegate to ALL FOUR analysts — content, writing, industry, and format — by calling each delegate tool. Pass the full resume text as the task to each one, prefixed with the target industry/role you identified.
-3. After receiving all four reports, synthesize a final assessment that includes:
-   - Target industry/role identified
-   - Individual dimension scores (from each analyst)
-   - Overall score (weighted average: content 30%, industry 25%, writing 25%, format 20%)
-   - Top 3 priority improvements (the most impactful changes across all dimensions)
+		// Progress goes to standard error when standard output carries JSON.
+		progress := os.Stdout
+		if assessFormat == "json" {
+			progress = os.Stderr
+		}
 
-Always delegate to all four analysts. Do not skip any. Present the final report in a clean, readable format.`,
-			SubAgents: buildAssessSubAgents(adapter),
-		})
+		var cache assess.Cache
+		cacheKey := assess.CacheKey(assessPromptVersion, assessProvider, assessBaseURL, assessModel, rubricKey(rubric), weightsKey(weights), prompt)
+		if !assessNoCache {
+			if cache.Dir, err = assess.DefaultCacheDir(); err != nil {
+				sugar.Warnf("Assessments will not be cached: %v", err)
+			}
+		}
 
-		prompt := fmt.Sprintf("Assess the following resume (in YAML format):\n\n---\n%s\n---", resumeText)
+		var result *assess.Assessment
+		var cached bool
+		if cache.Dir != "" {
+			result, cached = cache.Get(cacheKey)
+		}
+		if cached {
+			fmt.Fprintln(progress, "Using the cached assessment of this resume; --no-cache assesses it again.")
+		} else {
+			if result, err = runAssessment(sugar, rubric, prompt, jobText != "", weights, progress); err != nil {
+				sugar.Fatalf("Assessment failed: %v", err)
+			}
+			if cache.Dir != "" {
+				if err := cache.Put(cacheKey, result); err != nil {
+					sugar.Warnf("Failed to cache the assessment: %v", err)
+				}
+			}
+		}
 
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+		if assessFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(result); err != nil {
+				sugar.Fatalf("Failed to write report: %v", err)
+			}
+		} else {
+			fmt.Println(tui.RenderReport("Resume Assessment", result.Markdown()))
+		}
 
-		stream := agent.Invoke(ctx, []core.Message{
-			core.NewUserMessage(prompt),
-		})
+		// A cached assessment is already in the history.
+		if !assessNoHistory && !cached {
+			historyPath := assessHistory
+			if historyPath == "" {
+				historyPath = assess.HistoryPath(inputPath)
+			}
+			entry := assess.NewEntry(inputPath, rawBytes, assessProvider, assessModel, result)
+			entry.Job = assessJob
+			entry.Rubric = rubric.Name
+			if err := assess.AppendHistory(historyPath, entry); err != nil {
+				sugar.Warnf("Failed to append to history file %s: %v", historyPath, err)
+			}
+		}
 
-		header := buildAssessHeader(agent)
-		isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+		if assessSuggest {
+			if err := reviewEdits(inputPath, rawBytes, result.Edits(), progress); err != nil {
+				sugar.Fatalf("Failed to apply edits: %v", err)
+			}
+		}
 
-		if assessVerbose || !isTTY {
-			runVerbose(stream, header, cancel)
-		} else {
-			runTUI(stream, header, cancel, sugar)
+		if result.Overall < assessMinScore {
+			fmt.Fprintf(os.Stderr, "Overall score %.1f is below --min-score %.1f\n", result.Overall, assessMinScore)
+			os.Exit(1)
 		}
 	},
 }
 
+// assessPromptVersion is part of the cache key of assessments. Bump it
+// whenever the prompts change, so cached answers to the old prompts are
+// not reused.
+const assessPromptVersion = "2"
+
+// runAssessment has the coordinator and its analysts assess the prompt.
+func runAssessment(sugar *zap.SugaredLogger, rubric *assess.Rubric, prompt string, withJob bool, weights assess.Weights, progress *os.File) (*assess.Assessment, error) {
+	providerFor, err := newAssessProvider()
+	if err != nil {
+		return nil, err
+	}
+	adapter := providerFor(assessModel)
+
+	subAgents := buildAssessSubAgents(providerFor, rubric.Select(withJob), withJob, assessSuggest)
+	agent := agentsdk.NewAgent(agentsdk.AgentConfig{
+		Name:         "resume-coordinator",
+		Provider:     adapter,
+		MaxIter:      10,
+		SystemPrompt: coordinatorPrompt(len(subAgents), withJob, weights),
+		SubAgents:    subAgents,
+	})
+	formatter := agentsdk.NewAgent(agentsdk.AgentConfig{
+		Name:         "assessment-formatter",
+		Provider:     adapter,
+		MaxIter:      1,
+		SystemPrompt: "You correct resume assessments that do not match a JSON schema. Respond with only the corrected JSON object.",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	isTTY := term.IsTerminal(int(progress.Fd()))
+	ask := func(ctx context.Context, prompt string) (string, error) {
+		stream := agent.Invoke(ctx, []core.Message{core.NewUserMessage(prompt)})
+		header := buildAssessHeader(agent)
+		if assessVerbose || !isTTY {
+			return runVerbose(stream, header, progress)
+		}
+		return runTUI(stream, header, progress)
+	}
+	repair := func(ctx context.Context, prompt string) (string, error) {
+		sugar.Warn("Assessment did not match the schema; asking the model to correct it")
+		stream := formatter.Invoke(ctx, []core.Message{core.NewUserMessage(prompt)})
+		return finalReport(stream, buildAssessHeader(formatter))
+	}
+	return assess.Run(ctx, ask, repair, prompt, weights, assessRetries)
+}
+
+// renderAssessInput renders the resume through the modern-markdown
+// template, so that the agents read the same text whatever the input
+// format.
+func renderAssessInput(sugar *zap.SugaredLogger, r *resume.Resume) (string, error) {
+	tmpl, err := generators.LoadTemplate("modern-markdown")
+	if err != nil {
+		return "", err
+	}
+	return generators.NewGenerator(sugar).GenerateWithTemplate(tmpl, r)
+}
+
+// editableValues lists the summary and highlights by path, for the
+// analysts to address their edits to.
+func editableValues(r *resume.Resume) string {
+	var b strings.Builder
+	b.WriteString("Editable values (path: text):\n")
+	if r.Summary != "" {
+		fmt.Fprintf(&b, "\n- summary: %s", r.Summary)
+	}
+	for i, p := range r.Experience.Positions {
+		for j, h := range p.Highlights {
+			fmt.Fprintf(&b, "\n- experience.positions[%d].highlights[%d]: %s", i, j, h)
+		}
+	}
+	if r.Projects != nil {
+		for i, p := range r.Projects.Projects {
+			for j, h := range p.Highlights {
+				fmt.Fprintf(&b, "\n- projects.projects[%d].highlights[%d]: %s", i, j, h)
+			}
+		}
+	}
+	return b.String()
+}
+
+// rubricKey spells out the analysts of a rubric, for the cache key.
+func rubricKey(rubric *assess.Rubric) string {
+	data, _ := json.Marshal(rubric.Analysts)
+	return string(data)
+}
+
+// weightsKey spells out weights exactly, for the cache key.
+func weightsKey(weights assess.Weights) string {
+	parts := make([]string, 0, len(weights))
+	for _, name := range weights.Names() {
+		parts = append(parts, fmt.Sprintf("%s=%g", name, weights[name]))
+	}
+	return strings.Join(parts, ",")
+}
+
 func buildAssessHeader(agent *agentsdk.Agent) tui.AgentHeader {
 	info := agent.Info()
 	header := tui.AgentHeader{
@@ -130,167 +389,257 @@ func buildAssessHeader(agent *agentsdk.Agent) tui.AgentHeader {
 	return header
 }
 
-// runVerbose streams all agent output with colored prefixes using the SDK's StreamVerbose.
-func runVerbose(stream *agentsdk.EventStream, header tui.AgentHeader, cancel context.CancelFunc) {
-	result := tui.StreamVerbose(header, stream.Deltas(), os.Stdout)
+// runVerbose streams all agent output with colored prefixes using the SDK's
+// StreamVerbose and returns the coordinator's final answer.
+func runVerbose(stream *agentsdk.EventStream, header tui.AgentHeader, w io.Writer) (string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shown, collected := teeDeltas(ctx, stream.Deltas())
+	reportCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		report, err := collectReport(header, collected)
+		reportCh <- report
+		errCh <- err
+	}()
+
+	result := tui.StreamVerbose(header, shown, w)
+	report, collectErr := <-reportCh, <-errCh
+	fmt.Fprintln(w)
 	if result.Err != nil {
-		cancel()
-		fmt.Fprintf(os.Stderr, "Assessment error: %v\n", result.Err)
-		os.Exit(1)
+		return "", result.Err
+	}
+	if collectErr != nil {
+		return "", collectErr
 	}
-
 	if err := stream.Wait(); err != nil {
-		fmt.Fprintf(os.Stderr, "Assessment failed: %v\n", err)
-		os.Exit(1)
+		return "", err
 	}
-
-	fmt.Println()
+	return report, nil
 }
 
-// runTUI runs the bubbletea progress UI, then prints the final report.
-func runTUI(stream *agentsdk.EventStream, header tui.AgentHeader, cancel context.CancelFunc, sugar *zap.SugaredLogger) {
+// runTUI runs the bubbletea progress UI and returns the coordinator's final
+// answer.
+func runTUI(stream *agentsdk.EventStream, header tui.AgentHeader, w io.Writer) (string, error) {
 	model := tui.NewStreamModel(header, stream.Deltas())
-	p := tea.NewProgram(model)
+	p := tea.NewProgram(model, tea.WithOutput(w))
 
 	finalModel, err := p.Run()
 	if err != nil {
-		cancel()
-		sugar.Fatalf("TUI error: %v", err)
+		return "", fmt.Errorf("TUI error: %w", err)
 	}
 
 	m := finalModel.(tui.StreamModel)
 	if m.Err() != nil {
-		cancel()
-		sugar.Fatalf("Assessment error: %v", m.Err())
+		return "", m.Err()
 	}
-
-	report := m.FinalReport()
-	if report != "" {
-		fmt.Println(tui.RenderReport("Resume Assessment", report))
+	if err := stream.Wait(); err != nil {
+		return "", err
 	}
+	return m.FinalReport(), nil
+}
 
+// finalReport waits for an agent without showing its progress and returns
+// its final answer.
+func finalReport(stream *agentsdk.EventStream, header tui.AgentHeader) (string, error) {
+	report, err := collectReport(header, stream.Deltas())
+	if err != nil {
+		return "", err
+	}
 	if err := stream.Wait(); err != nil {
-		sugar.Fatalf("Assessment failed: %v", err)
+		return "", err
 	}
+	return report, nil
 }
 
-func buildAssessSubAgents(provider core.Provider) []agentsdk.SubAgentDef {
-	return []agentsdk.SubAgentDef{
-		{
-			Name:     "content_analyst",
-			Provider: provider,
-			MaxIter:  1,
-			Description: "Analyzes resume content quality: achievement quantity, use of metrics/numbers, " +
-				"specificity of accomplishments, and demonstrated impact. Delegate the full resume text to this agent.",
-			SystemPrompt: `You are a resume content analyst. Score the resume on CONTENT (1-10) based on:
-
-- **Quantity of achievements**: Does each role have 3-5 strong bullet points? Are there enough concrete accomplishments?
-- **Metrics & numbers**: Are achievements quantified (percentages, dollar amounts, team sizes, timeframes)?
-- **Specificity**: Are bullet points specific to this person's contribution, or generic/vague?
-- **Impact**: Do bullet points show results and outcomes, not just responsibilities?
-
-Output format:
-CONTENT SCORE: X/10
-
-Strengths:
-- ...
-
-Weaknesses:
-- ...
-
-Suggestions:
-- ...
-
-Be direct and specific. Reference actual bullet points from the resume.`,
-		},
-		{
-			Name:     "writing_analyst",
-			Provider: provider,
-			MaxIter:  1,
-			Description: "Analyzes resume writing quality: succinctness, clarity, readability, grammar, " +
-				"and professional tone. Delegate the full resume text to this agent.",
-			SystemPrompt: `You are a resume writing analyst. Score the resume on WRITING QUALITY (1-10) based on:
-
-- **Succinctness**: Are bullet points concise (ideally 1-2 lines)? Is there unnecessary wordiness or filler?
-- **Clarity**: Can a recruiter understand each bullet point in under 5 seconds? Is the language unambiguous?
-- **Readability**: Is sentence structure varied? Are action verbs used consistently? Is parallel structure maintained?
-- **Grammar & mechanics**: Any spelling errors, grammatical issues, or inconsistent punctuation/formatting?
-- **Professional tone**: Is the language professional without being stiff or overly casual?
-
-Output format:
-WRITING SCORE: X/10
-
-Strengths:
-- ...
-
-Weaknesses:
-- ...
-
-Suggestions:
-- ...
-
-Be direct and specific. Quote actual phrases from the resume that could be improved.`,
-		},
-		{
-			Name:     "industry_analyst",
-			Provider: provider,
-			MaxIter:  1,
-			Description: "Analyzes resume industry fit: relevant keywords, industry conventions, " +
-				"role-specific expectations, and ATS compatibility. Delegate the full resume text with the target industry/role.",
-			SystemPrompt: `You are a resume industry analyst. The task will include the target industry/role and the resume text. Score on INDUSTRY FIT (1-10) based on:
-
-- **Keywords**: Does the resume include relevant industry/role keywords that ATS systems and recruiters look for?
-- **Conventions**: Does the resume follow the norms for this industry (e.g., tech resumes emphasize projects and skills; sales resumes emphasize revenue and quotas; academic CVs emphasize publications)?
-- **Role alignment**: Do the experiences and skills clearly map to the target role?
-- **Skill relevance**: Are the listed skills current and valued in this industry? Are outdated or irrelevant skills cluttering the resume?
-- **Competitive positioning**: How would this resume compare to a typical applicant pool for this role?
-
-Output format:
-INDUSTRY FIT SCORE: X/10
-
-Target role/industry analyzed: ...
-
-Strengths:
-- ...
-
-Weaknesses:
-- ...
-
-Missing keywords/skills:
-- ...
-
-Suggestions:
-- ...
-
-Be direct and specific to the industry identified.`,
-		},
-		{
-			Name:     "format_analyst",
-			Provider: provider,
-			MaxIter:  1,
-			Description: "Analyzes resume content structure: section ordering, information density, " +
-				"completeness, and length appropriateness. Delegate the full resume text to this agent.",
-			SystemPrompt: `You are a resume structure analyst. The visual formatting is handled automatically by a generator — do NOT evaluate fonts, spacing, bullet styles, or visual hierarchy. Instead, score the resume on STRUCTURE (1-10) based on its content organization:
-
-- **Section ordering**: Are sections ordered by relevance to the target role? (Most impactful sections first)
-- **Length**: Is the amount of content appropriate for the candidate's experience level? (Concise for <10 years, more detail acceptable for senior)
-- **Information density**: Is there redundant or filler content that could be condensed or removed? Are there gaps where more detail is needed?
-- **Section completeness**: Are expected sections present (contact, experience, education, skills)? Are any critical sections missing?
-- **Logical flow**: Does the resume tell a coherent career story? Do sections build on each other logically?
-
-Output format:
-STRUCTURE SCORE: X/10
-
-Strengths:
-- ...
-
-Weaknesses:
-- ...
-
-Suggestions:
-- ...
-
-Be direct and specific about structural improvements. Do not comment on visual formatting — only content organization.`,
-		},
+// collectReport drives the stream model without a terminal to pick the
+// final answer out of the deltas.
+func collectReport(header tui.AgentHeader, deltas <-chan core.Delta) (string, error) {
+	p := tea.NewProgram(tui.NewStreamModel(header, deltas),
+		tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer(), tea.WithoutSignalHandler())
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	m := finalModel.(tui.StreamModel)
+	if m.Err() != nil {
+		return "", m.Err()
 	}
+	return m.FinalReport(), nil
+}
+
+// teeDeltas copies every delta to two channels. Each copy is queued
+// without limit, so a consumer that falls behind or stops reading never
+// stalls the agent or the other consumer. Once ctx is done the remaining
+// deltas are drained and dropped.
+func teeDeltas(ctx context.Context, in <-chan core.Delta) (<-chan core.Delta, <-chan core.Delta) {
+	a := make(chan core.Delta)
+	b := make(chan core.Delta)
+	go func() {
+		defer close(a)
+		defer close(b)
+		var queueA, queueB []core.Delta
+		for in != nil || len(queueA) > 0 || len(queueB) > 0 {
+			var outA, outB chan<- core.Delta
+			var nextA, nextB core.Delta
+			if len(queueA) > 0 {
+				outA, nextA = a, queueA[0]
+			}
+			if len(queueB) > 0 {
+				outB, nextB = b, queueB[0]
+			}
+			select {
+			case d, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queueA = append(queueA, d)
+				queueB = append(queueB, d)
+			case outA <- nextA:
+				queueA = queueA[1:]
+			case outB <- nextB:
+				queueB = queueB[1:]
+			case <-ctx.Done():
+				if in != nil {
+					for range in {
+					}
+				}
+				return
+			}
+		}
+	}()
+	return a, b
+}
+
+// coordinatorPrompt instructs the coordinator to delegate to every analyst
+// and combine their reports.
+func coordinatorPrompt(analysts int, withJob bool, weights assess.Weights) string {
+	identify := "Read the resume carefully and identify the candidate's target industry/role."
+	pass := "Pass the full resume text as the task to each one, prefixed with the target industry/role you identified."
+	roleField := "the target industry/role identified"
+	if withJob {
+		identify = "Read the job posting and the resume carefully. The target role is the one in the job posting."
+		pass = "Pass the full job posting and the full resume text as the task to each one."
+		roleField = "the role and company of the job posting"
+	}
+	return fmt.Sprintf(`You are a senior resume review coordinator. You have %[1]d specialist analysts available.
+
+Your process:
+1. %[2]s
+2. Delegate to ALL %[1]d analysts by calling each delegate tool. %[3]s
+3. After receiving all %[1]d reports, combine them into a single JSON object with:
+   - "role": %[4]s
+   - "dimensions": the analyst reports exactly as returned
+   - "priorities": the top 3 improvements, the most impactful changes across all dimensions, favouring the heavier dimensions (%[5]s)
+
+Always delegate to every analyst. Do not skip any. Respond with only the JSON object, following this schema:
+
+%[6]s`, analysts, identify, pass, roleField, weights, assess.Schema())
+}
+
+// buildAssessSubAgents turns the analysts of a rubric into sub-agents.
+// providerFor returns the provider of an analyst's model.
+func buildAssessSubAgents(providerFor func(model string) core.Provider, analysts []assess.Analyst, withJob, suggest bool) []agentsdk.SubAgentDef {
+	defs := make([]agentsdk.SubAgentDef, len(analysts))
+	for i, a := range analysts {
+		prompt := strings.TrimSpace(a.Prompt) + "\n\n" + analystOutput(a.Dimension)
+		if withJob && a.Weight > 0 {
+			// Analysts that also run without a job posting are told to
+			// judge the resume for this one.
+			prompt += "\n\nThe task includes a job posting. Judge the resume for that specific opening, not in the abstract."
+		}
+		if suggest && a.Suggest {
+			prompt += "\n\n" + editInstructions
+		}
+		model := a.Model
+		if model == "" {
+			model = assessModel
+		}
+		defs[i] = agentsdk.SubAgentDef{
+			Name:         a.Name,
+			Provider:     providerFor(model),
+			MaxIter:      1,
+			Description:  strings.TrimSpace(a.Description),
+			SystemPrompt: prompt,
+		}
+	}
+	return defs
+}
+
+// editInstructions asks an analyst for edits that can be applied to the
+// resume as they are.
+const editInstructions = `Also propose up to 5 concrete rewrites in "edits". Each edit replaces one value of the resume:
+
+- "path": the path of the value as listed under "Editable values" in the task, such as "summary" or "experience.positions[2].highlights[1]"
+- "original": the current value at that path, copied exactly
+- "replacement": the full new value, using only facts already in the resume
+- "reason": one sentence on what the rewrite improves
+
+Only edit the values listed under "Editable values".`
+
+// reviewEdits checks proposed edits against the resume, lets the user
+// accept or reject each one and writes the accepted edits to the file.
+// Edits are only listed when there is no terminal to review them on.
+func reviewEdits(path string, data []byte, edits []assess.Edit, w *os.File) error {
+	values, err := resume.YAMLScalars(data)
+	if err != nil {
+		return err
+	}
+	var valid []assess.Edit
+	for _, edit := range edits {
+		current, ok := values[edit.Path]
+		switch {
+		case !ok:
+			fmt.Fprintf(w, "Skipping edit of %s: no such value in the resume\n", edit.Path)
+		case strings.TrimSpace(current) != strings.TrimSpace(edit.Original):
+			fmt.Fprintf(w, "Skipping edit of %s: the original text does not match the resume\n", edit.Path)
+		default:
+			valid = append(valid, edit)
+		}
+	}
+	if len(valid) == 0 {
+		fmt.Fprintln(w, "No applicable edits were proposed.")
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(w.Fd())) {
+		fmt.Fprintf(w, "%d edit(s) proposed; run in a terminal to review them.\n", len(valid))
+		return nil
+	}
+
+	finalModel, err := tea.NewProgram(newReviewModel(valid), tea.WithOutput(w)).Run()
+	if err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+	accepted := finalModel.(reviewModel).Accepted()
+	if len(accepted) == 0 {
+		fmt.Fprintln(w, "No edits accepted.")
+		return nil
+	}
+
+	textEdits := make([]resume.TextEdit, len(accepted))
+	for i, edit := range accepted {
+		textEdits[i] = resume.TextEdit{Path: edit.Path, Original: edit.Original, Replacement: edit.Replacement}
+	}
+	out, err := resume.ApplyYAMLEdits(data, textEdits)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Applied %d of %d edit(s) to %s\n", len(accepted), len(valid), path)
+	return nil
+}
+
+// analystOutput tells an analyst to answer with a JSON report of its
+// dimension.
+func analystOutput(dimension string) string {
+	return fmt.Sprintf(`Respond with only a JSON object with "dimension" set to %q, following this schema:
+
+%s`, dimension, assess.DimensionSchema())
 }
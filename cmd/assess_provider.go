@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urmzd/adk/core"
+	"github.com/urmzd/adk/provider/ollama"
+	"github.com/urmzd/adk/provider/openai"
+	"github.com/urmzd/resume-generator/pkg/assess"
+)
+
+// newAssessProvider checks that the server selected by --provider answers
+// and returns a function giving its provider for a model.
+func newAssessProvider() (func(model string) core.Provider, error) {
+	apiKey := ""
+	if assessAPIKeyEnv != "" {
+		apiKey = os.Getenv(assessAPIKeyEnv)
+	}
+	endpoint, err := assess.ResolveEndpoint(assessProvider, assessBaseURL, assessOllamaURL, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch endpoint.Provider {
+	case "openai":
+		if err := endpoint.Probe(assessAPIKeyEnv); err != nil {
+			return nil, fmt.Errorf("no OpenAI-compatible server is available at %s. Start one (llama.cpp server, vLLM, LM Studio) or pass --base-url.\n  Error: %v", endpoint.BaseURL, err)
+		}
+		return func(model string) core.Provider {
+			return openai.NewAdapter(openai.NewClient(endpoint.APIKey, model, endpoint.BaseURL))
+		}, nil
+	default:
+		// Ollama takes no key, so none is sent with the probe either.
+		endpoint.APIKey = ""
+		if err := endpoint.Probe(assessAPIKeyEnv); err != nil {
+			return nil, fmt.Errorf("Ollama is not available at %s. Install Ollama (https://ollama.com) and start it with 'ollama serve'.\n  Error: %v", endpoint.BaseURL, err)
+		}
+		return func(model string) core.Provider {
+			return ollama.NewAdapter(ollama.NewClient(endpoint.BaseURL, model, ""))
+		}, nil
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/urmzd/resume-generator/pkg/assess"
+)
+
+var (
+	reviewTitle   = lipgloss.NewStyle().Bold(true)
+	reviewPath    = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	reviewRemoved = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	reviewAdded   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	reviewMuted   = lipgloss.NewStyle().Faint(true)
+)
+
+// reviewModel shows proposed edits one at a time and records which the
+// user accepts.
+type reviewModel struct {
+	edits    []assess.Edit
+	accepted []bool
+	current  int
+}
+
+func newReviewModel(edits []assess.Edit) reviewModel {
+	return reviewModel{edits: edits, accepted: make([]bool, len(edits))}
+}
+
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.String() {
+	case "y", "enter":
+		m.accepted[m.current] = true
+		m.current++
+	case "n", "backspace":
+		m.current++
+	case "a":
+		for i := m.current; i < len(m.edits); i++ {
+			m.accepted[i] = true
+		}
+		m.current = len(m.edits)
+	case "q", "esc":
+		m.current = len(m.edits)
+	case "ctrl+c":
+		// Abandon the review without writing anything.
+		for i := range m.accepted {
+			m.accepted[i] = false
+		}
+		return m, tea.Quit
+	}
+	if m.current >= len(m.edits) {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m reviewModel) View() string {
+	if m.current >= len(m.edits) {
+		return ""
+	}
+	edit := m.edits[m.current]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n\n", reviewTitle.Render(fmt.Sprintf("Edit %d of %d", m.current+1, len(m.edits))), reviewPath.Render(edit.Path))
+	if edit.Reason != "" {
+		fmt.Fprintf(&b, "%s\n\n", edit.Reason)
+	}
+	fmt.Fprintf(&b, "%s\n%s\n\n", reviewRemoved.Render("- "+edit.Original), reviewAdded.Render("+ "+edit.Replacement))
+	b.WriteString(reviewMuted.Render("y accept · n reject · a accept the rest · q reject the rest · ctrl+c cancel"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Accepted returns the edits the user accepted.
+func (m reviewModel) Accepted() []assess.Edit {
+	var out []assess.Edit
+	for i, ok := range m.accepted {
+		if ok {
+			out = append(out, m.edits[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/assess"
+	"go.uber.org/zap"
+)
+
+var assessRubricsCmd = &cobra.Command{
+	Use:   "rubrics",
+	Short: "Manage assessment rubrics",
+	Long: `A rubric names the analysts of an assessment, their prompts, their weights
+and optionally the model each one uses. Rubric files are YAML:
+
+  name: clearance
+  description: Review for cleared defense roles
+  analysts:
+    - name: clearance_analyst
+      dimension: clearance
+      weight: 30        # share of the overall score
+      job_weight: 20    # share with --job (default: weight)
+      model: qwen3:14b  # default: --model
+      suggest: false    # proposes edits with --suggest
+      description: Reviews clearance eligibility. Delegate the full resume text.
+      prompt: |-
+        You are a security clearance reviewer. Score the resume on CLEARANCE (1-10) ...
+
+An analyst with only a job_weight runs only with --job. The analyst scoring
+the "fit" dimension must list the requirements of the job posting.
+
+Rubric files in $RESUME_RUBRICS_DIR, or resume-generator/rubrics in the user
+config directory, are picked up by name, and replace a built-in rubric of the
+same name; each file is named after its rubric, as clearance.yml for
+"name: clearance". 'assess --rubric' also takes the path to a rubric file.`,
+}
+
+var assessRubricsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available assessment rubrics",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		dir, err := assess.RubricDir()
+		if err != nil {
+			sugar.Warnf("Only built-in rubrics are listed: %v", err)
+		}
+		rubrics, skipped, err := assess.ListRubrics(dir)
+		if err != nil {
+			sugar.Fatalf("Failed to list rubrics: %v", err)
+		}
+		for _, err := range skipped {
+			sugar.Warnf("Skipping rubric: %v", err)
+		}
+		printRubrics(os.Stdout, rubrics)
+		if dir != "" {
+			fmt.Printf("User rubrics are read from %s\n", dir)
+		}
+	},
+}
+
+// printRubrics writes each rubric with its analysts and weights.
+func printRubrics(w io.Writer, rubrics []*assess.Rubric) {
+	for _, r := range rubrics {
+		fmt.Fprintf(w, "%s (%s)\n", r.Name, r.Source)
+		if r.Description != "" {
+			fmt.Fprintf(w, "    %s\n", r.Description)
+		}
+		for _, a := range r.Analysts {
+			var details []string
+			if a.Weight == 0 {
+				details = append(details, "with --job only")
+			}
+			if a.Model != "" {
+				details = append(details, "model "+a.Model)
+			}
+			if a.Suggest {
+				details = append(details, "suggests edits")
+			}
+			line := fmt.Sprintf("    - %s: %s", a.Name, a.Dimension)
+			if len(details) > 0 {
+				line += " (" + strings.Join(details, ", ") + ")"
+			}
+			fmt.Fprintln(w, line)
+		}
+		if weights := r.Weights(false); len(weights) > 0 {
+			fmt.Fprintf(w, "    weights: %s\n", weights)
+		}
+		fmt.Fprintf(w, "    weights with --job: %s\n\n", r.Weights(true))
+	}
+}
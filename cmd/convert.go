@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/generators"
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"github.com/urmzd/resume-generator/pkg/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ConvertOutput string
+	ConvertTo     string
+	ConvertForce  bool
+)
+
+// maxReportedLosses caps how many lost fields a conversion lists.
+const maxReportedLosses = 15
+
+// convertExtensions maps a target format to the extension of converted files.
+var convertExtensions = map[string]string{
+	"yaml": ".yml",
+	"json": ".json",
+	"toml": ".toml",
+	"md":   ".md",
+}
+
+func initConvertCmd() {
+	rootCmd.AddCommand(convertCmd)
+	convertCmd.Flags().StringVarP(&InputFile, "input", "i", "", "Resume file or directory to convert, or - for standard input")
+	convertCmd.Flags().StringVarP(&ConvertOutput, "output", "o", "", "Output file or directory (defaults to stdout for a single file, or the input directory)")
+	convertCmd.Flags().StringVar(&ConvertTo, "to", "", "Target format (yaml, json, toml, md). Inferred from the output extension when omitted.")
+	convertCmd.Flags().BoolVar(&ConvertForce, "force", false, "Overwrite existing files when converting a directory")
+	convertCmd.Flags().StringVar(&InputFormat, "input-format", "", "Input format (yaml, json, jsonc, toml, md). Detected from the extension or content when omitted.")
+	_ = convertCmd.MarkFlagRequired("input")
+}
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert resume data between YAML, JSON, TOML and Markdown",
+	Long: `Convert resume data between YAML, JSON, TOML and Markdown.
+
+Output is canonical: dates drop their time of day, and empty optional sections
+are left out. Each conversion is parsed back and compared with the input, and
+any field the target format cannot carry (for example Markdown dropping the
+layout section) is reported as a warning.
+
+Examples:
+  # Convert a single file; the format follows the output extension
+  resume-generator convert -i resume.yml -o resume.toml
+
+  # Write to stdout
+  resume-generator convert -i resume.json --to yaml
+
+  # Convert every resume in a directory
+  resume-generator convert -i resumes/ -o converted/ --to json
+
+When converting a directory, a file whose converted name already exists,
+such as resume.json next to resume.yml with --to yaml, is reported and
+left alone unless --force is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		target := resume.NormalizeFormat(ConvertTo)
+		if ConvertTo != "" && (target == "" || target == "jsonc") {
+			sugar.Fatalf("Unsupported target format %q (supported: yaml, json, toml, md)", ConvertTo)
+		}
+
+		if InputFile != stdinInput {
+			inputPath, err := utils.ResolvePath(InputFile)
+			if err != nil {
+				sugar.Fatalf("Error resolving input path: %v", err)
+			}
+			if utils.DirExists(inputPath) {
+				if target == "" {
+					sugar.Fatalf("--to is required when converting a directory")
+				}
+				if err := convertDirectory(sugar, inputPath, ConvertOutput, target, ConvertForce); err != nil {
+					sugar.Fatalf("%v", err)
+				}
+				return
+			}
+		}
+
+		toStdout := ConvertOutput == "" || ConvertOutput == "-"
+		if target == "" && !toStdout {
+			target = resume.NormalizeFormat(strings.TrimPrefix(filepath.Ext(ConvertOutput), "."))
+		}
+		if target == "" || target == "jsonc" {
+			sugar.Fatalf("Cannot infer the target format; pass --to (yaml, json, toml, md)")
+		}
+
+		inputData, inputPath, err := loadInput(InputFile, InputFormat)
+		if err != nil {
+			sugar.Fatalf("%v", err)
+		}
+		data, lost, err := convertResume(sugar, inputData.ToResume(), target)
+		if err != nil {
+			sugar.Fatalf("Failed to convert %s: %v", inputPath, err)
+		}
+		warnLostFields(sugar, inputPath, target, lost)
+
+		if toStdout {
+			if _, err := os.Stdout.Write(data); err != nil {
+				sugar.Fatalf("Failed to write to stdout: %v", err)
+			}
+			return
+		}
+
+		outputPath, err := utils.ResolvePath(ConvertOutput)
+		if err != nil {
+			sugar.Fatalf("Error resolving output path: %v", err)
+		}
+		if outputPath == inputPath {
+			sugar.Fatalf("Refusing to overwrite the input file %s", inputPath)
+		}
+		if err := os.WriteFile(outputPath, data, 0644); err != nil {
+			sugar.Fatalf("Failed to write %s: %v", outputPath, err)
+		}
+		sugar.Infof("Converted %s (%s) to %s (%s)", inputPath, inputData.GetFormat(), outputPath, target)
+	},
+}
+
+// convertDirectory converts every recognized resume file directly inside
+// inputDir to target, writing into outputDir (inputDir when empty). Files
+// already in the target format are skipped. Existing files are only
+// replaced when overwrite is set.
+func convertDirectory(logger *zap.SugaredLogger, inputDir, outputDir, target string, overwrite bool) error {
+	if outputDir == "" {
+		outputDir = inputDir
+	}
+	outputDir, err := utils.ResolvePath(outputDir)
+	if err != nil {
+		return fmt.Errorf("error resolving output directory: %w", err)
+	}
+	if err := utils.EnsureDir(outputDir); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(inputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", inputDir, err)
+	}
+
+	written := make(map[string]string)
+	var failed []string
+	converted := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		format := resume.FormatFromPath(name)
+		if entry.IsDir() || format == "" {
+			continue
+		}
+		if format == target {
+			logger.Infof("Skipping %s; already %s", name, target)
+			continue
+		}
+
+		outName := strings.TrimSuffix(name, filepath.Ext(name)) + convertExtensions[target]
+		if previous, ok := written[outName]; ok {
+			logger.Errorf("Skipping %s; %s was already written from %s", name, outName, previous)
+			failed = append(failed, name)
+			continue
+		}
+		outputPath := filepath.Join(outputDir, outName)
+		if !overwrite && utils.FileExists(outputPath) {
+			logger.Errorf("Skipping %s; %s already exists (pass --force to overwrite it)", name, outputPath)
+			failed = append(failed, name)
+			continue
+		}
+
+		inputPath := filepath.Join(inputDir, name)
+		inputData, err := resume.LoadResumeFromFile(inputPath)
+		if err != nil {
+			logger.Errorf("Failed to load %s: %v", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		data, lost, err := convertResume(logger, inputData.ToResume(), target)
+		if err != nil {
+			logger.Errorf("Failed to convert %s: %v", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		warnLostFields(logger, name, target, lost)
+
+		if err := os.WriteFile(outputPath, data, 0644); err != nil {
+			logger.Errorf("Failed to write %s: %v", outName, err)
+			failed = append(failed, name)
+			continue
+		}
+		written[outName] = name
+		converted++
+	}
+
+	logger.Infof("Converted %d file(s) to %s in %s", converted, target, outputDir)
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to convert %d file(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// convertResume serializes r as target and reports the fields that do not
+// survive parsing the result back.
+func convertResume(logger *zap.SugaredLogger, r *resume.Resume, target string) ([]byte, []string, error) {
+	resume.Canonicalize(r)
+
+	var data []byte
+	if target == "md" {
+		// Markdown is written through the same template its parser reads
+		tmpl, err := generators.LoadTemplate("modern-markdown")
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := generators.NewGenerator(logger).GenerateWithTemplate(tmpl, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = []byte(content)
+	} else {
+		var err error
+		if data, _, err = resume.SerializeResume(r, target); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	parsed, err := resume.LoadResumeFromBytes(data, target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("converted %s does not parse back: %w", target, err)
+	}
+	roundTripped := parsed.ToResume()
+	resume.Canonicalize(roundTripped)
+	lost, err := resume.FieldDifferences(r, roundTripped)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, lost, nil
+}
+
+func warnLostFields(logger *zap.SugaredLogger, source, target string, lost []string) {
+	if len(lost) == 0 {
+		return
+	}
+	sort.Strings(lost)
+	shown := lost
+	if len(shown) > maxReportedLosses {
+		shown = shown[:maxReportedLosses]
+	}
+	logger.Warnf("Converting %s to %s loses or changes %d field(s): %s", source, target, len(lost), strings.Join(shown, ", "))
+	if len(lost) > len(shown) {
+		logger.Warnf("...and %d more", len(lost)-len(shown))
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+func TestConvertDirectoryKeepsExistingFiles(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	original, err := os.ReadFile(filepath.Join("..", "assets", "example_resumes", "software_engineer.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := resume.LoadResumeFromBytes(original, "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	asJSON, _, err := convertResume(logger, parsed.ToResume(), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	ymlPath := filepath.Join(dir, "resume.yml")
+	if err := os.WriteFile(ymlPath, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "resume.json"), asJSON, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = convertDirectory(logger, dir, "", "yaml", false)
+	if err == nil || !strings.Contains(err.Error(), "resume.json") {
+		t.Errorf("convertDirectory() error = %v, want resume.json reported", err)
+	}
+	if got, _ := os.ReadFile(ymlPath); string(got) != string(original) {
+		t.Error("convertDirectory() replaced the existing resume.yml")
+	}
+
+	if err := convertDirectory(logger, dir, "", "yaml", true); err != nil {
+		t.Errorf("convertDirectory() with overwrite error = %v", err)
+	}
+	if got, _ := os.ReadFile(ymlPath); string(got) == string(original) {
+		t.Error("convertDirectory() with overwrite left resume.yml as it was")
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/generators"
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+var (
+	DiffHTMLOutput string
+	DiffDOCXOutput string
+	DiffTemplate   string
+	DiffFormat     string
+	DiffExitCode   bool
+)
+
+func initDiffCmd() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&DiffHTMLOutput, "html", "", "Write a redlined HTML rendering of the new resume to this path")
+	diffCmd.Flags().StringVar(&DiffDOCXOutput, "docx", "", "Write a DOCX of the new resume with the changes as tracked changes to this path")
+	diffCmd.Flags().StringVar(&DiffTemplate, "template", "modern-html", "HTML template used for --html")
+	diffCmd.Flags().StringVar(&DiffFormat, "format", "text", "Report format: text or json")
+	diffCmd.Flags().BoolVar(&DiffExitCode, "exit-code", false, "Exit with status 1 when the resumes differ")
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff old new",
+	Short: "Show semantic changes between two versions of a resume",
+	Long: `Diff compares two resume files by entity rather than by line: positions are
+matched by company and title, projects by name and institutions by name and
+degree, so reordering is not a change. It reports added and removed entities,
+reworded bullets and changed fields such as dates.
+
+Examples:
+  # Review changes in a pull request
+  resume-generator diff <(git show main:resume.yml) resume.yml
+
+  # Redlined HTML and a DOCX with tracked changes for reviewers
+  resume-generator diff old.yml new.yml --html redline.html --docx redline.docx`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		if DiffFormat != "text" && DiffFormat != "json" {
+			sugar.Fatalf("Unsupported report format %q (supported: text, json)", DiffFormat)
+		}
+
+		oldData, _, err := loadInput(args[0], "")
+		if err != nil {
+			sugar.Fatalf("%v", err)
+		}
+		newData, _, err := loadInput(args[1], "")
+		if err != nil {
+			sugar.Fatalf("%v", err)
+		}
+		oldResume, newResume := oldData.ToResume(), newData.ToResume()
+
+		changes := resume.DiffResumes(oldResume, newResume)
+		if err := printChanges(changes, DiffFormat); err != nil {
+			sugar.Fatalf("Failed to write report: %v", err)
+		}
+
+		generator := generators.NewGenerator(sugar)
+		if DiffHTMLOutput != "" {
+			tmpl, err := generators.LoadTemplate(DiffTemplate)
+			if err != nil {
+				sugar.Fatalf("Failed to load template %s: %v", DiffTemplate, err)
+			}
+			content, err := generator.GenerateRedlineHTML(tmpl, oldResume, newResume)
+			if err != nil {
+				sugar.Fatalf("Failed to render redline HTML: %v", err)
+			}
+			if err := os.WriteFile(DiffHTMLOutput, []byte(content), 0644); err != nil {
+				sugar.Fatalf("Failed to write %s: %v", DiffHTMLOutput, err)
+			}
+			sugar.Infof("Wrote redlined HTML to %s", DiffHTMLOutput)
+		}
+		if DiffDOCXOutput != "" {
+			docxBytes, err := generator.GenerateRedlineDOCX(oldResume, newResume)
+			if err != nil {
+				sugar.Fatalf("Failed to render redline DOCX: %v", err)
+			}
+			if err := os.WriteFile(DiffDOCXOutput, docxBytes, 0644); err != nil {
+				sugar.Fatalf("Failed to write %s: %v", DiffDOCXOutput, err)
+			}
+			sugar.Infof("Wrote DOCX with tracked changes to %s", DiffDOCXOutput)
+		}
+
+		if DiffExitCode && len(changes) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func printChanges(changes []resume.Change, format string) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []resume.Change{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No changes.")
+		return nil
+	}
+	symbols := map[string]string{
+		resume.ChangeAdded:    "+",
+		resume.ChangeRemoved:  "-",
+		resume.ChangeModified: "~",
+		resume.ChangeReworded: "~",
+	}
+	for _, c := range changes {
+		fmt.Printf("%s %s\n", symbols[c.Kind], c)
+	}
+	return nil
+}
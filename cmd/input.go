@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"github.com/urmzd/resume-generator/pkg/utils"
+)
+
+// stdinInput is the input path that reads resume data from standard input.
+const stdinInput = "-"
+
+// loadInput loads resume data from path, or from standard input when path is
+// "-". A non-empty format overrides detection from the extension and content.
+// It returns the resolved path, which is "-" for standard input.
+func loadInput(path, format string) (resume.InputData, string, error) {
+	if path == stdinInput {
+		inputData, err := resume.LoadResumeFromReader(os.Stdin, format)
+		if err != nil {
+			return nil, path, fmt.Errorf("failed to load resume data from stdin: %w", err)
+		}
+		// Relative image paths follow the working directory for piped input.
+		if wd, err := os.Getwd(); err == nil {
+			inputData.ToResume().ResolveImages(wd)
+		}
+		return inputData, path, nil
+	}
+
+	resolved, err := utils.ResolvePath(path)
+	if err != nil {
+		return nil, path, fmt.Errorf("error resolving input path: %w", err)
+	}
+	if !utils.FileExists(resolved) {
+		return nil, resolved, fmt.Errorf("input file does not exist: %s", resolved)
+	}
+	inputData, err := resume.LoadResumeFromFileAs(resolved, format)
+	if err != nil {
+		return nil, resolved, fmt.Errorf("failed to load resume data: %w", err)
+	}
+	return inputData, resolved, nil
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/lint"
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"github.com/urmzd/resume-generator/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// lintConfigName is the config file lint picks up next to the input.
+const lintConfigName = ".resumelint.yml"
+
+var (
+	LintConfig string
+	LintFormat string
+)
+
+func initLintCmd() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringVarP(&InputFile, "input", "i", "", "Path to the resume data file (e.g., resume.yml), or - for standard input")
+	lintCmd.Flags().StringVar(&InputFormat, "input-format", "", "Input format (yaml, json, jsonc, toml, md). Detected from the extension or content when omitted.")
+	lintCmd.Flags().StringVar(&LintConfig, "config", "", "Rule config file (default: "+lintConfigName+" next to the input, if present)")
+	lintCmd.Flags().StringVar(&LintFormat, "format", "text", "Report format: text or json")
+	_ = lintCmd.MarkFlagRequired("input")
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint -i resume.yml",
+	Short: "Check resume writing against style rules, offline",
+	Long: `Lint checks the summary and highlights of a resume against writing rules,
+without a language model:
+
+  action-verb     bullets start with an action verb
+  tense           past roles in the past tense, the current role in the present
+  quantification  bullets contain a number, percentage or amount
+  length          bullets are at most max_words words (default 30)
+  duplicate       no bullet repeats or nearly repeats another
+  filler          no filler phrases such as "responsible for"
+  passive-voice   bullets use the active voice
+  readability     text reads at or below grade max_grade (default 16)
+
+Each rule reports at error, warning or info severity, or is turned off, in a
+YAML config file:
+
+  rules:
+    quantification: error
+    passive-voice: off
+  max_words: 25
+
+In YAML resumes, a "# lint:ignore" comment on a value or on the line above it
+suppresses every rule for that value and what is nested in it; "# lint:ignore
+filler,length" suppresses only those rules.
+
+Lint exits with status 1 when any issue has error severity.
+
+Examples:
+  resume-generator lint -i resume.yml
+  resume-generator lint -i resume.yml --config lint.yml --format json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		if LintFormat != "text" && LintFormat != "json" {
+			sugar.Fatalf("Unsupported report format %q (supported: text, json)", LintFormat)
+		}
+
+		data, name, err := readLintInput(InputFile)
+		if err != nil {
+			sugar.Fatalf("%v", err)
+		}
+		format := InputFormat
+		if format == "" && name != stdinInput {
+			format = resume.FormatFromPath(name)
+		}
+		inputData, err := resume.LoadResumeFromBytes(data, format)
+		if err != nil {
+			sugar.Fatalf("Failed to load resume data: %v", err)
+		}
+
+		cfg := lint.DefaultConfig()
+		configPath := LintConfig
+		if configPath == "" && name != stdinInput {
+			if candidate := filepath.Join(filepath.Dir(name), lintConfigName); utils.FileExists(candidate) {
+				configPath = candidate
+			}
+		}
+		if configPath != "" {
+			if cfg, err = lint.LoadConfig(configPath); err != nil {
+				sugar.Fatalf("Failed to load lint config: %v", err)
+			}
+		}
+
+		issues := lint.Lint(inputData.ToResume(), cfg)
+		if inputData.GetFormat() == "yaml" {
+			source, err := lint.ParseSource(data)
+			if err != nil {
+				sugar.Fatalf("Failed to read comments: %v", err)
+			}
+			for _, rule := range source.UnknownRules() {
+				sugar.Warnf("lint:ignore names unknown rule %q", rule)
+			}
+			issues = source.Apply(issues)
+		}
+
+		if LintFormat == "json" {
+			if issues == nil {
+				issues = []lint.Issue{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(issues); err != nil {
+				sugar.Fatalf("Failed to write report: %v", err)
+			}
+		} else {
+			printLintIssues(os.Stdout, filepath.Base(name), issues)
+		}
+
+		for _, issue := range issues {
+			if issue.Severity == lint.Error {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+// readLintInput reads the raw resume data, which lint needs for comments.
+// It returns the resolved path, or "-" for standard input.
+func readLintInput(path string) ([]byte, string, error) {
+	if path == stdinInput {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, path, fmt.Errorf("failed to read resume data from stdin: %w", err)
+		}
+		return data, path, nil
+	}
+	resolved, err := utils.ResolvePath(path)
+	if err != nil {
+		return nil, path, fmt.Errorf("error resolving input path: %w", err)
+	}
+	if !utils.FileExists(resolved) {
+		return nil, resolved, fmt.Errorf("input file does not exist: %s", resolved)
+	}
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return nil, resolved, fmt.Errorf("failed to read resume data: %w", err)
+	}
+	return data, resolved, nil
+}
+
+// printLintIssues writes one line per issue, prefixed with its line in the
+// input when known, and a count by severity.
+func printLintIssues(w io.Writer, name string, issues []lint.Issue) {
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "No issues.")
+		return
+	}
+	counts := map[lint.Severity]int{}
+	for _, issue := range issues {
+		counts[issue.Severity]++
+		if issue.Line > 0 {
+			fmt.Fprintf(w, "%s:%d: %s\n", name, issue.Line, issue)
+		} else {
+			fmt.Fprintf(w, "%s: %s\n", name, issue)
+		}
+	}
+	fmt.Fprintf(w, "\n%d issue(s): %d error(s), %d warning(s), %d info\n",
+		len(issues), counts[lint.Error], counts[lint.Warning], counts[lint.Info])
+}
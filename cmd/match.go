@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/match"
+	"go.uber.org/zap"
+)
+
+var (
+	MatchJob        string
+	MatchFormat     string
+	MatchEmbeddings bool
+	MatchEmbedModel string
+	MatchOllamaURL  string
+	MatchSimilarity float64
+)
+
+func initMatchCmd() {
+	rootCmd.AddCommand(matchCmd)
+	matchCmd.Flags().StringVarP(&InputFile, "input", "i", "", "Path to the resume data file (e.g., resume.yml), or - for standard input")
+	matchCmd.Flags().StringVar(&InputFormat, "input-format", "", "Input format (yaml, json, jsonc, toml, md). Detected from the extension or content when omitted.")
+	matchCmd.Flags().StringVar(&MatchJob, "job", "", "Plain-text job posting to match against")
+	matchCmd.Flags().StringVar(&MatchFormat, "format", "text", "Report format: text or json")
+	matchCmd.Flags().BoolVar(&MatchEmbeddings, "embeddings", false, "Relate missing terms to resume text by embedding similarity through Ollama")
+	matchCmd.Flags().StringVar(&MatchEmbedModel, "embed-model", "nomic-embed-text", "Ollama embedding model used with --embeddings")
+	matchCmd.Flags().StringVar(&MatchOllamaURL, "ollama-url", "http://localhost:11434", "Ollama server URL")
+	matchCmd.Flags().Float64Var(&MatchSimilarity, "similarity", match.DefaultSimilarity, "Cosine similarity from which --embeddings counts resume text as related")
+	_ = matchCmd.MarkFlagRequired("input")
+	_ = matchCmd.MarkFlagRequired("job")
+}
+
+var matchCmd = &cobra.Command{
+	Use:   "match -i resume.yml --job posting.txt",
+	Short: "Report how well a resume covers the key phrases of a job posting",
+	Long: `Match extracts the key phrases of a job posting and looks each one up in the
+skills, technologies, certifications, highlights and summary of the resume.
+
+Key phrases are the languages, frameworks, tools, practices and certifications
+of a bundled skills dictionary that the posting mentions, plus the words and
+word pairs it repeats that are uncommon in job postings in general (TF-IDF).
+Each term is reported as:
+
+  covered  listed as a skill or technology and backed by a highlight or the
+           summary, or mentioned in two or more places
+  weak     mentioned once, or listed without a highlight to back it
+  missing  not in the resume
+
+The overall score is the share of covered terms weighted by importance, with
+weak terms counting half. Matching runs offline and gives the same result for
+the same inputs. With --embeddings, missing terms are also compared with the
+resume text through a local Ollama embedding model, so a paraphrase counts as
+weak coverage.
+
+Examples:
+  resume-generator match -i resume.yml --job posting.txt
+  resume-generator match -i resume.yml --job posting.txt --format json
+  resume-generator match -i resume.yml --job posting.txt --embeddings`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		if MatchFormat != "text" && MatchFormat != "json" {
+			sugar.Fatalf("Unsupported report format %q (supported: text, json)", MatchFormat)
+		}
+
+		inputData, _, err := loadInput(InputFile, InputFormat)
+		if err != nil {
+			sugar.Fatalf("%v", err)
+		}
+		resumeData := inputData.ToResume()
+
+		posting, err := os.ReadFile(MatchJob)
+		if err != nil {
+			sugar.Fatalf("Failed to read job posting: %v", err)
+		}
+
+		report := match.Analyze(string(posting), resumeData)
+		if MatchEmbeddings {
+			embedder := &match.OllamaEmbedder{
+				URL:    MatchOllamaURL,
+				Model:  MatchEmbedModel,
+				Client: &http.Client{Timeout: 2 * time.Minute},
+			}
+			if err := match.Relate(context.Background(), report, resumeData, embedder, MatchSimilarity); err != nil {
+				sugar.Fatalf("Failed to compare with embeddings (is Ollama running at %s with %s pulled?): %v", MatchOllamaURL, MatchEmbedModel, err)
+			}
+		}
+
+		if MatchFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				sugar.Fatalf("Failed to write report: %v", err)
+			}
+			return
+		}
+		printMatchReport(os.Stdout, report)
+	},
+}
+
+// printMatchReport writes the overall score and the terms grouped by
+// coverage, weakest first.
+func printMatchReport(w io.Writer, report *match.Report) {
+	fmt.Fprintf(w, "Coverage: %d/100 (%d key phrases)\n", report.Score, len(report.Terms))
+	for _, c := range []match.Coverage{match.Missing, match.Weak, match.Covered} {
+		terms := report.Filter(c)
+		if len(terms) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s (%d)\n", strings.ToUpper(string(c)), len(terms))
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, t := range terms {
+			detail := strings.Join(t.Evidence, "; ")
+			if t.Related != "" {
+				detail = fmt.Sprintf("related (%.2f): %s", t.Similarity, t.Related)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%.2f\t%s\n", t.Term, t.Category, t.Weight, detail)
+		}
+		tw.Flush()
+	}
+}
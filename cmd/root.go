@@ -9,6 +9,7 @@ import (
 
 var (
 	InputFile     string
+	InputFormat   string
 	GeneratorType string
 	LaTeXEngine   string
 
@@ -27,6 +28,13 @@ func initRootCmd() {
 	initSchemaCmd()
 	initScreenshotsCmd()
 	initAssessCmd()
+	initSkillsCmd()
+	initConvertCmd()
+	initDiffCmd()
+	initATSCheckCmd()
+	initMatchCmd()
+	initLintCmd()
+	initTranslateCmd()
 	rootCmd.PersistentFlags().StringVarP(&GeneratorType, "generator", "g", "base", "The type of generator to use (e.g., base, json-resume)")
 }
 
@@ -2,34 +2,48 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/ats"
 	"github.com/urmzd/resume-generator/pkg/compilers"
 	"github.com/urmzd/resume-generator/pkg/generators"
+	"github.com/urmzd/resume-generator/pkg/pdf"
 	"github.com/urmzd/resume-generator/pkg/resume"
 	"github.com/urmzd/resume-generator/pkg/utils"
 	"go.uber.org/zap"
 )
 
 var (
-	OutputDir     string
-	TemplateNames []string
+	OutputDir       string
+	TemplateNames   []string
+	CoverLetterFile string
+	RunStdout       bool
+	RunMaxPages     int
+	RunFit          bool
+	RunATSCheck     bool
+	RunParams       []string
 )
 
 func initRunCmd() {
 	rootCmd.AddCommand(runCmd)
-	runCmd.Flags().StringVarP(&InputFile, "input", "i", "", "Path to the resume data file (e.g., resume.yml)")
+	runCmd.Flags().StringVarP(&InputFile, "input", "i", "", "Path to the resume data file (e.g., resume.yml), or - for standard input")
+	runCmd.Flags().StringVar(&InputFormat, "input-format", "", "Input format (yaml, json, jsonc, toml, md). Detected from the extension or content when omitted.")
 	defaultOut := utils.DefaultOutputDir()
 	runCmd.Flags().StringVarP(&OutputDir, "output-dir", "o", defaultOut, "Root directory where generated resumes will be stored")
 	runCmd.Flags().StringVar(&OutputDir, "output-root", defaultOut, "Alias for --output-dir")
 	runCmd.Flags().StringSliceVarP(&TemplateNames, "template", "t", nil, "Template name(s). Repeat the flag or use comma-separated values. Defaults to all available templates.")
 	runCmd.Flags().StringVarP(&LaTeXEngine, "latex-engine", "e", "", "LaTeX engine to use (xelatex, pdflatex, lualatex, latex). Auto-detects if not specified.")
-
-	_ = runCmd.MarkFlagRequired("input")
+	runCmd.Flags().StringVar(&CoverLetterFile, "cover-letter", "", "Path to a cover letter file to generate alongside the resume (e.g., letter.yml)")
+	runCmd.Flags().BoolVar(&RunStdout, "stdout", false, "Write the single generated artifact to standard output instead of a run directory (requires exactly one template)")
+	runCmd.Flags().IntVar(&RunMaxPages, "max-pages", 1, "Page limit for PDF resumes; longer ones are reported, or tightened with --fit")
+	runCmd.Flags().BoolVar(&RunATSCheck, "ats-check", false, "Check that applicant tracking systems can parse each generated PDF and report a compatibility score")
+	runCmd.Flags().StringArrayVar(&RunParams, "param", nil, "Template parameter as name=value, e.g. accent=#0a66c2; repeatable. See 'templates list' for each template's parameters.")
+	runCmd.Flags().BoolVar(&RunFit, "fit", false, "Tighten the layout and trim the oldest highlights of HTML and LaTeX resumes until they fit --max-pages")
 
 	generators.SetEmbeddedFS(EmbeddedTemplatesFS)
 }
@@ -37,23 +51,60 @@ func initRunCmd() {
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Generate a resume from a data file",
+	Long: `Generate a resume from a data file with one or more templates.
+
+Pass -i - to read the resume from standard input; the format is detected from
+the content unless --input-format is given. With --stdout and a single
+template, the artifact (PDF, Markdown or DOCX) is written to standard output
+and logs stay on standard error, so run composes in shell pipelines:
+
+  cat resume.yml | resume-generator run -i - -t modern-html --stdout > resume.pdf
+
+With --fit, HTML and LaTeX resumes longer than --max-pages are recompiled
+with progressively tighter settings: a denser layout, the classic typography,
+two skill columns, and finally dropping the last highlight of the oldest
+positions. Every change is reported; the input file is never modified.
+
+  resume-generator run -i resume.yml --max-pages 1 --fit
+
+Templates declare parameters such as an accent color or the page margin.
+--param sets them for this run, over the values in the resume's
+layout.params and the template defaults:
+
+  resume-generator run -i resume.yml -t modern-html --param accent=#0a66c2 --param show_icons=false`,
 	Run: func(cmd *cobra.Command, args []string) {
 		logger, _ := zap.NewProduction()
 		sugar := logger.Sugar()
 
-		// Resolve input file path
-		inputPath, err := utils.ResolvePath(InputFile)
-		if err != nil {
-			sugar.Fatalf("Error resolving input path: %s", err)
+		if RunStdout && CoverLetterFile != "" {
+			sugar.Fatalf("--stdout writes a single artifact and cannot be combined with --cover-letter")
 		}
-		if !utils.FileExists(inputPath) {
-			sugar.Fatalf("Input file does not exist: %s", inputPath)
+		if RunMaxPages < 1 {
+			sugar.Fatalf("--max-pages must be at least 1")
+		}
+
+		// A standalone cover letter may name the resume it accompanies
+		var letter *resume.CoverLetter
+		if CoverLetterFile != "" {
+			letterPath, err := utils.ResolvePath(CoverLetterFile)
+			if err != nil {
+				sugar.Fatalf("Error resolving cover letter path: %s", err)
+			}
+			if letter, err = resume.LoadCoverLetterFromFile(letterPath); err != nil {
+				sugar.Fatalf("Error loading cover letter: %s", err)
+			}
+			if InputFile == "" {
+				InputFile = letter.ResumePath()
+			}
+		}
+		if InputFile == "" {
+			sugar.Fatalf("An input file is required: pass --input or reference the resume from the cover letter")
 		}
 
 		// Load resume data using unified adapter
-		inputData, err := resume.LoadResumeFromFile(inputPath)
+		inputData, inputPath, err := loadInput(InputFile, InputFormat)
 		if err != nil {
-			sugar.Fatalf("Error loading resume data: %s", err)
+			sugar.Fatalf("%v", err)
 		}
 
 		// Validate input
@@ -65,6 +116,20 @@ var runCmd = &cobra.Command{
 		resumeData := inputData.ToResume()
 		sugar.Infof("Loaded resume for %s (format: %s)", resumeData.Contact.Name, inputData.GetFormat())
 
+		// Fall back to a cover letter declared inline in the resume
+		if letter == nil {
+			if RunStdout && resumeData.CoverLetter != nil {
+				sugar.Infof("Skipping the inline cover letter; --stdout writes only the resume")
+			} else {
+				letter = resumeData.CoverLetter
+			}
+		}
+		if letter != nil {
+			if errs := resume.ValidateCoverLetter(letter, "cover_letter"); len(errs) > 0 {
+				sugar.Fatalf("Cover letter validation error: %s: %s", errs[0].Field, errs[0].Message)
+			}
+		}
+
 		// Generate using unified template system
 		generator := generators.NewGenerator(sugar)
 
@@ -76,33 +141,50 @@ var runCmd = &cobra.Command{
 		if len(selectedTemplates) == 0 {
 			sugar.Fatalf("No templates available for generation")
 		}
-		sugar.Infof("Generating resumes for %d template(s)", len(selectedTemplates))
-
-		// Determine output folder and filenames
-		resumeSlug := generateFilenameSlug(inputPath)
-		currentTime := time.Now()
-
-		rootDirInput := strings.TrimSpace(OutputDir)
-		resolvedDir, err := utils.ResolvePath(rootDirInput)
-		if err != nil {
-			sugar.Fatalf("Error resolving output directory: %s", err)
-		}
-		if resolvedDir == "" {
-			if resolvedDir, err = os.Getwd(); err != nil {
-				sugar.Fatalf("Failed to determine working directory: %s", err)
-			}
+		if RunStdout && len(selectedTemplates) != 1 {
+			sugar.Fatalf("--stdout requires exactly one template; pass it with -t")
 		}
-		if err := utils.EnsureDir(resolvedDir); err != nil {
-			sugar.Fatalf("Error creating output directory: %s", err)
+		params, err := parseParams(RunParams, selectedTemplates)
+		if err != nil {
+			sugar.Fatalf("%v", err)
 		}
+		generator.SetParams(params)
+		sugar.Infof("Generating resumes for %d template(s)", len(selectedTemplates))
 
 		desiredBase := generateOutputBaseName(resumeData.Contact.Name)
 		pdfExt := ".pdf"
 
-		// Create timestamped run directory: <root>/<slug>/<YYYY-MM-DD_HH-MM>/
-		runDir := generateRunDir(filepath.Join(resolvedDir, resumeSlug), currentTime)
-		if err := utils.EnsureDir(runDir); err != nil {
-			sugar.Fatalf("Error creating run output directory: %s", err)
+		var runDir string
+		if RunStdout {
+			// Build in a scratch directory; only the artifact reaches stdout
+			if runDir, err = os.MkdirTemp("", "resume-stdout-*"); err != nil {
+				sugar.Fatalf("Failed to create temp output directory: %s", err)
+			}
+			defer func() { _ = os.RemoveAll(runDir) }()
+		} else {
+			// Determine output folder and filenames
+			resumeSlug := generateFilenameSlug(inputPath)
+			currentTime := time.Now()
+
+			rootDirInput := strings.TrimSpace(OutputDir)
+			resolvedDir, err := utils.ResolvePath(rootDirInput)
+			if err != nil {
+				sugar.Fatalf("Error resolving output directory: %s", err)
+			}
+			if resolvedDir == "" {
+				if resolvedDir, err = os.Getwd(); err != nil {
+					sugar.Fatalf("Failed to determine working directory: %s", err)
+				}
+			}
+			if err := utils.EnsureDir(resolvedDir); err != nil {
+				sugar.Fatalf("Error creating output directory: %s", err)
+			}
+
+			// Create timestamped run directory: <root>/<slug>/<YYYY-MM-DD_HH-MM>/
+			runDir = generateRunDir(filepath.Join(resolvedDir, resumeSlug), currentTime)
+			if err := utils.EnsureDir(runDir); err != nil {
+				sugar.Fatalf("Error creating run output directory: %s", err)
+			}
 		}
 
 		// Pre-load the HTML fallback template for DOCX->PDF conversion
@@ -161,30 +243,8 @@ var runCmd = &cobra.Command{
 				}
 
 				// Also generate a PDF via the HTML fallback template
-				if htmlFallbackTmpl != nil {
-					htmlContent, htmlErr := generator.GenerateWithTemplate(htmlFallbackTmpl, resumeData)
-					if htmlErr != nil {
-						sugar.Warnf("Failed to generate HTML for DOCX PDF fallback: %v", htmlErr)
-					} else {
-						pdfOutputPath := strings.TrimSuffix(docxOutputPath, ".docx") + ".pdf"
-						debugDir, debugErr := os.MkdirTemp("", "resume-debug-*")
-						if debugErr != nil {
-							sugar.Warnf("Failed to create temp debug dir for DOCX PDF: %v", debugErr)
-						} else {
-							if pdfErr := compileHTMLToPDF(sugar, htmlContent, pdfOutputPath, debugDir); pdfErr != nil {
-								// Keep debug dir on failure
-								persistedDebug := filepath.Join(runDir, desiredBase+"."+tmpl.Name+"_debug")
-								if mvErr := os.Rename(debugDir, persistedDebug); mvErr != nil {
-									sugar.Warnf("Failed to persist debug dir: %v (temp dir: %s)", mvErr, debugDir)
-								} else {
-									sugar.Warnf("Failed to generate PDF for DOCX template %s: %v (debug: %s)", tmpl.Name, pdfErr, persistedDebug)
-								}
-							} else {
-								_ = os.RemoveAll(debugDir)
-								sugar.Infof("Generated PDF alongside DOCX: %s", pdfOutputPath)
-							}
-						}
-					}
+				if !RunStdout {
+					docxFallbackPDF(sugar, htmlFallbackTmpl, resumeData, renderResume(generator), docxOutputPath, runDir, desiredBase+"."+tmpl.Name)
 				}
 
 				results = append(results, generationResult{
@@ -196,56 +256,15 @@ var runCmd = &cobra.Command{
 			}
 
 			// Standard template-based generation for HTML and LaTeX
-			content, err := generator.GenerateWithTemplate(tmpl, resumeData)
-			if err != nil {
-				sugar.Fatalf("Failed to generate resume with template %s: %v", tmpl.Name, err)
-			}
-
 			pdfOutputPath, err := ensureUniqueOutputPath(runDir, desiredBase, tmpl.Name, pdfExt)
 			if err != nil {
 				sugar.Fatalf("Error determining output filename for template %s: %v", tmpl.Name, err)
 			}
 
-			// Use a temp directory for debug artifacts; only persist on failure
-			debugDir, err := os.MkdirTemp("", "resume-debug-*")
-			if err != nil {
-				sugar.Fatalf("Failed to create temp debug directory: %v", err)
-			}
-
-			var templateDir string
-			if tmpl.Embedded && tmpl.EmbeddedDir != "" {
-				extractedDir, extractErr := generators.ExtractEmbeddedTemplateDir(tmpl.EmbeddedDir)
-				if extractErr != nil {
-					sugar.Fatalf("Failed to extract embedded template files for %s: %v", tmpl.Name, extractErr)
-				}
-				defer func() { _ = os.RemoveAll(extractedDir) }()
-				templateDir = extractedDir
-			} else {
-				templateDir = filepath.Dir(tmpl.Path)
+			if err := compilePDF(sugar, tmpl, resumeData, renderResume(generator), pdfOutputPath, runDir, desiredBase, RunFit); err != nil {
+				sugar.Fatalf("Failed to compile template %s: %v", tmpl.Name, err)
 			}
 
-			var compileErr error
-			switch tmpl.Type {
-			case generators.TemplateTypeLaTeX:
-				compileErr = compileLaTeXToPDF(sugar, content, pdfOutputPath, debugDir, templateDir)
-			case generators.TemplateTypeHTML:
-				compileErr = compileHTMLToPDF(sugar, content, pdfOutputPath, debugDir)
-			default:
-				sugar.Fatalf("Unknown template type: %s", tmpl.Type)
-			}
-
-			if compileErr != nil {
-				// Persist debug dir next to output on failure
-				persistedDebug := filepath.Join(runDir, desiredBase+"."+tmpl.Name+"_debug")
-				if mvErr := os.Rename(debugDir, persistedDebug); mvErr != nil {
-					sugar.Warnf("Failed to persist debug dir: %v (temp dir: %s)", mvErr, debugDir)
-				}
-				sugar.Fatalf("Failed to compile template %s: %v (debug: %s)", tmpl.Name, compileErr, persistedDebug)
-			}
-
-			// Success: clean up debug artifacts
-			_ = os.RemoveAll(debugDir)
-
 			results = append(results, generationResult{
 				template: tmpl.Name,
 				tType:    tmpl.Type,
@@ -253,23 +272,286 @@ var runCmd = &cobra.Command{
 			})
 		}
 
+		if letter != nil {
+			for _, tmpl := range selectedTemplates {
+				if !tmpl.SupportsCoverLetter() {
+					sugar.Warnf("Template %s has no cover letter; skipping", tmpl.Name)
+					continue
+				}
+				letterPath, err := generateCoverLetter(sugar, generator, tmpl, resumeData, letter, runDir, desiredBase+"_Cover_Letter", htmlFallbackTmpl)
+				if err != nil {
+					sugar.Fatalf("Failed to generate cover letter with template %s: %v", tmpl.Name, err)
+				}
+				sugar.Infof("Successfully generated cover letter (%s) using %s at %s", tmpl.Type, tmpl.Name, letterPath)
+			}
+		}
+
 		for _, result := range results {
-			sugar.Infof("Successfully generated resume (%s) using %s at %s", result.tType, result.template, result.outPath)
+			if !RunStdout {
+				sugar.Infof("Successfully generated resume (%s) using %s at %s", result.tType, result.template, result.outPath)
+			}
 
-			// Warn if the generated PDF exceeds one page
+			// Warn if the generated PDF exceeds the page limit
 			if strings.HasSuffix(result.outPath, ".pdf") {
-				if pdfData, readErr := os.ReadFile(result.outPath); readErr == nil {
-					if pages := compilers.CountPDFPages(pdfData); pages > 1 {
-						sugar.Warnf("Resume generated with template %s has %d pages (exceeds 1 page)", result.template, pages)
+				if pages, countErr := countPDFPages(result.outPath); countErr != nil {
+					sugar.Warnf("Could not count the pages of %s: %v", result.outPath, countErr)
+				} else if pages > RunMaxPages {
+					sugar.Warnf("Resume generated with template %s has %d pages (exceeds %s)%s", result.template, pages, pluralPages(RunMaxPages), collapseHint(resumeData))
+				}
+
+				if RunATSCheck {
+					if report, checkErr := ats.CheckFile(result.outPath, resumeData); checkErr != nil {
+						sugar.Warnf("Could not run the ATS check on %s: %v", result.outPath, checkErr)
+					} else {
+						logATSReport(sugar, result.template, report)
 					}
 				}
 			}
 		}
+
+		if RunStdout {
+			if err := streamFile(os.Stdout, results[0].outPath); err != nil {
+				sugar.Fatalf("Failed to write %s to stdout: %v", results[0].template, err)
+			}
+			sugar.Infof("Successfully streamed resume (%s) using %s to stdout", results[0].tType, results[0].template)
+		}
 	},
 }
 
-// compileHTMLToPDF compiles HTML content to PDF using a Chromium-based browser
-func compileHTMLToPDF(logger *zap.SugaredLogger, htmlContent, outputPath, debugDir string) error {
+// parseParams reads --param name=value pairs. Each name must be declared by
+// at least one of the templates.
+func parseParams(values []string, templates []*generators.Template) (map[string]string, error) {
+	params := map[string]string{}
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --param %q: expected name=value", value)
+		}
+		declared := false
+		for _, tmpl := range templates {
+			if _, ok := tmpl.Param(name); ok {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			return nil, fmt.Errorf("--param %s is not a parameter of the selected templates; see 'templates list'", name)
+		}
+		params[name] = v
+	}
+	return params, nil
+}
+
+// generateCoverLetter renders the cover letter with tmpl into runDir and
+// returns the path of the main artifact. Like the resume, DOCX letters also
+// get a PDF rendered through the HTML fallback template.
+func generateCoverLetter(logger *zap.SugaredLogger, generator *generators.Generator, tmpl *generators.Template, r *resume.Resume, letter *resume.CoverLetter, runDir, baseName string, htmlFallbackTmpl *generators.Template) (string, error) {
+	switch tmpl.Type {
+	case generators.TemplateTypeMarkdown:
+		content, err := generator.GenerateCoverLetter(tmpl, r, letter)
+		if err != nil {
+			return "", err
+		}
+		outPath, err := ensureUniqueOutputPath(runDir, baseName, tmpl.Name, ".md")
+		if err != nil {
+			return "", err
+		}
+		return outPath, os.WriteFile(outPath, []byte(content), 0644)
+
+	case generators.TemplateTypeDOCX:
+		docxBytes, err := generator.GenerateCoverLetterDOCX(r, letter)
+		if err != nil {
+			return "", err
+		}
+		outPath, err := ensureUniqueOutputPath(runDir, baseName, tmpl.Name, ".docx")
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(outPath, docxBytes, 0644); err != nil {
+			return "", err
+		}
+		if htmlFallbackTmpl != nil && htmlFallbackTmpl.SupportsCoverLetter() {
+			docxFallbackPDF(logger, htmlFallbackTmpl, r, renderCoverLetter(generator, letter), outPath, runDir, baseName+"."+tmpl.Name)
+		}
+		return outPath, nil
+	}
+
+	outPath, err := ensureUniqueOutputPath(runDir, baseName, tmpl.Name, ".pdf")
+	if err != nil {
+		return "", err
+	}
+	if err := compilePDF(logger, tmpl, r, renderCoverLetter(generator, letter), outPath, runDir, baseName, false); err != nil {
+		return "", err
+	}
+	return outPath, nil
+}
+
+// documentRenderer renders a document, the resume or its cover letter, for
+// r with tmpl. It returns the content and the metadata to write into a PDF
+// compiled from HTML, or nil.
+type documentRenderer func(tmpl *generators.Template, r *resume.Resume) (string, *compilers.PDFMetadata, error)
+
+func renderResume(generator *generators.Generator) documentRenderer {
+	return func(tmpl *generators.Template, r *resume.Resume) (string, *compilers.PDFMetadata, error) {
+		content, err := generator.GenerateWithTemplate(tmpl, r)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate resume: %w", err)
+		}
+		return content, resumePDFMetadata(r, content), nil
+	}
+}
+
+func renderCoverLetter(generator *generators.Generator, letter *resume.CoverLetter) documentRenderer {
+	return func(tmpl *generators.Template, r *resume.Resume) (string, *compilers.PDFMetadata, error) {
+		content, err := generator.GenerateCoverLetter(tmpl, r, letter)
+		return content, nil, err
+	}
+}
+
+// compilePDF renders r with an HTML or LaTeX template and compiles it to
+// pdfPath; with fit, the resume is then tightened until it fits --max-pages.
+// Build artifacts go to a temporary directory that is removed on success
+// and kept as <runDir>/<baseName>.<template>_debug on failure.
+func compilePDF(logger *zap.SugaredLogger, tmpl *generators.Template, r *resume.Resume, render documentRenderer, pdfPath, runDir, baseName string, fit bool) error {
+	debugDir, err := os.MkdirTemp("", "resume-debug-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp debug directory: %w", err)
+	}
+
+	// Support files of the template and the templates it extends
+	templateDir, err := generators.ExtractTemplateFiles(tmpl)
+	if err != nil {
+		_ = os.RemoveAll(debugDir)
+		return fmt.Errorf("failed to extract template files: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(templateDir) }()
+
+	compile := func(r *resume.Resume) error {
+		content, meta, err := render(tmpl, r)
+		if err != nil {
+			return err
+		}
+		switch tmpl.Type {
+		case generators.TemplateTypeLaTeX:
+			if err := generators.WriteImageAssets(r, debugDir); err != nil {
+				return err
+			}
+			return compileLaTeXToPDF(logger, content, pdfPath, debugDir, templateDir)
+		case generators.TemplateTypeHTML:
+			return compileHTMLToPDF(logger, content, pdfPath, debugDir, meta)
+		default:
+			return fmt.Errorf("unknown template type: %s", tmpl.Type)
+		}
+	}
+
+	compileErr := compile(r)
+	if compileErr == nil && fit {
+		compileErr = fitToPages(logger, tmpl.Name, r, pdfPath, compile)
+	}
+	if compileErr != nil {
+		// Persist debug dir next to output on failure
+		persistedDebug := filepath.Join(runDir, baseName+"."+tmpl.Name+"_debug")
+		if mvErr := os.Rename(debugDir, persistedDebug); mvErr != nil {
+			return fmt.Errorf("%w (temp dir: %s)", compileErr, debugDir)
+		}
+		return fmt.Errorf("%w (debug: %s)", compileErr, persistedDebug)
+	}
+
+	// Success: clean up debug artifacts
+	_ = os.RemoveAll(debugDir)
+	return nil
+}
+
+// docxFallbackPDF writes a PDF beside the DOCX at docxPath, rendered with
+// the HTML fallback template. The DOCX is the artifact, so failures are
+// only warned about.
+func docxFallbackPDF(logger *zap.SugaredLogger, fallback *generators.Template, r *resume.Resume, render documentRenderer, docxPath, runDir, baseName string) {
+	if fallback == nil {
+		return
+	}
+	pdfPath := strings.TrimSuffix(docxPath, ".docx") + ".pdf"
+	if err := compilePDF(logger, fallback, r, render, pdfPath, runDir, baseName, false); err != nil {
+		logger.Warnf("Failed to generate PDF for %s: %v", filepath.Base(docxPath), err)
+		return
+	}
+	logger.Infof("Generated PDF alongside DOCX: %s", pdfPath)
+}
+
+// fitToPages recompiles the resume with compile, tightening a copy of r one
+// step at a time, until the PDF at pdfPath fits RunMaxPages or nothing is
+// left to adjust. Each change is logged as it is made.
+func fitToPages(logger *zap.SugaredLogger, templateName string, r *resume.Resume, pdfPath string, compile func(*resume.Resume) error) error {
+	fitted := *r
+	var changes []string
+	for {
+		pages, err := countPDFPages(pdfPath)
+		if err != nil {
+			return fmt.Errorf("failed to count pages: %w", err)
+		}
+		if pages <= RunMaxPages {
+			if len(changes) > 0 {
+				logger.Infof("Fitted template %s to %s with %d change(s)", templateName, pluralPages(pages), len(changes))
+			}
+			return nil
+		}
+
+		change, ok := fitted.Tighten()
+		if !ok {
+			logger.Warnf("Could not fit template %s to %s: still %d pages after %d change(s)", templateName, pluralPages(RunMaxPages), pages, len(changes))
+			return nil
+		}
+		changes = append(changes, change)
+		logger.Infof("Fitting template %s (%d pages): %s", templateName, pages, change)
+		if err := compile(&fitted); err != nil {
+			return err
+		}
+	}
+}
+
+// countPDFPages returns the number of pages in the PDF at path.
+func countPDFPages(path string) (int, error) {
+	doc, err := pdf.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	return doc.NumPages()
+}
+
+func pluralPages(n int) string {
+	if n == 1 {
+		return "1 page"
+	}
+	return fmt.Sprintf("%d pages", n)
+}
+
+// streamFile copies the file at path to w.
+func streamFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// collapseHint suggests collapsing older positions when a resume overflows,
+// or reports how many positions are already collapsed.
+func collapseHint(r *resume.Resume) string {
+	detailed, earlier := generators.SplitExperience(r.Experience.Positions, r.Layout)
+	if len(earlier) > 0 {
+		return fmt.Sprintf("; %d older position(s) already collapsed, consider lowering layout.max_detailed_positions", len(earlier))
+	}
+	if len(detailed) > 3 {
+		return "; consider setting layout.collapse_before or layout.max_detailed_positions to collapse older positions"
+	}
+	return ""
+}
+
+// compileHTMLToPDF compiles HTML content to PDF using a Chromium-based browser.
+// When meta is set it is written into the PDF; failing to do so only warns.
+func compileHTMLToPDF(logger *zap.SugaredLogger, htmlContent, outputPath, debugDir string, meta *compilers.PDFMetadata) error {
 	baseName := strings.TrimSuffix(filepath.Base(outputPath), filepath.Ext(outputPath))
 	if baseName == "" {
 		baseName = "resume"
@@ -281,7 +563,20 @@ func compileHTMLToPDF(logger *zap.SugaredLogger, htmlContent, outputPath, debugD
 	}
 
 	compiler := compilers.NewRodHTMLToPDFCompiler(logger)
-	return compiler.Compile(htmlContent, outputPath)
+	if err := compiler.Compile(htmlContent, outputPath); err != nil {
+		return err
+	}
+	if meta != nil {
+		if err := compilers.WritePDFMetadata(outputPath, *meta); err != nil {
+			logger.Warnf("Failed to write PDF metadata to %s: %v", outputPath, err)
+		}
+	}
+	return nil
+}
+
+// resumePDFMetadata returns the PDF metadata for r rendered as htmlContent.
+func resumePDFMetadata(r *resume.Resume, htmlContent string) *compilers.PDFMetadata {
+	return &compilers.PDFMetadata{DocumentInfo: r.DocumentInfo(), Sections: generators.SectionTitles(htmlContent)}
 }
 
 // compileLaTeXToPDF compiles LaTeX content to PDF using available LaTeX engines
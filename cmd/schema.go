@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 
 	"github.com/invopop/jsonschema"
 	"github.com/spf13/cobra"
@@ -57,6 +58,7 @@ func generateSchema() error {
 	reflector := jsonschema.Reflector{
 		AllowAdditionalProperties: false,
 		DoNotReference:            true,
+		Mapper:                    schemaMapper,
 	}
 	schema := reflector.Reflect(&resume.Resume{})
 
@@ -99,6 +101,36 @@ Key features:
 	return nil
 }
 
+// schemaMapper overrides the reflected schema of types with custom
+// (un)marshalers.
+func schemaMapper(t reflect.Type) *jsonschema.Schema {
+	if t != reflect.TypeOf(resume.SkillItem{}) {
+		return nil
+	}
+
+	levels := make([]interface{}, len(resume.SkillLevels))
+	for i, level := range resume.SkillLevels {
+		levels[i] = level
+	}
+	props := jsonschema.NewProperties()
+	props.Set("name", &jsonschema.Schema{Type: "string"})
+	props.Set("level", &jsonschema.Schema{Type: "string", Enum: levels})
+	props.Set("years", &jsonschema.Schema{Type: "number", Minimum: "0"})
+
+	return &jsonschema.Schema{
+		Description: "A skill name, or an object with an optional level and years of experience",
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{
+				Type:                 "object",
+				Properties:           props,
+				Required:             []string{"name"},
+				AdditionalProperties: jsonschema.FalseSchema,
+			},
+		},
+	}
+}
+
 func addSchemaExample(schema *jsonschema.Schema) {
 	schema.Examples = []interface{}{
 		map[string]interface{}{
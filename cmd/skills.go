@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"github.com/urmzd/resume-generator/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// skillsYearsTolerance is how far a claimed number of years may exceed the
+// derived tenure before the report flags it.
+const skillsYearsTolerance = 0.5
+
+func initSkillsCmd() {
+	skillsCmd.AddCommand(skillsReportCmd)
+	rootCmd.AddCommand(skillsCmd)
+}
+
+var skillsCmd = &cobra.Command{
+	Use:   "skills",
+	Short: "Inspect the skills section of a resume",
+}
+
+var skillsReportCmd = &cobra.Command{
+	Use:   "report [file]",
+	Short: "List technologies by tenure derived from experience and projects",
+	Long: `Report walks the technologies listed on each position and dated project,
+merges overlapping date ranges and prints the resulting tenure per technology,
+longest first.
+
+It then checks the skills section against that history and flags skills whose
+claimed years exceed what the resume supports, or that no position or project
+lists at all.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		filePath, err := utils.ResolvePath(args[0])
+		if err != nil {
+			sugar.Fatalf("Error resolving file path: %v", err)
+		}
+		if !utils.FileExists(filePath) {
+			sugar.Fatalf("File does not exist: %s", filePath)
+		}
+
+		inputData, err := resume.LoadResumeFromFile(filePath)
+		if err != nil {
+			sugar.Fatalf("failed to load resume data: %v", err)
+		}
+		resumeData := inputData.ToResume()
+
+		tenures := resume.ComputeSkillTenure(resumeData, time.Now())
+		if len(tenures) == 0 {
+			fmt.Println("No dated technologies found in experience or projects.")
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TECHNOLOGY\tYEARS\tSOURCES")
+			for _, t := range tenures {
+				fmt.Fprintf(w, "%s\t%.1f\t%s\n", t.Name, t.Years, strings.Join(t.Sources, "; "))
+			}
+			_ = w.Flush()
+		}
+
+		derived := resume.IndexSkillTenure(tenures)
+
+		var warnings []string
+		for _, cat := range resumeData.Skills.Categories {
+			for _, item := range cat.Items {
+				years, ok := derived.Lookup(item.Name)
+				switch {
+				case !ok:
+					warnings = append(warnings, fmt.Sprintf("%s (%s): not listed on any position or dated project", item.Name, cat.Category))
+				case item.Years > years+skillsYearsTolerance:
+					warnings = append(warnings, fmt.Sprintf("%s (%s): claims %.1f years, history supports %.1f", item.Name, cat.Category, item.Years, years))
+				}
+			}
+		}
+
+		fmt.Println()
+		if len(warnings) == 0 {
+			fmt.Println("Every listed skill is backed by experience or project history.")
+			return
+		}
+		fmt.Printf("%d skill(s) not supported by history:\n", len(warnings))
+		for _, warning := range warnings {
+			fmt.Printf("  - %s\n", warning)
+		}
+	},
+}
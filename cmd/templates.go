@@ -10,11 +10,17 @@ import (
 	"github.com/urmzd/resume-generator/pkg/compilers"
 	"github.com/urmzd/resume-generator/pkg/generators"
 	"github.com/urmzd/resume-generator/pkg/utils"
+	"go.uber.org/zap"
 )
 
+var templateNewFormat string
+
 func initTemplatesCmd() {
 	templatesCmd.AddCommand(templatesListCmd)
 	templatesCmd.AddCommand(templatesValidateCmd)
+	templatesCmd.AddCommand(templatesNewCmd)
+	templatesCmd.AddCommand(templatesEjectCmd)
+	templatesNewCmd.Flags().StringVarP(&templateNewFormat, "format", "f", "html", "Template format: html, latex or markdown")
 	templatesCmd.AddCommand(latexEnginesCmd)
 	rootCmd.AddCommand(templatesCmd)
 }
@@ -22,6 +28,37 @@ func initTemplatesCmd() {
 var templatesCmd = &cobra.Command{
 	Use:   "templates",
 	Short: "Manage templates",
+	Long: `A template is a directory under templates/ with a config.yml and a template
+file. A template can extend another one and replace only some of its parts:
+
+  # templates/compact-html/config.yml
+  name: compact-html
+  extends: modern-html
+
+  {{/* templates/compact-html/template.html */}}
+  {{define "section-summary"}}<p class="lead">{{.Summary}}</p>{{end}}
+
+The derived template inherits the format, the template and letter files and
+every {{define}} block of the template it extends; its own blocks replace
+those of the same name. Files in a template's partials/ directory are parsed
+before its template file, so they can hold shared blocks, and derived
+templates inherit them too. Other files, such as LaTeX classes, are inherited
+unless the derived template has a file of the same name.
+
+Templates can declare parameters in config.yml:
+
+  params:
+    - name: accent
+      type: color       # string, color, number, length or bool
+      default: "#000000"
+      description: Color of the name and section titles
+
+Templates read them as .Params, e.g. {{with .Params.accent}}. Values come from
+the default, then the resume's layout.params, then 'run --param name=value'.
+A derived template inherits the parameters of the template it extends.
+
+'templates new' writes a starter template to build on, and 'templates eject'
+copies a built-in template to disk to customize it.`,
 }
 
 var templatesListCmd = &cobra.Command{
@@ -63,14 +100,7 @@ var templatesListCmd = &cobra.Command{
 		if len(htmlTemplates) > 0 {
 			fmt.Println("HTML Templates:")
 			for _, tmpl := range htmlTemplates {
-				name := tmpl.DisplayName
-				if name == "" {
-					name = tmpl.Name
-				}
-				fmt.Printf("  %s (%s)\n", name, tmpl.Name)
-				if tmpl.Description != "" {
-					fmt.Printf("      %s\n", tmpl.Description)
-				}
+				printTemplate(tmpl)
 			}
 			fmt.Println()
 		}
@@ -79,14 +109,7 @@ var templatesListCmd = &cobra.Command{
 		if len(latexTemplates) > 0 {
 			fmt.Println("LaTeX Templates (PDF):")
 			for _, tmpl := range latexTemplates {
-				name := tmpl.DisplayName
-				if name == "" {
-					name = tmpl.Name
-				}
-				fmt.Printf("  %s (%s)\n", name, tmpl.Name)
-				if tmpl.Description != "" {
-					fmt.Printf("      %s\n", tmpl.Description)
-				}
+				printTemplate(tmpl)
 			}
 			fmt.Println()
 		}
@@ -95,14 +118,7 @@ var templatesListCmd = &cobra.Command{
 		if len(markdownTemplates) > 0 {
 			fmt.Println("Markdown Templates:")
 			for _, tmpl := range markdownTemplates {
-				name := tmpl.DisplayName
-				if name == "" {
-					name = tmpl.Name
-				}
-				fmt.Printf("  %s (%s)\n", name, tmpl.Name)
-				if tmpl.Description != "" {
-					fmt.Printf("      %s\n", tmpl.Description)
-				}
+				printTemplate(tmpl)
 			}
 			fmt.Println()
 		}
@@ -114,6 +130,115 @@ var templatesListCmd = &cobra.Command{
 	},
 }
 
+// printTemplate writes a template's name, description, parent and params.
+func printTemplate(tmpl generators.Template) {
+	name := tmpl.DisplayName
+	if name == "" {
+		name = tmpl.Name
+	}
+	fmt.Printf("  %s (%s)\n", name, tmpl.Name)
+	if tmpl.Description != "" {
+		fmt.Printf("      %s\n", tmpl.Description)
+	}
+	if tmpl.Config.Extends != "" {
+		fmt.Printf("      extends %s\n", tmpl.Config.Extends)
+	}
+	if len(tmpl.Config.Params) == 0 {
+		return
+	}
+	fmt.Println("      Parameters:")
+	for _, p := range tmpl.Config.Params {
+		line := fmt.Sprintf("        %s (%s", p.Name, p.Type)
+		if p.Default != "" {
+			line += ", default " + p.Default
+		}
+		line += ")"
+		if p.Description != "" {
+			line += ": " + p.Description
+		}
+		fmt.Println(line)
+	}
+}
+
+var templatesNewCmd = &cobra.Command{
+	Use:   "new <name> [dir]",
+	Short: "Create a starter template",
+	Long: `Create a working template in dir/<name> (dir defaults to ./templates): a
+config.yml and a starter template with one {{define}} block per section.
+
+Examples:
+  resume-generator templates new my-theme --format html
+  resume-generator templates new my-cv --format latex ./templates`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		format := generators.TemplateType(strings.ToLower(strings.TrimSpace(templateNewFormat)))
+		dir, err := generators.NewTemplate(args[0], format, templatesDestDir(args))
+		if err != nil {
+			sugar.Fatalf("Failed to create template: %v", err)
+		}
+		fmt.Printf("Created %s\n", dir)
+		printTemplateHint(dir, args[0])
+	},
+}
+
+var templatesEjectCmd = &cobra.Command{
+	Use:   "eject <name> [dir]",
+	Short: "Copy a built-in template to disk for customization",
+	Long: `Copy every file of a template, including support files such as the LaTeX
+class default.cls and any subdirectories, to dir/<name> (dir defaults to
+./templates). A template on disk takes precedence over the built-in one of
+the same name, so later runs use the copy.
+
+Examples:
+  resume-generator templates eject modern-latex ./templates/`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		tmpl, err := generators.LoadTemplate(args[0])
+		if err != nil {
+			sugar.Fatalf("Failed to load template: %v", err)
+		}
+		dir, err := generators.EjectTemplate(tmpl, templatesDestDir(args))
+		if err != nil {
+			sugar.Fatalf("Failed to eject template: %v", err)
+		}
+		fmt.Printf("Copied %s to %s\n", tmpl.Name, dir)
+		if tmpl.Config.Extends != "" {
+			fmt.Printf("It still extends %s; eject that too to change what it inherits.\n", tmpl.Config.Extends)
+		}
+		printTemplateHint(dir, tmpl.Name)
+	},
+}
+
+// templatesDestDir returns the directory argument of new and eject.
+func templatesDestDir(args []string) string {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return "templates"
+}
+
+// printTemplateHint tells how to render with the template in dir, which is
+// found by name only from a templates/ directory.
+func printTemplateHint(dir, name string) {
+	parent := filepath.Dir(dir)
+	if filepath.Base(parent) != "templates" {
+		fmt.Printf("Templates are found in a templates/ directory; move %s into one to use it.\n", dir)
+		return
+	}
+	if root, err := filepath.Abs(filepath.Dir(parent)); err == nil {
+		if cwd, err := os.Getwd(); err != nil || root != cwd {
+			fmt.Printf("Set RESUME_TEMPLATES_DIR=%s or run from that directory to use it.\n", root)
+		}
+	}
+	fmt.Printf("Render with: resume-generator run -i resume.yml -t %s\n", name)
+}
+
 var templatesValidateCmd = &cobra.Command{
 	Use:   "validate [file]",
 	Short: "Validate a template file",
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	agentsdk "github.com/urmzd/adk"
+	"github.com/urmzd/adk/core"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+
+	"github.com/spf13/cobra"
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"github.com/urmzd/resume-generator/pkg/translate"
+	"github.com/urmzd/resume-generator/pkg/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	TranslateTo       string
+	TranslateOutput   string
+	TranslateGlossary string
+	translateRetries  int
+)
+
+func initTranslateCmd() {
+	rootCmd.AddCommand(translateCmd)
+	translateCmd.Flags().StringVarP(&InputFile, "input", "i", "", "Path to the YAML resume to translate")
+	translateCmd.Flags().StringVar(&TranslateTo, "to", "", "Target language as a BCP 47 code, e.g. de, fr or pt-BR")
+	translateCmd.Flags().StringVarP(&TranslateOutput, "output", "o", "", "Output file (default: resume.<lang>.yml next to the input)")
+	translateCmd.Flags().StringVar(&TranslateGlossary, "glossary", "", "YAML file of term: translation pairs to use for recurring terms")
+	translateCmd.Flags().IntVar(&translateRetries, "retries", 2, "Times to ask the model to correct a translation that fails the checks")
+	// translate talks to the same inference servers as assess.
+	translateCmd.Flags().StringVar(&assessProvider, "provider", "ollama", "Inference server: ollama, or openai for any OpenAI-compatible chat completions endpoint")
+	translateCmd.Flags().StringVarP(&assessModel, "model", "m", "qwen3.5:4b", "Model to translate with")
+	translateCmd.Flags().StringVar(&assessBaseURL, "base-url", "", "Provider base URL (default http://localhost:11434 for ollama, http://localhost:8080/v1 for openai)")
+	translateCmd.Flags().StringVar(&assessAPIKeyEnv, "api-key-env", "OPENAI_API_KEY", "Environment variable holding the API key sent to the provider, if any")
+	translateCmd.Flags().BoolVarP(&assessVerbose, "verbose", "v", false, "Show the streaming output of the model")
+	_ = translateCmd.MarkFlagRequired("input")
+	_ = translateCmd.MarkFlagRequired("to")
+}
+
+var translateCmd = &cobra.Command{
+	Use:   "translate -i resume.yml --to de",
+	Short: "Translate a YAML resume with a local LLM",
+	Long: `Translate writes a copy of a YAML resume with its human-language values
+translated: the summary, highlights and duties, job and section titles,
+skill categories, degree names and descriptions, language names and the
+cover letter. Names of people, companies, institutions and projects,
+technologies, links, dates and settings are left as they are, and so are
+the structure, key order and comments of the file.
+
+Every translation is checked: links, numbers and the names of the resume
+(the candidate, companies, institutions, projects, certifications and
+technologies) must carry over unchanged, and glossary terms must use their
+pinned translation. Failing answers are sent back to the model up to
+--retries times; checks that still fail are reported as warnings. The
+result must pass the same validation as any resume before it is written.
+
+A glossary pins the translation of recurring terms. A term mapped to itself
+is kept untranslated:
+
+  on-call: Rufbereitschaft
+  stakeholders: Stakeholder
+
+Translate uses the inference servers of assess: Ollama by default, or any
+OpenAI-compatible endpoint with --provider openai.
+
+Examples:
+  resume-generator translate -i resume.yml --to de -o resume.de.yml
+  resume-generator translate -i resume.yml --to fr --glossary glossary.fr.yml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, _ := zap.NewProduction()
+		sugar := logger.Sugar()
+
+		tag, err := language.Parse(TranslateTo)
+		if err != nil {
+			sugar.Fatalf("Invalid --to %q: expected a language code such as de or pt-BR", TranslateTo)
+		}
+		languageName := display.English.Tags().Name(tag)
+		if languageName == "" {
+			sugar.Fatalf("Unknown language %q", TranslateTo)
+		}
+		if translateRetries < 0 {
+			sugar.Fatalf("--retries must not be negative, got %d", translateRetries)
+		}
+
+		inputPath, err := utils.ResolvePath(InputFile)
+		if err != nil {
+			sugar.Fatalf("Error resolving input path: %s", err)
+		}
+		inputData, err := resume.LoadResumeFromFile(inputPath)
+		if err != nil {
+			sugar.Fatalf("Failed to load resume data: %v", err)
+		}
+		if inputData.GetFormat() != "yaml" {
+			sugar.Fatalf("translate reads and writes YAML resumes only, got %s", filepath.Base(inputPath))
+		}
+		data, err := os.ReadFile(inputPath)
+		if err != nil {
+			sugar.Fatalf("Error reading input file: %s", err)
+		}
+
+		outputPath := TranslateOutput
+		if outputPath == "" {
+			ext := filepath.Ext(inputPath)
+			outputPath = strings.TrimSuffix(inputPath, ext) + "." + tag.String() + ext
+		}
+		if outputPath, err = utils.ResolvePath(outputPath); err != nil {
+			sugar.Fatalf("Error resolving output path: %s", err)
+		}
+		if outputPath == inputPath {
+			sugar.Fatalf("The output would overwrite the input; pass another -o")
+		}
+
+		var glossary translate.Glossary
+		if TranslateGlossary != "" {
+			if glossary, err = translate.LoadGlossary(TranslateGlossary); err != nil {
+				sugar.Fatalf("Failed to load glossary: %v", err)
+			}
+		}
+
+		segments, err := translate.Segments(data)
+		if err != nil {
+			sugar.Fatalf("Failed to read resume values: %v", err)
+		}
+		if len(segments) == 0 {
+			sugar.Fatalf("The resume has no text to translate")
+		}
+
+		providerFor, err := newAssessProvider()
+		if err != nil {
+			sugar.Fatalf("%v", err)
+		}
+		agent := agentsdk.NewAgent(agentsdk.AgentConfig{
+			Name:         "resume-translator",
+			Provider:     providerFor(assessModel),
+			MaxIter:      1,
+			SystemPrompt: "You are a professional translator of resumes. You translate the values of JSON objects and answer with only JSON.",
+		})
+		ask := func(ctx context.Context, prompt string) (string, error) {
+			stream := agent.Invoke(ctx, []core.Message{core.NewUserMessage(prompt)})
+			header := buildAssessHeader(agent)
+			if assessVerbose {
+				return runVerbose(stream, header, os.Stderr)
+			}
+			return finalReport(stream, header)
+		}
+
+		fmt.Fprintf(os.Stderr, "Translating %d value(s) into %s with %s...\n", len(segments), languageName, assessModel)
+		result, err := translate.Run(context.Background(), ask, translate.Request{
+			Segments: segments,
+			Language: languageName,
+			Glossary: glossary,
+			Keep:     translate.ProperNouns(inputData.ToResume()),
+		}, translateRetries)
+		if err != nil {
+			sugar.Fatalf("Translation failed: %v", err)
+		}
+		for _, warning := range result.Warnings {
+			sugar.Warnf("Check the translation of %s", warning)
+		}
+
+		edits := make([]resume.TextEdit, len(segments))
+		for i, s := range segments {
+			edits[i] = resume.TextEdit{Path: s.Path, Original: s.Text, Replacement: result.Translations[s.Path]}
+		}
+		out, err := resume.ApplyYAMLEdits(data, edits)
+		if err != nil {
+			sugar.Fatalf("Failed to write translations: %v", err)
+		}
+
+		translated, err := resume.LoadResumeFromBytes(out, "yaml")
+		if err != nil {
+			sugar.Fatalf("Translated resume does not parse: %v", err)
+		}
+		if errs := resume.Validate(translated.ToResume()); len(errs) > 0 {
+			for _, e := range errs {
+				sugar.Errorf("%s: %s", e.Field, e.Message)
+			}
+			sugar.Fatalf("Translated resume failed validation with %d error(s)", len(errs))
+		}
+
+		if err := os.WriteFile(outputPath, out, 0644); err != nil {
+			sugar.Fatalf("Failed to write %s: %v", outputPath, err)
+		}
+		sugar.Infof("Translated %d value(s) into %s: %s", len(segments), languageName, outputPath)
+	},
+}
@@ -3,34 +3,26 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/urmzd/resume-generator/pkg/resume"
-	"github.com/urmzd/resume-generator/pkg/utils"
 	"go.uber.org/zap"
 )
 
 func initValidateCmd() {
 	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringVar(&InputFormat, "input-format", "", "Input format (yaml, json, jsonc, toml, md). Detected from the extension or content when omitted.")
 }
 
 var validateCmd = &cobra.Command{
 	Use:   "validate [file]",
 	Short: "Validate a resume configuration file",
+	Long:  "Validate a resume configuration file. Pass - to read the resume from standard input.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		logger, _ := zap.NewProduction()
 		sugar := logger.Sugar()
 
-		// Resolve file path
-		filePath, err := utils.ResolvePath(args[0])
+		inputData, _, err := loadInput(args[0], InputFormat)
 		if err != nil {
-			sugar.Fatalf("Error resolving file path: %v", err)
-		}
-		if !utils.FileExists(filePath) {
-			sugar.Fatalf("File does not exist: %s", filePath)
-		}
-
-		inputData, err := resume.LoadResumeFromFile(filePath)
-		if err != nil {
-			sugar.Fatalf("failed to load resume data: %v", err)
+			sugar.Fatalf("%v", err)
 		}
 
 		resumeData := inputData.ToResume()
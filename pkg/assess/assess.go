@@ -0,0 +1,309 @@
+package assess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// Dimension is one analyst's structured verdict.
+type Dimension struct {
+	Dimension   string   `json:"dimension" jsonschema:"description=Name of the dimension scored"`
+	Score       int      `json:"score" jsonschema:"minimum=1,maximum=10"`
+	Strengths   []string `json:"strengths"`
+	Weaknesses  []string `json:"weaknesses"`
+	Suggestions []string `json:"suggestions"`
+	// Requirements is set by the fit analyst only.
+	Requirements []Requirement `json:"requirements,omitempty" jsonschema:"description=Fit dimension only: every requirement of the job posting"`
+	// Edits is set by the writing and content analysts when asked for
+	// suggestions they can apply.
+	Edits []Edit `json:"edits,omitempty" jsonschema:"description=Only when asked: rewrites of single resume values"`
+}
+
+// Edit is a rewrite an analyst proposes for one value of the resume.
+type Edit struct {
+	Path        string `json:"path" jsonschema:"description=Path of the value in the resume data, such as experience.positions[2].highlights[1]"`
+	Original    string `json:"original" jsonschema:"description=The current text of the value, verbatim"`
+	Replacement string `json:"replacement"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// FitDimension scores a resume against a job posting requirement by
+// requirement.
+const FitDimension = "fit"
+
+// Requirement coverage levels.
+const (
+	Met     = "met"
+	Partial = "partial"
+	Unmet   = "missing"
+)
+
+// Requirement is one requirement of a job posting and how the resume
+// covers it.
+type Requirement struct {
+	Requirement string `json:"requirement"`
+	Coverage    string `json:"coverage" jsonschema:"enum=met,enum=partial,enum=missing"`
+	Evidence    string `json:"evidence,omitempty" jsonschema:"description=Resume text that shows the requirement is met"`
+}
+
+// Assessment is the coordinator's combined report. Overall is not part of
+// the model's answer: Parse computes it from the dimension scores.
+type Assessment struct {
+	Role       string      `json:"role" jsonschema:"description=Target role taken from the job posting or identified from the resume"`
+	Dimensions []Dimension `json:"dimensions"`
+	Priorities []string    `json:"priorities" jsonschema:"description=The most impactful improvements across all dimensions,maxItems=5"`
+	Overall    float64     `json:"overall" jsonschema:"-"`
+}
+
+// Weights maps each dimension an assessment must score to its relative
+// share of the overall score.
+type Weights map[string]float64
+
+// DefaultWeights are the dimensions of the default rubric.
+var DefaultWeights = builtinRubric(DefaultRubric).Weights(false)
+
+// JobWeights are the dimensions of the default rubric when assessing
+// against a job posting, with most of the weight on fit.
+var JobWeights = builtinRubric(DefaultRubric).Weights(true)
+
+// ParseWeights overrides weights with a spec such as "fit=40,content=20".
+// Values are relative; each must name a dimension of base and be positive.
+func ParseWeights(spec string, base Weights) (Weights, error) {
+	out := make(Weights, len(base))
+	for name, w := range base {
+		out[name] = w
+	}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("weight %q is not dimension=value", part)
+		}
+		if _, known := base[name]; !known {
+			return nil, fmt.Errorf("unknown dimension %q (dimensions: %s)", name, strings.Join(base.Names(), ", "))
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("weight of %s must be a positive number, got %q", name, value)
+		}
+		out[name] = w
+	}
+	return out, nil
+}
+
+// String lists the dimensions with their share of the overall score,
+// heaviest first, as in "fit 30%, content 25%".
+func (w Weights) String() string {
+	var total float64
+	for _, v := range w {
+		total += v
+	}
+	names := w.Names()
+	sort.SliceStable(names, func(i, j int) bool { return w[names[i]] > w[names[j]] })
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %.0f%%", name, 100*w[name]/total)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Names returns the dimensions in alphabetical order.
+func (w Weights) Names() []string {
+	names := make([]string, 0, len(w))
+	for name := range w {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Schema returns the JSON schema the coordinator's answer must follow.
+func Schema() string {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties: false,
+		DoNotReference:            true,
+	}
+	data, _ := json.MarshalIndent(reflector.Reflect(&Assessment{}), "", "  ")
+	return string(data)
+}
+
+// DimensionSchema returns the JSON schema of an analyst's answer.
+func DimensionSchema() string {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties: false,
+		DoNotReference:            true,
+	}
+	data, _ := json.MarshalIndent(reflector.Reflect(&Dimension{}), "", "  ")
+	return string(data)
+}
+
+var thinkBlock = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+// extractJSON returns the outermost JSON object in a model's answer,
+// dropping reasoning blocks, code fences and surrounding prose.
+func extractJSON(text string) (string, error) {
+	text = thinkBlock.ReplaceAllString(text, "")
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return "", errors.New("the answer contains no JSON object")
+	}
+	return text[start : end+1], nil
+}
+
+// Parse reads a coordinator's answer, validates it against the schema and
+// the dimensions in weights, and sets Overall. Validation errors list every
+// problem so a retry can fix them at once.
+func Parse(text string, weights Weights) (*Assessment, error) {
+	raw, err := extractJSON(text)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var a Assessment
+	if err := dec.Decode(&a); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := a.validate(weights); err != nil {
+		return nil, err
+	}
+	a.Overall = a.score(weights)
+	return &a, nil
+}
+
+func (a *Assessment) validate(weights Weights) error {
+	var problems []string
+	if strings.TrimSpace(a.Role) == "" {
+		problems = append(problems, "role is empty")
+	}
+	seen := map[string]bool{}
+	for i := range a.Dimensions {
+		d := &a.Dimensions[i]
+		d.Dimension = strings.ToLower(strings.TrimSpace(d.Dimension))
+		switch {
+		case weights[d.Dimension] == 0:
+			problems = append(problems, fmt.Sprintf("unexpected dimension %q (expected %s)", d.Dimension, strings.Join(weights.Names(), ", ")))
+		case seen[d.Dimension]:
+			problems = append(problems, fmt.Sprintf("dimension %q appears more than once", d.Dimension))
+		}
+		seen[d.Dimension] = true
+		if d.Score < 1 || d.Score > 10 {
+			problems = append(problems, fmt.Sprintf("%s score %d is outside 1-10", d.Dimension, d.Score))
+		}
+		if len(d.Suggestions) == 0 {
+			problems = append(problems, fmt.Sprintf("%s has no suggestions", d.Dimension))
+		}
+		if d.Dimension == FitDimension && len(d.Requirements) == 0 {
+			problems = append(problems, "fit lists no requirements")
+		}
+		for _, req := range d.Requirements {
+			switch req.Coverage {
+			case Met, Partial, Unmet:
+			default:
+				problems = append(problems, fmt.Sprintf("requirement %q has coverage %q (expected met, partial or missing)", req.Requirement, req.Coverage))
+			}
+		}
+		for _, edit := range d.Edits {
+			switch {
+			case strings.TrimSpace(edit.Path) == "":
+				problems = append(problems, fmt.Sprintf("%s proposes an edit without a path", d.Dimension))
+			case strings.TrimSpace(edit.Replacement) == "" || edit.Replacement == edit.Original:
+				problems = append(problems, fmt.Sprintf("%s proposes an edit of %s that changes nothing", d.Dimension, edit.Path))
+			}
+		}
+	}
+	for _, name := range weights.Names() {
+		if !seen[name] {
+			problems = append(problems, fmt.Sprintf("dimension %q is missing", name))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Edits returns the edits proposed across all dimensions.
+func (a *Assessment) Edits() []Edit {
+	var out []Edit
+	for _, d := range a.Dimensions {
+		out = append(out, d.Edits...)
+	}
+	return out
+}
+
+// score is the weighted average of the dimension scores, to one decimal.
+func (a *Assessment) score(weights Weights) float64 {
+	var total, sum float64
+	for _, d := range a.Dimensions {
+		total += weights[d.Dimension]
+		sum += weights[d.Dimension] * float64(d.Score)
+	}
+	if total == 0 {
+		return 0
+	}
+	return math.Round(sum/total*10) / 10
+}
+
+// Markdown renders the assessment as a report for the terminal.
+func (a *Assessment) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Target role:** %s\n\n", a.Role)
+	fmt.Fprintf(&b, "**Overall score:** %.1f/10\n\n", a.Overall)
+	b.WriteString("| Dimension | Score |\n|---|---|\n")
+	for _, d := range a.Dimensions {
+		fmt.Fprintf(&b, "| %s | %d/10 |\n", d.Dimension, d.Score)
+	}
+	if len(a.Priorities) > 0 {
+		b.WriteString("\n## Top priorities\n\n")
+		for i, p := range a.Priorities {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, p)
+		}
+	}
+	for _, d := range a.Dimensions {
+		fmt.Fprintf(&b, "\n## %s (%d/10)\n", strings.ToUpper(d.Dimension[:1])+d.Dimension[1:], d.Score)
+		for _, section := range []struct {
+			title string
+			items []string
+		}{{"Strengths", d.Strengths}, {"Weaknesses", d.Weaknesses}, {"Suggestions", d.Suggestions}} {
+			if len(section.items) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n**%s**\n\n", section.title)
+			for _, item := range section.items {
+				fmt.Fprintf(&b, "- %s\n", item)
+			}
+		}
+		if len(d.Requirements) > 0 {
+			b.WriteString("\n**Requirements**\n\n| Requirement | Coverage | Evidence |\n|---|---|---|\n")
+			for _, req := range d.Requirements {
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", cell(req.Requirement), req.Coverage, cell(req.Evidence))
+			}
+		}
+		if len(d.Edits) > 0 {
+			b.WriteString("\n**Proposed edits**\n\n")
+			for _, edit := range d.Edits {
+				fmt.Fprintf(&b, "- `%s`: %s\n", edit.Path, edit.Replacement)
+			}
+		}
+	}
+	return b.String()
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", "\\|"), "\n", " ")
+}
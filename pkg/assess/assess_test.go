@@ -0,0 +1,219 @@
+package assess
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validAnswer = `<think>Four reports are in.</think>
+Here is the final report:
+` + "```json" + `
+{
+  "role": "Backend engineering",
+  "dimensions": [
+    {"dimension": "content", "score": 7, "strengths": ["Metrics"], "weaknesses": [], "suggestions": ["Quantify the migration"]},
+    {"dimension": "Writing", "score": 8, "strengths": [], "weaknesses": [], "suggestions": ["Trim the summary"]},
+    {"dimension": "industry", "score": 6, "strengths": [], "weaknesses": ["No cloud keywords"], "suggestions": ["Add AWS"]},
+    {"dimension": "structure", "score": 9, "strengths": [], "weaknesses": [], "suggestions": ["Move skills up"]}
+  ],
+  "priorities": ["Quantify the migration", "Add AWS"]
+}
+` + "```"
+
+func TestParse(t *testing.T) {
+	a, err := Parse(validAnswer, DefaultWeights)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// 0.3*7 + 0.25*8 + 0.25*6 + 0.2*9 = 7.4
+	if a.Overall != 7.4 {
+		t.Errorf("Overall = %v, want 7.4", a.Overall)
+	}
+	if a.Dimensions[1].Dimension != "writing" {
+		t.Errorf("dimension names are not normalized: %q", a.Dimensions[1].Dimension)
+	}
+	if md := a.Markdown(); !strings.Contains(md, "**Overall score:** 7.4/10") || !strings.Contains(md, "1. Quantify the migration") {
+		t.Errorf("Markdown() =\n%s", md)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		want   string
+	}{
+		{"prose", "CONTENT SCORE: 7/10", "no JSON object"},
+		{"unknown field", `{"role": "x", "dimensions": [], "priorities": [], "grade": "A"}`, "unknown field"},
+		{"out of range", strings.Replace(validAnswer, `"score": 9`, `"score": 12`, 1), "structure score 12 is outside 1-10"},
+		{"missing dimension", strings.Replace(validAnswer, `"dimension": "industry"`, `"dimension": "keywords"`, 1), `dimension "industry" is missing`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.answer, DefaultWeights)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunRetries(t *testing.T) {
+	ask := func(ctx context.Context, prompt string) (string, error) {
+		return "CONTENT SCORE: 7/10", nil
+	}
+	var repairs []string
+	repair := func(ctx context.Context, prompt string) (string, error) {
+		repairs = append(repairs, prompt)
+		if len(repairs) == 1 {
+			return `{"role": "x"}`, nil
+		}
+		return validAnswer, nil
+	}
+
+	a, err := Run(context.Background(), ask, repair, "assess", DefaultWeights, 2)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if a.Overall != 7.4 || len(repairs) != 2 {
+		t.Errorf("Overall = %v after %d repairs, want 7.4 after 2", a.Overall, len(repairs))
+	}
+	if !strings.Contains(repairs[1], `dimension "content" is missing`) {
+		t.Errorf("repair prompt does not explain the problem:\n%s", repairs[1])
+	}
+
+	_, err = Run(context.Background(), ask, ask, "assess", DefaultWeights, 1)
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempt(s)") {
+		t.Errorf("Run() error = %v, want failure after 2 attempts", err)
+	}
+	if _, err = Run(context.Background(), ask, ask, "assess", DefaultWeights, -1); err == nil || !strings.Contains(err.Error(), "after 1 attempt(s)") {
+		t.Errorf("Run() with negative retries error = %v, want failure after 1 attempt", err)
+	}
+
+	failing := func(ctx context.Context, prompt string) (string, error) { return "", errors.New("connection refused") }
+	if _, err := Run(context.Background(), failing, repair, "assess", DefaultWeights, 2); err == nil {
+		t.Error("Run() with a failing model succeeded")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	dir := t.TempDir()
+	resumePath := filepath.Join(dir, "resume.yml")
+	path := HistoryPath(resumePath)
+	if path != filepath.Join(dir, "resume.assess.jsonl") {
+		t.Errorf("HistoryPath() = %q", path)
+	}
+
+	a, err := Parse(validAnswer, DefaultWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := NewEntry(resumePath, []byte("v1"), "ollama", "qwen3.5:4b", a)
+	second := NewEntry(resumePath, []byte("v2"), "ollama", "qwen3.5:4b", a)
+	for _, e := range []Entry{first, second} {
+		if err := AppendHistory(path, e); err != nil {
+			t.Fatalf("AppendHistory() error = %v", err)
+		}
+	}
+
+	entries, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Revision == entries[1].Revision || entries[1].Scores["industry"] != 6 || entries[1].Overall != 7.4 {
+		t.Errorf("entries = %+v", entries)
+	}
+	if entries, err := ReadHistory(filepath.Join(dir, "none.jsonl")); err != nil || entries != nil {
+		t.Errorf("ReadHistory() of a missing file = %v, %v", entries, err)
+	}
+}
+
+func TestParseFit(t *testing.T) {
+	weights, err := ParseWeights("fit=45, content=10", JobWeights)
+	if err != nil {
+		t.Fatalf("ParseWeights() error = %v", err)
+	}
+	if got := weights.String(); got != "fit 45%, industry 15%, structure 15%, writing 15%, content 10%" {
+		t.Errorf("String() = %q", got)
+	}
+
+	fit := `{"dimension": "fit", "score": 4, "strengths": [], "weaknesses": [], "suggestions": ["Add Kafka"], "requirements": [
+      {"requirement": "5+ years of Go", "coverage": "met", "evidence": "Go at Acme since 2019"},
+      {"requirement": "Kafka", "coverage": "missing"}
+    ]},
+    {"dimension": "content"`
+	answer := strings.Replace(validAnswer, `{"dimension": "content"`, fit, 1)
+	a, err := Parse(answer, weights)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// (45*4 + 10*7 + 15*8 + 15*6 + 15*9) / 100 = 5.95
+	if a.Overall != 6 {
+		t.Errorf("Overall = %v, want 6", a.Overall)
+	}
+	if md := a.Markdown(); !strings.Contains(md, "| Kafka | missing |  |") {
+		t.Errorf("Markdown() has no requirements table:\n%s", md)
+	}
+
+	if _, err := Parse(validAnswer, weights); err == nil || !strings.Contains(err.Error(), `dimension "fit" is missing`) {
+		t.Errorf("Parse() without fit error = %v", err)
+	}
+	bad := strings.Replace(answer, `"coverage": "met"`, `"coverage": "yes"`, 1)
+	if _, err := Parse(bad, weights); err == nil || !strings.Contains(err.Error(), `coverage "yes"`) {
+		t.Errorf("Parse() with a bad coverage error = %v", err)
+	}
+
+	for _, spec := range []string{"fit", "taste=3", "fit=0", "fit=high"} {
+		if _, err := ParseWeights(spec, JobWeights); err == nil {
+			t.Errorf("ParseWeights(%q) succeeded", spec)
+		}
+	}
+}
+
+func TestParseEdits(t *testing.T) {
+	edits := `"suggestions": ["Trim the summary"], "edits": [
+      {"path": "summary", "original": "Engineer who likes Go.", "replacement": "Backend engineer.", "reason": "Shorter"}
+    ]}`
+	answer := strings.Replace(validAnswer, `"suggestions": ["Trim the summary"]}`, edits, 1)
+	a, err := Parse(answer, DefaultWeights)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := a.Edits(); len(got) != 1 || got[0].Path != "summary" || got[0].Reason != "Shorter" {
+		t.Errorf("Edits() = %+v", got)
+	}
+	if md := a.Markdown(); !strings.Contains(md, "- `summary`: Backend engineer.") {
+		t.Errorf("Markdown() has no proposed edits:\n%s", md)
+	}
+
+	noop := strings.Replace(answer, `"replacement": "Backend engineer."`, `"replacement": "Engineer who likes Go."`, 1)
+	if _, err := Parse(noop, DefaultWeights); err == nil || !strings.Contains(err.Error(), "edit of summary that changes nothing") {
+		t.Errorf("Parse() with a no-op edit error = %v", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := Cache{Dir: filepath.Join(t.TempDir(), "assess")}
+	key := CacheKey("resume", "qwen3.5:4b", "prompt")
+	if key == CacheKey("resum", "eqwen3.5:4b", "prompt") {
+		t.Error("CacheKey() ignores part boundaries")
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("Get() hit an empty cache")
+	}
+
+	a, err := Parse(validAnswer, DefaultWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put(key, a); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	cached, ok := cache.Get(key)
+	if !ok || cached.Overall != 7.4 || cached.Role != a.Role || len(cached.Dimensions) != 4 {
+		t.Errorf("Get() = %+v, %v", cached, ok)
+	}
+}
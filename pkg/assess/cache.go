@@ -0,0 +1,81 @@
+package assess
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Cache keeps assessments on disk, one JSON file per key.
+type Cache struct {
+	Dir string
+}
+
+// DefaultCacheDir is the per-user directory assessments are cached in.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "resume-generator", "assess"), nil
+}
+
+// CacheKey hashes everything that shapes an assessment: the resume as the
+// model sees it, the model, the prompts and the weights. Parts are
+// length-prefixed so that moving text from one part to the next changes
+// the key.
+func CacheKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%d:%s;", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Get returns the assessment cached under key. A missing or unreadable
+// entry is a miss.
+func (c Cache) Get(key string) (*Assessment, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var a Assessment
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, false
+	}
+	return &a, true
+}
+
+// Put stores an assessment under key, replacing any earlier entry.
+func (c Cache) Put(key string, a *Assessment) error {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a concurrent Get never reads a
+	// partial entry.
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
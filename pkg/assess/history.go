@@ -0,0 +1,100 @@
+package assess
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is one line of an assessment history file.
+type Entry struct {
+	Time     time.Time      `json:"time"`
+	Resume   string         `json:"resume"`
+	Job      string         `json:"job,omitempty"`
+	Rubric   string         `json:"rubric,omitempty"`
+	Revision string         `json:"revision"`
+	Provider string         `json:"provider"`
+	Model    string         `json:"model"`
+	Role     string         `json:"role"`
+	Overall  float64        `json:"overall"`
+	Scores   map[string]int `json:"scores"`
+}
+
+// NewEntry records an assessment of the resume with the given content.
+// Revision is a short hash of the content, so repeated runs against an
+// unchanged file can be told apart from new revisions.
+func NewEntry(resumePath string, content []byte, provider, model string, a *Assessment) Entry {
+	sum := sha256.Sum256(content)
+	scores := make(map[string]int, len(a.Dimensions))
+	for _, d := range a.Dimensions {
+		scores[d.Dimension] = d.Score
+	}
+	return Entry{
+		Time:     time.Now().UTC().Truncate(time.Second),
+		Resume:   resumePath,
+		Revision: hex.EncodeToString(sum[:])[:12],
+		Provider: provider,
+		Model:    model,
+		Role:     a.Role,
+		Overall:  a.Overall,
+		Scores:   scores,
+	}
+}
+
+// HistoryPath returns the default history file of a resume: resume.yml
+// keeps its history in resume.assess.jsonl next to it.
+func HistoryPath(resumePath string) string {
+	return strings.TrimSuffix(resumePath, filepath.Ext(resumePath)) + ".assess.jsonl"
+}
+
+// AppendHistory adds an entry to a JSON Lines history file, creating it
+// when needed.
+func AppendHistory(path string, e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadHistory returns the entries of a history file, oldest first. A
+// missing file has no entries.
+func ReadHistory(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
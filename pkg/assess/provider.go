@@ -0,0 +1,73 @@
+package assess
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURLs lists the inference servers assess can talk to, with
+// the base URL each one listens on by default.
+var DefaultBaseURLs = map[string]string{
+	"ollama": "http://localhost:11434",
+	"openai": "http://localhost:8080/v1",
+}
+
+// Endpoint is the inference server an assessment is sent to.
+type Endpoint struct {
+	Provider string
+	BaseURL  string
+	APIKey   string
+}
+
+// ResolveEndpoint picks the server for provider. An empty baseURL falls
+// back to ollamaURL for ollama, then to the provider's default.
+func ResolveEndpoint(provider, baseURL, ollamaURL, apiKey string) (Endpoint, error) {
+	defaultURL, ok := DefaultBaseURLs[provider]
+	if !ok {
+		return Endpoint{}, fmt.Errorf("unsupported provider %q (supported: ollama, openai)", provider)
+	}
+	if baseURL == "" && provider == "ollama" {
+		baseURL = ollamaURL
+	}
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+	return Endpoint{
+		Provider: provider,
+		BaseURL:  strings.TrimRight(baseURL, "/"),
+		APIKey:   apiKey,
+	}, nil
+}
+
+// ProbeURL is the URL that answers when the server is up: the model list
+// for OpenAI-compatible servers, the root for ollama.
+func (e Endpoint) ProbeURL() string {
+	if e.Provider == "openai" {
+		return e.BaseURL + "/models"
+	}
+	return e.BaseURL
+}
+
+// Probe checks that the server answers, authenticating with the API key
+// when one is set. apiKeyEnv names the variable the key came from.
+func (e Endpoint) Probe(apiKeyEnv string) error {
+	req, err := http.NewRequest(http.MethodGet, e.ProbeURL(), nil)
+	if err != nil {
+		return err
+	}
+	if e.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.APIKey)
+	}
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%s: check the API key in $%s", resp.Status, apiKeyEnv)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package assess
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveEndpoint(t *testing.T) {
+	tests := []struct {
+		name                         string
+		provider, baseURL, ollamaURL string
+		wantURL, wantProbe           string
+	}{
+		{"ollama default", "ollama", "", "", "http://localhost:11434", "http://localhost:11434"},
+		{"ollama url flag", "ollama", "", "http://gpu:11434/", "http://gpu:11434", "http://gpu:11434"},
+		{"base url wins", "ollama", "http://other:1", "http://gpu:11434", "http://other:1", "http://other:1"},
+		{"openai default", "openai", "", "http://gpu:11434", "http://localhost:8080/v1", "http://localhost:8080/v1/models"},
+		{"openai trailing slash", "openai", "https://api.example.com/v1/", "", "https://api.example.com/v1", "https://api.example.com/v1/models"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, err := ResolveEndpoint(tt.provider, tt.baseURL, tt.ollamaURL, "")
+			if err != nil {
+				t.Fatalf("ResolveEndpoint: %v", err)
+			}
+			if endpoint.BaseURL != tt.wantURL {
+				t.Errorf("BaseURL = %q, want %q", endpoint.BaseURL, tt.wantURL)
+			}
+			if got := endpoint.ProbeURL(); got != tt.wantProbe {
+				t.Errorf("ProbeURL = %q, want %q", got, tt.wantProbe)
+			}
+		})
+	}
+}
+
+func TestResolveEndpointUnsupported(t *testing.T) {
+	_, err := ResolveEndpoint("anthropic", "", "", "")
+	if err == nil || !strings.Contains(err.Error(), `unsupported provider "anthropic"`) {
+		t.Fatalf("err = %v, want unsupported provider", err)
+	}
+}
+
+func TestProbe(t *testing.T) {
+	var gotPath, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	endpoint, err := ResolveEndpoint("openai", srv.URL+"/v1", "", "good")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := endpoint.Probe("OPENAI_API_KEY"); err != nil {
+		t.Fatalf("Probe: %v", err)
+	}
+	if gotPath != "/v1/models" || gotAuth != "Bearer good" {
+		t.Errorf("probe hit %q with %q, want /v1/models with the key", gotPath, gotAuth)
+	}
+
+	endpoint.APIKey = "bad"
+	err = endpoint.Probe("OPENAI_API_KEY")
+	if err == nil || !strings.Contains(err.Error(), "$OPENAI_API_KEY") {
+		t.Fatalf("err = %v, want a hint about $OPENAI_API_KEY", err)
+	}
+}
+
+func TestProbeUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	endpoint, err := ResolveEndpoint("ollama", url, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := endpoint.Probe(""); err == nil {
+		t.Fatal("Probe succeeded against a closed server")
+	}
+}
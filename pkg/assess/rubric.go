@@ -0,0 +1,275 @@
+package assess
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed rubrics/*.yml
+var builtinRubrics embed.FS
+
+// DefaultRubric is the rubric assess uses unless told otherwise.
+const DefaultRubric = "default"
+
+// BuiltinSource is the Source of rubrics compiled into the binary.
+const BuiltinSource = "built-in"
+
+// Rubric defines the analysts of an assessment and how their scores
+// combine:
+//
+//	name: academic
+//	description: Review of an academic CV
+//	analysts:
+//	  - name: publications_analyst
+//	    dimension: publications
+//	    weight: 40
+//	    model: qwen3:14b
+//	    description: Reviews the publication record. Delegate the full CV.
+//	    prompt: |-
+//	      You are an academic CV reviewer. Score the PUBLICATIONS (1-10) ...
+type Rubric struct {
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description"`
+	Analysts    []Analyst `yaml:"analysts"`
+	// Source is the file the rubric was read from, or BuiltinSource.
+	Source string `yaml:"-"`
+}
+
+// Analyst is a sub-agent that scores one dimension.
+//
+// Weight is the analyst's relative share of the overall score, and
+// JobWeight its share when assessing against a job posting, which
+// defaults to Weight. An analyst with only a JobWeight runs only with a
+// job posting. Suggest marks the analysts that propose edits, and Model
+// overrides the model the other agents use.
+type Analyst struct {
+	Name        string  `yaml:"name"`
+	Dimension   string  `yaml:"dimension"`
+	Description string  `yaml:"description"`
+	Prompt      string  `yaml:"prompt"`
+	Weight      float64 `yaml:"weight"`
+	JobWeight   float64 `yaml:"job_weight"`
+	Suggest     bool    `yaml:"suggest"`
+	Model       string  `yaml:"model"`
+}
+
+var agentName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ParseRubric reads a rubric from YAML and checks that it can run.
+func ParseRubric(data []byte, source string) (*Rubric, error) {
+	var r Rubric
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	r.Source = source
+
+	var problems []string
+	if !agentName.MatchString(r.Name) {
+		problems = append(problems, fmt.Sprintf("name %q must be lowercase letters, digits and underscores", r.Name))
+	}
+	if len(r.Analysts) == 0 {
+		problems = append(problems, "no analysts")
+	}
+	names, dimensions := map[string]bool{}, map[string]bool{}
+	for i := range r.Analysts {
+		a := &r.Analysts[i]
+		a.Dimension = strings.ToLower(strings.TrimSpace(a.Dimension))
+		if a.JobWeight == 0 {
+			a.JobWeight = a.Weight
+		}
+		label := fmt.Sprintf("analysts[%d]", i)
+		if a.Name != "" {
+			label = a.Name
+		}
+		switch {
+		case !agentName.MatchString(a.Name):
+			problems = append(problems, fmt.Sprintf("%s: name %q must be lowercase letters, digits and underscores", label, a.Name))
+		case names[a.Name]:
+			problems = append(problems, fmt.Sprintf("%s: name appears more than once", label))
+		}
+		names[a.Name] = true
+		switch {
+		case a.Dimension == "":
+			problems = append(problems, fmt.Sprintf("%s: dimension is empty", label))
+		case dimensions[a.Dimension]:
+			problems = append(problems, fmt.Sprintf("%s: dimension %q is scored by another analyst", label, a.Dimension))
+		}
+		dimensions[a.Dimension] = true
+		if strings.TrimSpace(a.Prompt) == "" {
+			problems = append(problems, fmt.Sprintf("%s: prompt is empty", label))
+		}
+		if strings.TrimSpace(a.Description) == "" {
+			problems = append(problems, fmt.Sprintf("%s: description is empty", label))
+		}
+		if a.Weight < 0 || a.JobWeight <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: weight or job_weight must be positive", label))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s: %s", source, strings.Join(problems, "; "))
+	}
+	return &r, nil
+}
+
+// Select returns the analysts that run with or without a job posting.
+func (r *Rubric) Select(withJob bool) []Analyst {
+	var out []Analyst
+	for _, a := range r.Analysts {
+		if withJob || a.Weight > 0 {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Weights returns the dimension weights with or without a job posting.
+func (r *Rubric) Weights(withJob bool) Weights {
+	w := Weights{}
+	for _, a := range r.Select(withJob) {
+		if withJob {
+			w[a.Dimension] = a.JobWeight
+		} else {
+			w[a.Dimension] = a.Weight
+		}
+	}
+	return w
+}
+
+// RubricDir is the directory of user rubrics: $RESUME_RUBRICS_DIR, or
+// resume-generator/rubrics in the user config directory.
+func RubricDir() (string, error) {
+	if dir := strings.TrimSpace(os.Getenv("RESUME_RUBRICS_DIR")); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "resume-generator", "rubrics"), nil
+}
+
+// ListRubrics returns the built-in rubrics and the *.yml and *.yaml
+// rubrics in dir, sorted by name. A rubric in dir replaces a built-in one
+// of the same name. Files in dir that are not valid rubrics are left out
+// and returned in skipped, so one broken file does not hide the others.
+func ListRubrics(dir string) (rubrics []*Rubric, skipped []error, err error) {
+	byName := map[string]*Rubric{}
+	entries, err := builtinRubrics.ReadDir("rubrics")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		data, err := builtinRubrics.ReadFile("rubrics/" + entry.Name())
+		if err != nil {
+			return nil, nil, err
+		}
+		r, err := ParseRubric(data, BuiltinSource)
+		if err != nil {
+			return nil, nil, err
+		}
+		byName[r.Name] = r
+	}
+
+	if dir != "" {
+		files, err := os.ReadDir(dir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, nil, err
+		}
+		for _, file := range files {
+			ext := filepath.Ext(file.Name())
+			if file.IsDir() || (ext != ".yml" && ext != ".yaml") {
+				continue
+			}
+			r, err := readUserRubric(filepath.Join(dir, file.Name()))
+			if err != nil {
+				skipped = append(skipped, err)
+				continue
+			}
+			byName[r.Name] = r
+		}
+	}
+
+	rubrics = make([]*Rubric, 0, len(byName))
+	for _, r := range byName {
+		rubrics = append(rubrics, r)
+	}
+	sort.Slice(rubrics, func(i, j int) bool { return rubrics[i].Name < rubrics[j].Name })
+	return rubrics, skipped, nil
+}
+
+// ReadRubric reads a rubric file.
+func ReadRubric(path string) (*Rubric, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRubric(data, path)
+}
+
+// readUserRubric reads a rubric of the user rubric directory, whose file
+// is named after the rubric so FindRubric can read it alone.
+func readUserRubric(path string) (*Rubric, error) {
+	r, err := ReadRubric(path)
+	if err != nil {
+		return nil, err
+	}
+	if stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)); r.Name != stem {
+		return nil, fmt.Errorf("%s: rubric %s must be in a file named %s.yml", path, r.Name, r.Name)
+	}
+	return r, nil
+}
+
+// FindRubric returns the rubric named name: name.yml or name.yaml in dir,
+// otherwise the built-in rubric of that name. It reads name as a file when
+// it is a path to one. Other files in dir are not read, so a broken rubric
+// does not affect the others.
+func FindRubric(name, dir string) (*Rubric, error) {
+	if strings.ContainsAny(name, `/\`) || strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml") {
+		return ReadRubric(name)
+	}
+	if dir != "" {
+		for _, ext := range []string{".yml", ".yaml"} {
+			path := filepath.Join(dir, name+ext)
+			if _, err := os.Stat(path); err == nil {
+				return readUserRubric(path)
+			}
+		}
+	}
+	if data, err := builtinRubrics.ReadFile("rubrics/" + name + ".yml"); err == nil {
+		return ParseRubric(data, BuiltinSource)
+	}
+
+	rubrics, _, err := ListRubrics(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(rubrics))
+	for i, r := range rubrics {
+		names[i] = r.Name
+	}
+	return nil, fmt.Errorf("unknown rubric %q (rubrics: %s)", name, strings.Join(names, ", "))
+}
+
+// builtinRubric returns a rubric compiled into the binary.
+func builtinRubric(name string) *Rubric {
+	data, err := builtinRubrics.ReadFile("rubrics/" + name + ".yml")
+	if err != nil {
+		panic(err)
+	}
+	r, err := ParseRubric(data, BuiltinSource)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
@@ -0,0 +1,119 @@
+package assess
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const clearanceRubric = `name: clearance
+description: Defense contractor review
+analysts:
+  - name: clearance_analyst
+    dimension: Clearance
+    weight: 3
+    model: qwen3:14b
+    description: Reviews clearance eligibility. Delegate the full resume.
+    prompt: Score CLEARANCE (1-10).
+  - name: writing_analyst
+    dimension: writing
+    weight: 1
+    suggest: true
+    description: Reviews the writing. Delegate the full resume.
+    prompt: Score WRITING (1-10).
+  - name: fit_analyst
+    dimension: fit
+    job_weight: 2
+    description: Reviews fit. Delegate the posting and the resume.
+    prompt: Score FIT (1-10).
+`
+
+func TestDefaultRubric(t *testing.T) {
+	r := builtinRubric(DefaultRubric)
+	if got := r.Weights(false).String(); got != "content 30%, industry 25%, writing 25%, structure 20%" {
+		t.Errorf("Weights(false) = %q", got)
+	}
+	if got := r.Weights(true).String(); got != "fit 30%, content 25%, industry 15%, structure 15%, writing 15%" {
+		t.Errorf("Weights(true) = %q", got)
+	}
+	if n := len(r.Select(false)); n != 4 {
+		t.Errorf("Select(false) has %d analysts, want 4", n)
+	}
+}
+
+func TestRubrics(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "clearance.yml"), []byte(clearanceRubric), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a rubric"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("name: [broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "misnamed.yml"), []byte(clearanceRubric), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rubrics, skipped, err := ListRubrics(dir)
+	if err != nil {
+		t.Fatalf("ListRubrics() error = %v", err)
+	}
+	if len(rubrics) != 2 || rubrics[0].Name != "clearance" || rubrics[1].Source != BuiltinSource {
+		t.Fatalf("ListRubrics() = %+v", rubrics)
+	}
+	if len(skipped) != 2 || !strings.Contains(skipped[0].Error(), "broken.yml") || !strings.Contains(skipped[1].Error(), "named clearance.yml") {
+		t.Errorf("ListRubrics() skipped = %v", skipped)
+	}
+	if _, err := FindRubric("default", dir); err != nil {
+		t.Errorf("FindRubric(default) with a broken user rubric error = %v", err)
+	}
+	if _, err := FindRubric("broken", dir); err == nil {
+		t.Error("FindRubric(broken) succeeded")
+	}
+
+	r, err := FindRubric("clearance", dir)
+	if err != nil {
+		t.Fatalf("FindRubric() error = %v", err)
+	}
+	if r.Analysts[0].Dimension != "clearance" || r.Analysts[0].Model != "qwen3:14b" {
+		t.Errorf("analyst = %+v", r.Analysts[0])
+	}
+	if got := r.Weights(false).String(); got != "clearance 75%, writing 25%" {
+		t.Errorf("Weights(false) = %q", got)
+	}
+	if got := r.Weights(true).String(); got != "clearance 50%, fit 33%, writing 17%" {
+		t.Errorf("Weights(true) = %q", got)
+	}
+
+	if _, err := FindRubric(filepath.Join(dir, "clearance.yml"), ""); err != nil {
+		t.Errorf("FindRubric() of a path error = %v", err)
+	}
+	if _, err := FindRubric("academic", dir); err == nil || !strings.Contains(err.Error(), "rubrics: clearance, default") {
+		t.Errorf("FindRubric() of an unknown rubric error = %v", err)
+	}
+}
+
+func TestParseRubricInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(string) string
+		want string
+	}{
+		{"unknown field", func(s string) string { return strings.Replace(s, "weight: 3", "wieght: 3", 1) }, "field wieght not found"},
+		{"duplicate dimension", func(s string) string { return strings.Replace(s, "dimension: writing", "dimension: clearance", 1) }, `dimension "clearance" is scored by another analyst`},
+		{"bad name", func(s string) string { return strings.Replace(s, "name: writing_analyst", "name: Writing Analyst", 1) }, "must be lowercase"},
+		{"no weight", func(s string) string { return strings.Replace(s, "job_weight: 2", "job_weight: 0", 1) }, "fit_analyst: weight or job_weight must be positive"},
+		{"no prompt", func(s string) string { return strings.Replace(s, "prompt: Score FIT (1-10).", `prompt: ""`, 1) }, "fit_analyst: prompt is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRubric([]byte(tt.edit(clearanceRubric)), "clearance.yml")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseRubric() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package assess
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Ask sends a prompt to a model and returns its final answer.
+type Ask func(ctx context.Context, prompt string) (string, error)
+
+// Run asks for an assessment and parses the answer. While the answer is
+// malformed, up to retries more times, repair is asked to correct it
+// given the validation errors; repair only needs to reformat, so it can be
+// a single model call rather than the whole analyst team.
+func Run(ctx context.Context, ask, repair Ask, prompt string, weights Weights, retries int) (*Assessment, error) {
+	answer, err := ask(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		a, perr := Parse(answer, weights)
+		if perr == nil {
+			return a, nil
+		}
+		if attempt >= retries {
+			return nil, fmt.Errorf("malformed assessment after %d attempt(s): %w", attempt+1, perr)
+		}
+		answer, err = repair(ctx, RepairPrompt(answer, perr, weights))
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// RepairPrompt asks a model to correct an answer that failed validation.
+func RepairPrompt(answer string, problem error, weights Weights) string {
+	var b strings.Builder
+	b.WriteString("The following resume assessment does not match the required JSON schema.\n\n")
+	fmt.Fprintf(&b, "Problems: %v\n\n", problem)
+	fmt.Fprintf(&b, "It must score exactly these dimensions: %s.\n\n", strings.Join(weights.Names(), ", "))
+	fmt.Fprintf(&b, "Schema:\n%s\n\n", Schema())
+	fmt.Fprintf(&b, "Assessment:\n---\n%s\n---\n\n", answer)
+	b.WriteString("Return only the corrected JSON object, keeping the content of the assessment.")
+	return b.String()
+}
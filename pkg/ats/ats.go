@@ -0,0 +1,433 @@
+package ats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/urmzd/resume-generator/pkg/generators"
+	"github.com/urmzd/resume-generator/pkg/pdf"
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// Names of the checks in a Report.
+const (
+	CheckContent = "content"
+	CheckOrder   = "reading order"
+	CheckGlyphs  = "glyphs"
+	CheckContact = "contact"
+	CheckColumns = "columns"
+)
+
+// checkWeights is how much each check counts toward the overall score.
+var checkWeights = map[string]int{
+	CheckContent: 35,
+	CheckOrder:   15,
+	CheckGlyphs:  20,
+	CheckContact: 15,
+	CheckColumns: 15,
+}
+
+// headerBand is the distance from the top and bottom page edges, in points,
+// that applicant tracking systems commonly discard as header and footer.
+const headerBand = 0.3 * 72
+
+// Result is the outcome of one check. Score runs from 0 to 1.
+type Result struct {
+	Name   string
+	Score  float64
+	Issues []string
+}
+
+// Report is the applicant tracking system compatibility of a rendered PDF
+// measured against the resume it was rendered from.
+type Report struct {
+	Pages   int
+	Results []Result
+}
+
+// Score returns the weighted score of all checks from 0 to 100.
+func (r *Report) Score() int {
+	var total, weights float64
+	for _, res := range r.Results {
+		w := float64(checkWeights[res.Name])
+		total += w * res.Score
+		weights += w
+	}
+	if weights == 0 {
+		return 0
+	}
+	return int(math.Round(100 * total / weights))
+}
+
+// Verdict summarizes the score: "safe" from 85, "review" from 60 and
+// "risky" below.
+func (r *Report) Verdict() string {
+	switch score := r.Score(); {
+	case score >= 85:
+		return "safe"
+	case score >= 60:
+		return "review"
+	default:
+		return "risky"
+	}
+}
+
+// CheckFile checks the PDF at path against r.
+func CheckFile(path string, r *resume.Resume) (*Report, error) {
+	doc, err := pdf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return Check(doc, r)
+}
+
+// Check extracts the text layer of doc the way a parser would and checks it
+// against r: that every company, title, start year and skill is present and
+// positions appear in the order they are rendered, that no glyph lost its
+// Unicode mapping, that contact details are in the page body and that
+// bullets are not interleaved with neighbouring columns.
+func Check(doc *pdf.Reader, r *resume.Resume) (*Report, error) {
+	pages, err := doc.Pages()
+	if err != nil {
+		return nil, err
+	}
+	layer := make([]pageText, len(pages))
+	for i, p := range pages {
+		lines, err := p.Lines()
+		if err != nil {
+			return nil, err
+		}
+		_, height := p.Size()
+		layer[i] = pageText{lines: lines, top: p.CropBox.URY, height: height}
+	}
+	return check(layer, r), nil
+}
+
+// pageText is the text layer of one page.
+type pageText struct {
+	lines []pdf.TextLine
+	// top is the y coordinate of the top edge; height is the page height.
+	top, height float64
+}
+
+func check(pages []pageText, r *resume.Resume) *Report {
+	var raw strings.Builder
+	for _, p := range pages {
+		for _, line := range p.lines {
+			raw.WriteString(line.Text)
+			raw.WriteByte('\n')
+		}
+	}
+	c := &checker{r: r, pages: pages, raw: raw.String(), text: normalize(raw.String())}
+
+	report := &Report{Pages: len(pages)}
+	if strings.TrimSpace(c.raw) == "" {
+		for _, name := range []string{CheckContent, CheckOrder, CheckGlyphs, CheckContact, CheckColumns} {
+			report.Results = append(report.Results, Result{Name: name, Issues: []string{"the PDF has no extractable text layer"}})
+		}
+		return report
+	}
+	report.Results = []Result{c.content(), c.order(), c.glyphs(), c.contact(), c.columns()}
+	return report
+}
+
+type checker struct {
+	r     *resume.Resume
+	pages []pageText
+	raw   string
+	// text is raw normalized for matching.
+	text string
+	// lost records expected strings that were only found with ligatures
+	// removed, for the glyph check.
+	lost []string
+}
+
+// find reports whether s appears in the text layer. Strings that only match
+// once ff, fi and fl are dropped are recorded as ligature corruption and
+// count as missing.
+func (c *checker) find(s string) bool {
+	needle := normalize(s)
+	if needle == "" || strings.Contains(c.text, needle) {
+		return true
+	}
+	stripped := ligaturePairs.Replace(needle)
+	if stripped != needle && strings.Contains(c.text, stripped) {
+		c.lost = append(c.lost, strings.TrimSpace(s))
+	}
+	return false
+}
+
+var ligaturePairs = strings.NewReplacer("ffi", "", "ffl", "", "ff", "", "fi", "", "fl", "")
+
+// section reports whether a section is rendered under the layout.
+func (c *checker) section(name string) bool {
+	if c.r.Layout == nil || len(c.r.Layout.Sections) == 0 {
+		return true
+	}
+	for _, s := range c.r.Layout.Sections {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// positions returns the positions in the order the templates render them.
+func (c *checker) positions() []resume.Experience {
+	if !c.section("experience") {
+		return nil
+	}
+	detailed, earlier := generators.SplitExperience(c.r.Experience.Positions, c.r.Layout)
+	return append(detailed, earlier...)
+}
+
+func (c *checker) content() Result {
+	res := Result{Name: CheckContent}
+	var found, total int
+	expect := func(label, value string) {
+		if strings.TrimSpace(value) == "" {
+			return
+		}
+		total++
+		if c.find(value) {
+			found++
+			return
+		}
+		res.Issues = append(res.Issues, fmt.Sprintf("missing %s %q", label, strings.TrimSpace(value)))
+	}
+
+	for _, p := range c.positions() {
+		expect("company", p.Company)
+		expect("title", p.Title)
+		if !p.Dates.Start.IsZero() {
+			expect("start year of "+p.Company, fmt.Sprint(p.Dates.Start.Year()))
+		}
+		if p.Dates.End != nil && !p.Dates.End.IsZero() {
+			expect("end year of "+p.Company, fmt.Sprint(p.Dates.End.Year()))
+		}
+	}
+	if c.section("skills") {
+		for _, cat := range c.r.Skills.Categories {
+			for _, item := range cat.Items {
+				expect("skill", item.Name)
+			}
+		}
+	}
+
+	res.Score = ratio(found, total)
+	return res
+}
+
+// order checks that positions appear in the text layer in the order they
+// are rendered, which is what a parser relies on to pair titles, companies
+// and dates.
+func (c *checker) order() Result {
+	res := Result{Name: CheckOrder}
+	var inOrder, total, cursor int
+	for _, p := range c.positions() {
+		anchor := normalize(p.Company + p.Title)
+		if anchor == "" {
+			continue
+		}
+		company, title := normalize(p.Company), normalize(p.Title)
+		idx := indexEither(c.text[cursor:], company, title, anchor)
+		if idx < 0 {
+			if indexEither(c.text, company, title, anchor) >= 0 {
+				total++
+				res.Issues = append(res.Issues, fmt.Sprintf("%s appears out of order", p.Label()))
+			}
+			continue
+		}
+		total++
+		inOrder++
+		cursor += idx + 1
+	}
+	res.Score = ratio(inOrder, total)
+	return res
+}
+
+// indexEither returns the index of the first non-empty needle found in s:
+// the company and title rendered together, or either of them alone.
+func indexEither(s string, company, title, both string) int {
+	for _, needle := range []string{both, company, title} {
+		if needle == "" {
+			continue
+		}
+		if idx := strings.Index(s, needle); idx >= 0 {
+			return idx
+		}
+	}
+	return -1
+}
+
+// glyphs looks for characters that parsers mangle or that show a font lost
+// its Unicode mapping: ligatures, replacement and private use characters,
+// control characters, and words that are only found with ligatures removed.
+func (c *checker) glyphs() Result {
+	res := Result{Name: CheckGlyphs}
+	counts := map[string]int{}
+	var order []string
+	var total, suspect int
+	for _, r := range c.raw {
+		if r == '\n' {
+			continue
+		}
+		total++
+		var kind string
+		switch {
+		case r >= 0xFB00 && r <= 0xFB06:
+			kind = "ligature characters"
+		case r == unicode.ReplacementChar:
+			kind = "replacement characters (U+FFFD)"
+		case r >= 0xE000 && r <= 0xF8FF:
+			kind = "private use characters (usually icon fonts)"
+		case unicode.IsControl(r) && r != '\t':
+			kind = "control characters"
+		default:
+			continue
+		}
+		if counts[kind] == 0 {
+			order = append(order, kind)
+		}
+		counts[kind]++
+		suspect++
+	}
+	for _, kind := range order {
+		res.Issues = append(res.Issues, fmt.Sprintf("%d %s in the text layer", counts[kind], kind))
+	}
+	for _, lost := range c.lost {
+		res.Issues = append(res.Issues, fmt.Sprintf("%q is extracted without its ligature (ff, fi or fl has no Unicode mapping)", lost))
+	}
+
+	// Every 1% of suspect characters costs a fifth of the score; each word
+	// that lost a ligature costs a tenth.
+	res.Score = 1
+	if total > 0 {
+		res.Score -= 20 * float64(suspect) / float64(total)
+	}
+	res.Score -= 0.1 * float64(len(c.lost))
+	res.Score = math.Max(0, res.Score)
+	return res
+}
+
+// contact checks that the name, email and phone number are in the body of
+// the first page rather than only in a link, the header or footer margin, or
+// a running header repeated on every page.
+func (c *checker) contact() Result {
+	res := Result{Name: CheckContact}
+	var ok, total int
+	for _, field := range []struct{ label, value string }{
+		{"name", c.r.Contact.Name},
+		{"email", c.r.Contact.Email},
+		{"phone", c.r.Contact.Phone},
+	} {
+		needle := normalize(field.value)
+		if needle == "" {
+			continue
+		}
+		total++
+
+		var onPages, inBody int
+		for i, p := range c.pages {
+			onPage := false
+			for _, line := range p.lines {
+				if !strings.Contains(normalize(line.Text), needle) {
+					continue
+				}
+				onPage = true
+				fromTop := p.top - line.Y
+				if i == 0 && fromTop > headerBand && fromTop < p.height-headerBand {
+					inBody++
+				}
+			}
+			if onPage {
+				onPages++
+			}
+		}
+
+		switch {
+		case onPages == 0:
+			res.Issues = append(res.Issues, fmt.Sprintf("%s is not in the text layer", field.label))
+		case len(c.pages) > 1 && onPages == len(c.pages):
+			res.Issues = append(res.Issues, fmt.Sprintf("%s is in a running header or footer repeated on every page", field.label))
+		case inBody == 0:
+			res.Issues = append(res.Issues, fmt.Sprintf("%s is only in the header or footer margin, or not on the first page", field.label))
+		default:
+			ok++
+		}
+	}
+	res.Score = ratio(ok, total)
+	return res
+}
+
+// columns checks that the summary and highlights read as contiguous text.
+// A multi-column layout that a parser reads across the columns interleaves
+// lines from both, so a bullet's opening is found but not the whole bullet.
+func (c *checker) columns() Result {
+	res := Result{Name: CheckColumns}
+	var intact, total int
+	check := func(label, s string) {
+		needle := normalize(s)
+		if len(needle) < 24 {
+			return
+		}
+		// Bullets that are not rendered at all, such as those trimmed to fit
+		// the page, are not counted.
+		if !strings.Contains(c.text, needle[:16]) {
+			return
+		}
+		total++
+		if strings.Contains(c.text, needle) {
+			intact++
+			return
+		}
+		res.Issues = append(res.Issues, fmt.Sprintf("%s is interleaved with other text: %q", label, excerpt(s)))
+	}
+
+	if c.section("summary") {
+		check("summary", c.r.Summary)
+	}
+	if c.section("experience") {
+		detailed, _ := generators.SplitExperience(c.r.Experience.Positions, c.r.Layout)
+		for _, p := range detailed {
+			for _, h := range p.Highlights {
+				check("highlight of "+p.Label(), h)
+			}
+		}
+	}
+	res.Score = ratio(intact, total)
+	return res
+}
+
+// normalize reduces s to lower-case letters and digits, so text matches
+// across line breaks, hyphenation, escaping and typographic punctuation.
+// Ligature characters are expanded first.
+func normalize(s string) string {
+	s = ligatures.Replace(s)
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+var ligatures = strings.NewReplacer("ﬀ", "ff", "ﬁ", "fi", "ﬂ", "fl", "ﬃ", "ffi", "ﬄ", "ffl", "ﬅ", "st", "ﬆ", "st")
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(n) / float64(total)
+}
+
+func excerpt(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,166 @@
+package ats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urmzd/resume-generator/pkg/pdf"
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+func testResume() *resume.Resume {
+	end := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+	return &resume.Resume{
+		Contact: resume.Contact{Name: "Jane Doe", Email: "jane@example.com", Phone: "+1 555 010 0199"},
+		Summary: "Backend engineer focused on reliable distributed systems and developer tooling.",
+		Skills: resume.Skills{Categories: []resume.SkillCategory{
+			{Category: "Languages", Items: resume.SkillItemsFromNames("Go", "Python")},
+		}},
+		Experience: resume.ExperienceList{Positions: []resume.Experience{
+			{
+				Company: "Acme", Title: "Staff Engineer",
+				Highlights: []string{"Led the migration of the billing platform to an event-driven architecture"},
+				Dates:      resume.DateRange{Start: time.Date(2019, time.July, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			{
+				Company: "Globex", Title: "Software Engineer",
+				Highlights: []string{"Built a profiling dashboard used by every product team"},
+				Dates:      resume.DateRange{Start: time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC), End: &end},
+			},
+		}},
+	}
+}
+
+// lines lays out texts top to bottom on a letter page starting at y.
+func lines(y float64, texts ...string) []pdf.TextLine {
+	out := make([]pdf.TextLine, len(texts))
+	for i, text := range texts {
+		out[i] = pdf.TextLine{Text: text, X: 72, Y: y - 14*float64(i), Size: 11}
+	}
+	return out
+}
+
+func page(ls []pdf.TextLine) pageText {
+	return pageText{lines: ls, top: 792, height: 792}
+}
+
+func cleanPage() pageText {
+	return page(lines(740,
+		"Jane Doe",
+		"jane@example.com | +1 (555) 010-0199",
+		"Backend engineer focused on reliable distributed",
+		"systems and developer tooling.",
+		"Experience",
+		"Staff Engineer — Acme   Jul 2019 – Present",
+		"• Led the migration of the billing platform to an event-",
+		"driven architecture",
+		"Software Engineer — Globex   Mar 2015 – Jun 2019",
+		"• Built a profiling dashboard used by every product team",
+		"Skills",
+		"Languages: Go, Python",
+	))
+}
+
+func result(t *testing.T, report *Report, name string) Result {
+	t.Helper()
+	for _, res := range report.Results {
+		if res.Name == name {
+			return res
+		}
+	}
+	t.Fatalf("report has no %s check", name)
+	return Result{}
+}
+
+func TestCheckClean(t *testing.T) {
+	report := check([]pageText{cleanPage()}, testResume())
+	for _, res := range report.Results {
+		if res.Score != 1 || len(res.Issues) > 0 {
+			t.Errorf("%s = %.2f %q, want a clean pass", res.Name, res.Score, res.Issues)
+		}
+	}
+	if report.Score() != 100 || report.Verdict() != "safe" {
+		t.Errorf("Score() = %d (%s), want 100 (safe)", report.Score(), report.Verdict())
+	}
+}
+
+func TestCheckMissingAndOutOfOrder(t *testing.T) {
+	p := cleanPage()
+	// Swap the two positions and drop a skill.
+	p.lines[5], p.lines[8] = p.lines[8], p.lines[5]
+	p.lines[11].Text = "Languages: Go"
+
+	report := check([]pageText{p}, testResume())
+	content := result(t, report, CheckContent)
+	if len(content.Issues) != 1 || content.Issues[0] != `missing skill "Python"` {
+		t.Errorf("content issues = %q", content.Issues)
+	}
+	order := result(t, report, CheckOrder)
+	if order.Score >= 1 || len(order.Issues) != 1 || !strings.Contains(order.Issues[0], "Software Engineer at Globex") {
+		t.Errorf("order = %.2f %q, want Globex out of order", order.Score, order.Issues)
+	}
+}
+
+func TestCheckGlyphs(t *testing.T) {
+	p := cleanPage()
+	// A symbol font maps a glyph into the private use area.
+	p.lines[4].Text = "Experience \ue001"
+
+	res := result(t, check([]pageText{p}, testResume()), CheckGlyphs)
+	if res.Score >= 1 || len(res.Issues) != 1 || !strings.Contains(res.Issues[0], "private use") {
+		t.Errorf("glyphs = %.2f %q", res.Score, res.Issues)
+	}
+
+	// A font without a ToUnicode entry for the fi ligature drops it.
+	r := testResume()
+	r.Experience.Positions[1].Company = "Fiberfield"
+	p = cleanPage()
+	p.lines[8].Text = "Software Engineer — bereld   Mar 2015 – Jun 2019"
+	report := check([]pageText{p}, r)
+	res = result(t, report, CheckGlyphs)
+	if len(res.Issues) != 1 || !strings.Contains(res.Issues[0], `"Fiberfield" is extracted without its ligature`) {
+		t.Errorf("glyph issues = %q", res.Issues)
+	}
+	if result(t, report, CheckContent).Score >= 1 {
+		t.Error("a company mangled by a lost ligature should count as missing")
+	}
+}
+
+func TestCheckContactInHeader(t *testing.T) {
+	body := cleanPage()
+	// Move the contact line into the top margin and repeat the name on a
+	// second page as a running header.
+	body.lines[1].Y = 780
+	second := page(lines(780, "Jane Doe", "Education"))
+
+	res := result(t, check([]pageText{body, second}, testResume()), CheckContact)
+	want := []string{
+		"name is in a running header or footer repeated on every page",
+		"email is only in the header or footer margin, or not on the first page",
+		"phone is only in the header or footer margin, or not on the first page",
+	}
+	if res.Score != 0 || strings.Join(res.Issues, "\n") != strings.Join(want, "\n") {
+		t.Errorf("contact = %.2f %q", res.Score, res.Issues)
+	}
+}
+
+func TestCheckInterleavedColumns(t *testing.T) {
+	p := cleanPage()
+	// A two-column layout read straight across mixes a sidebar into the
+	// bullet.
+	p.lines[6].Text = "• Led the migration of the billing platform to an event-   Languages"
+	p.lines[7].Text = "driven architecture"
+
+	res := result(t, check([]pageText{p}, testResume()), CheckColumns)
+	if res.Score >= 1 || len(res.Issues) != 1 || !strings.Contains(res.Issues[0], "highlight of Staff Engineer at Acme") {
+		t.Errorf("columns = %.2f %q", res.Score, res.Issues)
+	}
+}
+
+func TestCheckNoTextLayer(t *testing.T) {
+	report := check([]pageText{page(nil)}, testResume())
+	if report.Score() != 0 || report.Verdict() != "risky" {
+		t.Errorf("Score() = %d (%s), want 0 for an image-only PDF", report.Score(), report.Verdict())
+	}
+}
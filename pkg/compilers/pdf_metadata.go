@@ -0,0 +1,358 @@
+package compilers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/urmzd/resume-generator/pkg/pdf"
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// PDFMetadata is the document information and outline that
+// ApplyPDFMetadata writes into a PDF.
+type PDFMetadata struct {
+	resume.DocumentInfo
+	// Sections are the rendered section headings in document order. Each
+	// becomes a top-level bookmark pointing at the page it starts on.
+	Sections []string
+}
+
+// pdfCreatorTool is recorded as the XMP creator tool.
+const pdfCreatorTool = "resume-generator"
+
+// WritePDFMetadata applies meta to the PDF at path in place.
+func WritePDFMetadata(path string, meta PDFMetadata) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, err := ApplyPDFMetadata(data, meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, updated, 0644)
+}
+
+// ApplyPDFMetadata returns the PDF with an incremental update appended that
+// sets the Info dictionary and an XMP metadata stream from meta and adds a
+// bookmark outline with one entry per section. The original revision is
+// kept byte for byte.
+func ApplyPDFMetadata(data []byte, meta PDFMetadata) ([]byte, error) {
+	return applyPDFMetadata(data, meta, time.Now().UTC())
+}
+
+func applyPDFMetadata(data []byte, meta PDFMetadata, now time.Time) ([]byte, error) {
+	r, err := pdf.NewReader(data)
+	if err != nil {
+		return nil, err
+	}
+	if r.StartXref() < 0 {
+		return nil, fmt.Errorf("PDF cross-reference data is damaged")
+	}
+	trailer := r.Trailer()
+	root, ok := trailer.Ref("Root")
+	if !ok {
+		return nil, fmt.Errorf("PDF trailer has no document catalog")
+	}
+	size, ok := trailer.Int("Size")
+	if !ok {
+		return nil, fmt.Errorf("PDF trailer has no object count")
+	}
+	catalog, err := r.Catalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document catalog: %w", err)
+	}
+	// A missing Info dictionary is replaced rather than treated as fatal.
+	info := r.ResolveDict(trailer["Info"])
+
+	var pages []*pdf.Page
+	if len(meta.Sections) > 0 {
+		if pages, err = r.Pages(); err != nil {
+			return nil, fmt.Errorf("failed to read page tree: %w", err)
+		}
+	}
+
+	u := &pdfUpdate{buf: bytes.NewBuffer(append([]byte(nil), data...)), next: size, offsets: make(map[int]int)}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		u.buf.WriteByte('\n')
+	}
+
+	infoBody, err := pdfInfoDict(info, meta.DocumentInfo, now)
+	if err != nil {
+		return nil, err
+	}
+	infoRef := u.add(infoBody)
+	metadataRef := u.add(pdfStream("/Type /Metadata /Subtype /XML", xmpPacket(meta.DocumentInfo, now)))
+
+	// An existing outline is kept when there are no sections to replace it.
+	catalogEntries := pdf.Dict{"Metadata": metadataRef}
+	if len(pages) > 0 {
+		outline, err := u.addOutline(meta.Sections, sectionPages(pages, meta.Sections), pages)
+		if err != nil {
+			return nil, err
+		}
+		catalogEntries["Outlines"] = outline
+		catalogEntries["PageMode"] = pdf.Name("UseOutlines")
+	}
+	catalogBody, err := mergeDict(catalog, catalogEntries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite document catalog: %w", err)
+	}
+	u.write(root, catalogBody)
+
+	if err := u.finish(r, root, infoRef); err != nil {
+		return nil, err
+	}
+	return u.buf.Bytes(), nil
+}
+
+// sectionPages returns the index of the page each section starts on. A
+// section is looked for on the page of the section before it and the pages
+// after; one that cannot be found stays on the previous section's page.
+func sectionPages(pages []*pdf.Page, sections []string) []int {
+	texts := make([]string, len(pages))
+	for i, page := range pages {
+		// Pages whose text cannot be extracted are skipped in the search.
+		text, _ := page.Text()
+		texts[i] = normalizeSearchText(text)
+	}
+	result := make([]int, len(sections))
+	current := 0
+	for i, section := range sections {
+		needle := normalizeSearchText(section)
+		for p := current; p < len(texts) && needle != ""; p++ {
+			if strings.Contains(texts[p], needle) {
+				current = p
+				break
+			}
+		}
+		result[i] = current
+	}
+	return result
+}
+
+func normalizeSearchText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
+
+// pdfUpdate accumulates the objects of an incremental update.
+type pdfUpdate struct {
+	buf     *bytes.Buffer
+	next    int
+	offsets map[int]int
+	gens    map[int]int
+}
+
+// add writes body as a new object and returns its reference.
+func (u *pdfUpdate) add(body string) pdf.Ref {
+	ref := pdf.Ref{Num: u.next}
+	u.next++
+	u.write(ref, body)
+	return ref
+}
+
+// write writes body as object ref, replacing any earlier definition.
+func (u *pdfUpdate) write(ref pdf.Ref, body string) {
+	u.offsets[ref.Num] = u.buf.Len()
+	if ref.Gen != 0 {
+		if u.gens == nil {
+			u.gens = make(map[int]int)
+		}
+		u.gens[ref.Num] = ref.Gen
+	}
+	fmt.Fprintf(u.buf, "%d %d obj\n%s\nendobj\n", ref.Num, ref.Gen, body)
+}
+
+// addOutline writes an outline with one top-level item per section and
+// returns the reference of the outline dictionary.
+func (u *pdfUpdate) addOutline(sections []string, pageIndexes []int, pages []*pdf.Page) (pdf.Ref, error) {
+	outline := pdf.Ref{Num: u.next}
+	first := outline.Num + 1
+	last := outline.Num + len(sections)
+	u.next = last + 1
+
+	u.write(outline, fmt.Sprintf("<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count %d >>", first, last, len(sections)))
+	for i, title := range sections {
+		num := first + i
+		item := pdf.Dict{
+			"Title":  pdf.TextString(title),
+			"Parent": outline,
+			"Dest":   pdf.Array{pages[pageIndexes[i]].Ref, pdf.Name("Fit")},
+		}
+		if num > first {
+			item["Prev"] = pdf.Ref{Num: num - 1}
+		}
+		if num < last {
+			item["Next"] = pdf.Ref{Num: num + 1}
+		}
+		body, err := pdf.Format(item)
+		if err != nil {
+			return pdf.Ref{}, err
+		}
+		u.write(pdf.Ref{Num: num}, body)
+	}
+	return outline, nil
+}
+
+// finish writes the cross-reference section and trailer of the update in
+// the same form as the previous revision.
+func (u *pdfUpdate) finish(prev *pdf.Reader, root, info pdf.Ref) error {
+	trailer := fmt.Sprintf("/Root %s /Info %s /Prev %d", root, info, prev.StartXref())
+	if id, ok := prev.Trailer()["ID"]; ok {
+		formatted, err := pdf.Format(id)
+		if err != nil {
+			return fmt.Errorf("invalid document ID: %w", err)
+		}
+		trailer += " /ID " + formatted
+	}
+
+	if prev.XRefStream() {
+		self := u.next
+		u.next++
+		u.offsets[self] = u.buf.Len()
+		nums := u.sortedNums()
+		var entries bytes.Buffer
+		for _, num := range nums {
+			entries.WriteByte(1)
+			_ = binary.Write(&entries, binary.BigEndian, uint32(u.offsets[num]))
+			_ = binary.Write(&entries, binary.BigEndian, uint16(u.gens[num]))
+		}
+		dict := fmt.Sprintf("/Type /XRef /Size %d /Index [%s] /W [1 4 2] %s", u.next, xrefIndex(nums), trailer)
+		fmt.Fprintf(u.buf, "%d 0 obj\n%s\nendobj\n", self, pdfStream(dict, entries.String()))
+		fmt.Fprintf(u.buf, "startxref\n%d\n%%%%EOF\n", u.offsets[self])
+		return nil
+	}
+
+	start := u.buf.Len()
+	u.buf.WriteString("xref\n")
+	nums := u.sortedNums()
+	for _, run := range xrefRuns(nums) {
+		fmt.Fprintf(u.buf, "%d %d\n", run[0], len(run))
+		for _, num := range run {
+			fmt.Fprintf(u.buf, "%010d %05d n\r\n", u.offsets[num], u.gens[num])
+		}
+	}
+	fmt.Fprintf(u.buf, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", u.next, trailer, start)
+	return nil
+}
+
+func (u *pdfUpdate) sortedNums() []int {
+	nums := make([]int, 0, len(u.offsets))
+	for num := range u.offsets {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// xrefRuns splits sorted object numbers into runs of consecutive numbers.
+func xrefRuns(nums []int) [][]int {
+	var runs [][]int
+	for i, num := range nums {
+		if i == 0 || num != nums[i-1]+1 {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], num)
+	}
+	return runs
+}
+
+func xrefIndex(nums []int) string {
+	var parts []string
+	for _, run := range xrefRuns(nums) {
+		parts = append(parts, fmt.Sprintf("%d %d", run[0], len(run)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// mergeDict serializes d with entries replaced or added.
+func mergeDict(d pdf.Dict, entries pdf.Dict) (string, error) {
+	merged := make(pdf.Dict, len(d)+len(entries))
+	for key, value := range d {
+		merged[key] = value
+	}
+	for key, value := range entries {
+		merged[key] = value
+	}
+	return pdf.Format(merged)
+}
+
+// pdfInfoDict returns the Info dictionary: the previous one with the fields
+// from info and the modification date replaced.
+func pdfInfoDict(prev pdf.Dict, info resume.DocumentInfo, now time.Time) (string, error) {
+	entries := pdf.Dict{"ModDate": pdf.TextString(pdfDate(now))}
+	if prev["CreationDate"] == nil {
+		entries["CreationDate"] = entries["ModDate"]
+	}
+	set := func(key pdf.Name, value string) {
+		if value != "" {
+			entries[key] = pdf.TextString(value)
+		}
+	}
+	set("Title", info.Title)
+	set("Author", info.Author)
+	set("Subject", info.Subject)
+	set("Keywords", strings.Join(info.Keywords, ", "))
+	return mergeDict(prev, entries)
+}
+
+func pdfDate(t time.Time) string {
+	return t.UTC().Format("D:20060102150405Z")
+}
+
+// pdfStream returns an unfiltered stream object body.
+func pdfStream(dict, content string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(content), content)
+}
+
+// xmpPacket returns an XMP metadata packet mirroring the Info dictionary.
+func xmpPacket(info resume.DocumentInfo, now time.Time) string {
+	esc := func(s string) string {
+		var b strings.Builder
+		_ = xml.EscapeText(&b, []byte(s))
+		return b.String()
+	}
+	stamp := now.UTC().Format(time.RFC3339)
+
+	var b strings.Builder
+	b.WriteString("<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString(`<x:xmpmeta xmlns:x="adobe:ns:meta/">` + "\n")
+	b.WriteString(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` + "\n")
+	b.WriteString(`<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:pdf="http://ns.adobe.com/pdf/1.3/" xmlns:xmp="http://ns.adobe.com/xap/1.0/">` + "\n")
+	b.WriteString("<dc:format>application/pdf</dc:format>\n")
+	if info.Title != "" {
+		fmt.Fprintf(&b, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", esc(info.Title))
+	}
+	if info.Author != "" {
+		fmt.Fprintf(&b, "<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", esc(info.Author))
+	}
+	if info.Subject != "" {
+		fmt.Fprintf(&b, "<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", esc(info.Subject))
+	}
+	if len(info.Keywords) > 0 {
+		b.WriteString("<dc:subject><rdf:Bag>")
+		for _, k := range info.Keywords {
+			fmt.Fprintf(&b, "<rdf:li>%s</rdf:li>", esc(k))
+		}
+		b.WriteString("</rdf:Bag></dc:subject>\n")
+		fmt.Fprintf(&b, "<pdf:Keywords>%s</pdf:Keywords>\n", esc(strings.Join(info.Keywords, ", ")))
+	}
+	fmt.Fprintf(&b, "<xmp:CreatorTool>%s</xmp:CreatorTool>\n", pdfCreatorTool)
+	fmt.Fprintf(&b, "<xmp:ModifyDate>%s</xmp:ModifyDate>\n", stamp)
+	fmt.Fprintf(&b, "<xmp:MetadataDate>%s</xmp:MetadataDate>\n", stamp)
+	b.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n")
+	b.WriteString(`<?xpacket end="w"?>`)
+	return b.String()
+}
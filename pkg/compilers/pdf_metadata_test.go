@@ -0,0 +1,204 @@
+package compilers
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urmzd/resume-generator/pkg/pdf"
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// buildTestPDF returns a PDF with one page per entry in pageTexts. Content
+// streams are Flate-compressed, and the cross-reference section is a table
+// or, with xrefStream, a cross-reference stream.
+func buildTestPDF(t *testing.T, pageTexts []string, xrefStream bool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	offsets := map[int]int{}
+	obj := func(num int, body string) {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", num, body)
+	}
+
+	buf.WriteString("%PDF-1.7\n")
+	n := len(pageTexts)
+	kids := make([]string, n)
+	for i := range pageTexts {
+		kids[i] = fmt.Sprintf("%d 0 R", 3+2*i)
+	}
+	obj(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	obj(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), n))
+	for i, text := range pageTexts {
+		obj(3+2*i, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents %d 0 R >>", 4+2*i))
+
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		fmt.Fprintf(zw, "BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		obj(4+2*i, fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+	infoNum := 3 + 2*n
+	obj(infoNum, "<< /Producer (Test) /CreationDate (D:20240101000000Z) >>")
+	size := infoNum + 1
+
+	if xrefStream {
+		offsets[size] = buf.Len()
+		var entries bytes.Buffer
+		entries.Write([]byte{0, 0, 0, 0, 0, 0xFF, 0xFF})
+		for num := 1; num <= size; num++ {
+			entries.WriteByte(1)
+			_ = binary.Write(&entries, binary.BigEndian, uint32(offsets[num]))
+			_ = binary.Write(&entries, binary.BigEndian, uint16(0))
+		}
+		fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /XRef /Size %d /W [1 4 2] /Root 1 0 R /Info %d 0 R /Length %d >>\nstream\n%s\nendstream\nendobj\n",
+			size, size+1, infoNum, entries.Len(), entries.String())
+		fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", offsets[size])
+		return buf.Bytes()
+	}
+
+	start := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f\r\n", size)
+	for num := 1; num < size; num++ {
+		fmt.Fprintf(&buf, "%010d 00000 n\r\n", offsets[num])
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, infoNum, start)
+	return buf.Bytes()
+}
+
+func TestApplyPDFMetadata(t *testing.T) {
+	meta := PDFMetadata{
+		DocumentInfo: resume.DocumentInfo{
+			Title:    "Jane Doe - Resume",
+			Author:   "Jane Doe",
+			Subject:  "Staff Engineer",
+			Keywords: []string{"Go", "Kubernetes"},
+		},
+		Sections: []string{"Summary", "Experience", "Education"},
+	}
+	now := time.Date(2025, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+	for _, xrefStream := range []bool{false, true} {
+		t.Run(fmt.Sprintf("xrefStream=%v", xrefStream), func(t *testing.T) {
+			original := buildTestPDF(t, []string{"Jane Doe Summary", "EXPERIENCE", "Education"}, xrefStream)
+			out, err := applyPDFMetadata(original, meta, now)
+			if err != nil {
+				t.Fatalf("applyPDFMetadata() error = %v", err)
+			}
+			if !bytes.HasPrefix(out, original) {
+				t.Fatal("the original revision must be kept intact")
+			}
+
+			r, err := pdf.NewReader(out)
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			if r.XRefStream() != xrefStream {
+				t.Errorf("update xref stream = %v, want %v", r.XRefStream(), xrefStream)
+			}
+			if r.StartXref() < len(original) {
+				t.Errorf("StartXref() = %d, want the update's cross-reference section", r.StartXref())
+			}
+			if n, _ := r.NumPages(); n != 3 {
+				t.Errorf("NumPages() = %d, want 3 through the update", n)
+			}
+
+			info := r.ResolveDict(r.Trailer()["Info"])
+			for key, want := range map[pdf.Name]string{
+				"Title":        "Jane Doe - Resume",
+				"Author":       "Jane Doe",
+				"Subject":      "Staff Engineer",
+				"Keywords":     "Go, Kubernetes",
+				"Producer":     "Test",
+				"CreationDate": "D:20240101000000Z",
+				"ModDate":      "D:20250304050607Z",
+			} {
+				if got, _ := info[key].(pdf.String); got.Text() != want {
+					t.Errorf("Info /%s = %q, want %q", key, got.Text(), want)
+				}
+			}
+
+			catalog, err := r.Catalog()
+			if err != nil {
+				t.Fatalf("catalog: %v", err)
+			}
+			if ref, _ := catalog.Ref("Pages"); ref != (pdf.Ref{Num: 2}) {
+				t.Errorf("catalog lost /Pages: %v", catalog["Pages"])
+			}
+			metadata, _ := r.Resolve(catalog["Metadata"])
+			stream, ok := metadata.(*pdf.Stream)
+			if !ok {
+				t.Fatalf("catalog /Metadata is %T, want a stream", metadata)
+			}
+			xmp, err := r.StreamData(stream)
+			if err != nil {
+				t.Fatalf("metadata stream: %v", err)
+			}
+			for _, want := range []string{"<rdf:li xml:lang=\"x-default\">Jane Doe - Resume</rdf:li>", "<rdf:li>Kubernetes</rdf:li>", "<pdf:Keywords>Go, Kubernetes</pdf:Keywords>"} {
+				if !strings.Contains(string(xmp), want) {
+					t.Errorf("XMP missing %s", want)
+				}
+			}
+
+			outlines := r.ResolveDict(catalog["Outlines"])
+			if count, _ := outlines.Int("Count"); count != 3 {
+				t.Errorf("outline count = %d, want 3", count)
+			}
+			wantPages := []int{3, 5, 7}
+			item := outlines["First"]
+			for i, title := range meta.Sections {
+				d := r.ResolveDict(item)
+				if d == nil {
+					t.Fatalf("outline item %d is missing", i)
+				}
+				if got, _ := d["Title"].(pdf.String); got.Text() != title {
+					t.Errorf("outline item %d title = %q, want %q", i, got.Text(), title)
+				}
+				dest, _ := d["Dest"].(pdf.Array)
+				if len(dest) != 2 || dest[0] != (pdf.Ref{Num: wantPages[i]}) || dest[1] != pdf.Name("Fit") {
+					t.Errorf("outline item %s dest = %v, want [%d 0 R /Fit]", title, dest, wantPages[i])
+				}
+				item = d["Next"]
+			}
+		})
+	}
+}
+
+func TestApplyPDFMetadataXrefOffsets(t *testing.T) {
+	out, err := applyPDFMetadata(buildTestPDF(t, []string{"One"}, false), PDFMetadata{Sections: []string{"One"}}, time.Now())
+	if err != nil {
+		t.Fatalf("applyPDFMetadata() error = %v", err)
+	}
+	r, err := pdf.NewReader(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every entry of the new cross-reference table points at its object.
+	section := out[r.StartXref():]
+	lines := strings.Split(string(section[:bytes.Index(section, []byte("trailer"))]), "\n")
+	var num, checked int
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		var offset, gen, first, count int
+		if _, err := fmt.Sscanf(line, "%d %d n", &offset, &gen); err == nil && strings.HasSuffix(line, "n") {
+			header := fmt.Sprintf("%d %d obj", num, gen)
+			if !bytes.HasPrefix(out[offset:], []byte(header)) {
+				t.Errorf("xref entry for object %d points at %q", num, out[offset:offset+len(header)])
+			}
+			num++
+			checked++
+		} else if _, err := fmt.Sscanf(line, "%d %d", &first, &count); err == nil {
+			num = first
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no cross-reference entries found")
+	}
+}
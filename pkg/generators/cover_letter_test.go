@@ -0,0 +1,111 @@
+package generators
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+func testCoverLetter() (*resume.Resume, *resume.CoverLetter) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	r := &resume.Resume{
+		Contact: resume.Contact{
+			Name:  "Jane Doe",
+			Email: "jane@example.com",
+			Links: []resume.Link{{URI: "https://github.com/janedoe"}},
+		},
+		Layout: &resume.Layout{Typography: "modern"},
+	}
+	letter := &resume.CoverLetter{
+		Recipient: &resume.Recipient{Name: "Alex Smith", Title: "Engineering Manager"},
+		Company:   "Acme & Co",
+		Date:      &date,
+		Body:      []string{"I am writing to apply.", "", "Thank you for your time."},
+	}
+	return r, letter
+}
+
+func TestGenerateCoverLetter(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	r, letter := testCoverLetter()
+	gen := NewGenerator(zap.NewNop().Sugar())
+
+	tests := []struct {
+		template string
+		want     []string
+	}{
+		{"modern-html", []string{
+			`<div class="header">`, "Jane Doe", "github.com/janedoe", "typo-modern",
+			"March 4, 2025", "Alex Smith", "Acme &amp; Co", "Dear Alex Smith,",
+			"<p>I am writing to apply.</p>", "Sincerely,",
+		}},
+		{"modern-latex", []string{
+			`\settypographymodern`, `\resumename{Jane Doe}`, `\faGithub`,
+			"March 4, 2025", `Acme \& Co`, "Dear Alex Smith,", "Thank you for your time.",
+			`\textbf{Jane Doe}`, `\end{document}`,
+		}},
+		{"modern-markdown", []string{
+			"# Jane Doe", "GitHub: [github.com/janedoe]", "March 4, 2025", "Acme & Co",
+			"Dear Alex Smith,", "I am writing to apply.", "**Jane Doe**",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, err := LoadTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("LoadTemplate() error: %v", err)
+			}
+			if !tmpl.SupportsCoverLetter() {
+				t.Fatalf("%s should ship a cover letter", tt.template)
+			}
+			got, err := gen.GenerateCoverLetter(tmpl, r, letter)
+			if err != nil {
+				t.Fatalf("GenerateCoverLetter() error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("cover letter missing %q", want)
+				}
+			}
+		})
+	}
+
+	t.Run("modern-docx", func(t *testing.T) {
+		docxBytes, err := gen.GenerateCoverLetterDOCX(r, letter)
+		if err != nil {
+			t.Fatalf("GenerateCoverLetterDOCX() error: %v", err)
+		}
+		xml, err := extractDocumentXML(docxBytes)
+		if err != nil {
+			t.Fatalf("failed to extract document.xml: %v", err)
+		}
+		for _, want := range []string{"JANE DOE", "March 4, 2025", "Dear Alex Smith,", "Thank you for your time."} {
+			if !strings.Contains(string(xml), want) {
+				t.Errorf("DOCX cover letter missing %q", want)
+			}
+		}
+	})
+
+	t.Run("template without letter", func(t *testing.T) {
+		tmpl, err := LoadTemplate("modern-cv")
+		if err != nil {
+			t.Fatalf("LoadTemplate() error: %v", err)
+		}
+		if tmpl.SupportsCoverLetter() {
+			t.Fatal("modern-cv has no cover letter")
+		}
+		if _, err := gen.GenerateCoverLetter(tmpl, r, letter); err == nil {
+			t.Error("expected an error for a template without a cover letter")
+		}
+	})
+}
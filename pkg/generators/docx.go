@@ -2,6 +2,7 @@ package generators
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/fumiama/go-docx"
@@ -25,6 +26,13 @@ func NewDOCXGenerator(logger *zap.SugaredLogger) *DOCXGenerator {
 
 // Generate creates a DOCX document from the resume and returns it as bytes.
 func (g *DOCXGenerator) Generate(r *resume.Resume) ([]byte, error) {
+	// Fail early on unreadable images, matching the HTML and LaTeX generators
+	for _, fi := range r.Images() {
+		if _, err := prepareImage(fi.Image, 0); err != nil {
+			return nil, fmt.Errorf("%s: %w", fi.Field, err)
+		}
+	}
+
 	doc := docx.New().WithDefaultTheme()
 
 	g.addHeader(doc, r.Contact)
@@ -41,9 +49,9 @@ func (g *DOCXGenerator) Generate(r *resume.Resume) ([]byte, error) {
 			case "education":
 				g.addEducation(doc, r.Education)
 			case "skills":
-				g.addSkills(doc, r.Skills)
+				g.addSkills(doc, r)
 			case "experience":
-				g.addExperience(doc, r.Experience)
+				g.addExperience(doc, r.Experience, r.Layout)
 			case "projects":
 				if r.Projects != nil {
 					g.addProjects(doc, *r.Projects)
@@ -52,6 +60,8 @@ func (g *DOCXGenerator) Generate(r *resume.Resume) ([]byte, error) {
 				if r.Languages != nil {
 					g.addLanguages(doc, *r.Languages)
 				}
+			case "references":
+				g.addReferences(doc, r)
 			}
 		}
 	} else {
@@ -60,17 +70,18 @@ func (g *DOCXGenerator) Generate(r *resume.Resume) ([]byte, error) {
 			g.addCertifications(doc, *r.Certifications)
 		}
 		g.addEducation(doc, r.Education)
-		g.addSkills(doc, r.Skills)
-		g.addExperience(doc, r.Experience)
+		g.addSkills(doc, r)
+		g.addExperience(doc, r.Experience, r.Layout)
 		if r.Projects != nil {
 			g.addProjects(doc, *r.Projects)
 		}
 		if r.Languages != nil {
 			g.addLanguages(doc, *r.Languages)
 		}
+		g.addReferences(doc, r)
 	}
 
-	if r.Layout != nil && r.Layout.References {
+	if r.Layout != nil && r.Layout.References && !r.ShowReferences() {
 		doc.AddParagraph() // spacing
 		refPara := doc.AddParagraph().Justification("center")
 		refPara.AddText("References available upon request").Italic().Size("20")
@@ -84,8 +95,61 @@ func (g *DOCXGenerator) Generate(r *resume.Resume) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// GenerateLetter creates a DOCX cover letter with the same header as the
+// resume and returns it as bytes.
+func (g *DOCXGenerator) GenerateLetter(r *resume.Resume, letter *resume.CoverLetter) ([]byte, error) {
+	if photo := r.Contact.Photo; photo != nil {
+		if _, err := prepareImage(photo, 0); err != nil {
+			return nil, fmt.Errorf("contact.photo: %w", err)
+		}
+	}
+
+	doc := docx.New().WithDefaultTheme()
+
+	g.addHeader(doc, r.Contact)
+
+	doc.AddParagraph().AddText(g.formatter.FormatLetterDate(letter)).Size("22")
+	doc.AddParagraph()
+
+	var recipient []string
+	if letter.Recipient != nil {
+		recipient = append(recipient, letter.Recipient.Name, letter.Recipient.Title)
+	}
+	recipient = append(recipient, letter.Company)
+	if letter.Recipient != nil && letter.Recipient.Location != nil {
+		recipient = append(recipient, g.formatter.FormatLocation(letter.Recipient.Location))
+	}
+	if lines := filterStrings(recipient); len(lines) > 0 {
+		for _, line := range lines {
+			doc.AddParagraph().AddText(line).Size("22")
+		}
+		doc.AddParagraph()
+	}
+
+	doc.AddParagraph().AddText(letter.SalutationText()).Size("22")
+	doc.AddParagraph()
+	for _, paragraph := range filterStrings(letter.Body) {
+		doc.AddParagraph().AddText(paragraph).Size("22")
+		doc.AddParagraph()
+	}
+
+	doc.AddParagraph().AddText(letter.ClosingText()).Size("22")
+	doc.AddParagraph().AddText(letter.SignatureFor(r.Contact)).Bold().Size("22")
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 // addHeader adds the name and contact information.
 func (g *DOCXGenerator) addHeader(doc *docx.Docx, contact resume.Contact) {
+	if contact.Photo != nil {
+		g.addPicture(doc.AddParagraph().Justification("center"), contact.Photo, defaultPhotoWidthMM)
+	}
+
 	// Name - large, bold, centered
 	namePara := doc.AddParagraph().Justification("center")
 	namePara.AddText(strings.ToUpper(contact.Name)).Bold().Size("36")
@@ -109,7 +173,7 @@ func (g *DOCXGenerator) addHeader(doc *docx.Docx, contact resume.Contact) {
 	}
 	for _, link := range contact.Links {
 		if link.URI != "" {
-			contactParts = append(contactParts, link.URI)
+			contactParts = append(contactParts, g.formatter.LinkPrefix(link)+g.formatter.LinkText(link))
 		}
 	}
 
@@ -178,6 +242,39 @@ func (g *DOCXGenerator) addLanguages(doc *docx.Docx, languages resume.LanguageLi
 	doc.AddParagraph()
 }
 
+// addReferences adds the references section when the layout enables it.
+func (g *DOCXGenerator) addReferences(doc *docx.Docx, r *resume.Resume) {
+	if !r.ShowReferences() {
+		return
+	}
+	title := r.References.Title
+	if title == "" {
+		title = "References"
+	}
+	g.addSectionHeader(doc, title)
+	for _, ref := range r.VisibleReferees() {
+		para := doc.AddParagraph()
+		para.AddText("• " + ref.Name).Bold().Size("22")
+		line := ""
+		if ref.Title != "" {
+			line += ", " + ref.Title
+		}
+		if ref.Company != "" {
+			line += " — " + ref.Company
+		}
+		if ref.Relationship != "" {
+			line += " (" + ref.Relationship + ")"
+		}
+		if line != "" {
+			para.AddText(line).Size("22")
+		}
+		if contact := filterStrings([]string{ref.Email, ref.Phone}); len(contact) > 0 {
+			doc.AddParagraph().AddText("  " + strings.Join(contact, " | ")).Size("20")
+		}
+	}
+	doc.AddParagraph()
+}
+
 // addSectionHeader adds a section title with underline styling.
 func (g *DOCXGenerator) addSectionHeader(doc *docx.Docx, title string) {
 	para := doc.AddParagraph()
@@ -252,8 +349,10 @@ func (g *DOCXGenerator) addEducation(doc *docx.Docx, education resume.EducationL
 	doc.AddParagraph() // spacing
 }
 
-// addSkills adds the skills section.
-func (g *DOCXGenerator) addSkills(doc *docx.Docx, skills resume.Skills) {
+// addSkills adds the skills section. It takes the whole resume because
+// derived skill years come from experience and projects.
+func (g *DOCXGenerator) addSkills(doc *docx.Docx, r *resume.Resume) {
+	skills := r.Skills
 	if len(skills.Categories) == 0 {
 		return
 	}
@@ -269,15 +368,15 @@ func (g *DOCXGenerator) addSkills(doc *docx.Docx, skills resume.Skills) {
 		skillPara.AddText("• ").Size("22")
 		skillPara.AddText(category.Category + ": ").Bold().Size("22")
 
-		skillNames := g.formatter.SkillNames(category.Items)
-		skillPara.AddText(strings.Join(skillNames, ", ")).Size("22")
+		skillPara.AddText(g.formatter.FormatSkills(r, category.Items)).Size("22")
 	}
 
 	doc.AddParagraph() // spacing
 }
 
-// addExperience adds the experience section.
-func (g *DOCXGenerator) addExperience(doc *docx.Docx, experience resume.ExperienceList) {
+// addExperience adds the experience section, collapsing older positions
+// into an "Earlier Experience" list when the layout requests it.
+func (g *DOCXGenerator) addExperience(doc *docx.Docx, experience resume.ExperienceList, layout *resume.Layout) {
 	if len(experience.Positions) == 0 {
 		return
 	}
@@ -288,7 +387,9 @@ func (g *DOCXGenerator) addExperience(doc *docx.Docx, experience resume.Experien
 	}
 	g.addSectionHeader(doc, title)
 
-	for _, pos := range g.formatter.SortExperienceByDate(experience.Positions) {
+	detailed, earlier := SplitExperience(experience.Positions, layout)
+
+	for _, pos := range detailed {
 		// Title and dates
 		headerPara := doc.AddParagraph()
 		dates := g.formatter.FormatDateRange(pos.Dates)
@@ -299,6 +400,9 @@ func (g *DOCXGenerator) addExperience(doc *docx.Docx, experience resume.Experien
 		headerPara.AddText(titleLine).Bold().Size("22")
 
 		// Company and location
+		if pos.Logo != nil {
+			g.addPicture(doc.AddParagraph(), pos.Logo, defaultLogoWidthMM)
+		}
 		var companyParts []string
 		if pos.Company != "" {
 			companyParts = append(companyParts, pos.Company)
@@ -323,6 +427,24 @@ func (g *DOCXGenerator) addExperience(doc *docx.Docx, experience resume.Experien
 
 		doc.AddParagraph() // spacing between positions
 	}
+
+	if len(earlier) == 0 {
+		return
+	}
+
+	g.addSectionHeader(doc, "Earlier Experience")
+	for _, pos := range earlier {
+		line := pos.Title
+		if pos.Company != "" {
+			line += ", " + pos.Company
+		}
+		if years := g.formatter.FormatYearRange(pos.Dates); years != "" {
+			line += " (" + years + ")"
+		}
+		bulletPara := doc.AddParagraph()
+		bulletPara.AddText("• " + line).Size("22")
+	}
+	doc.AddParagraph() // spacing
 }
 
 // addProjects adds the projects section.
@@ -342,6 +464,10 @@ func (g *DOCXGenerator) addProjects(doc *docx.Docx, projects resume.ProjectList)
 		headerPara := doc.AddParagraph()
 		headerPara.AddText(proj.Name).Bold().Size("22")
 
+		if proj.Thumbnail != nil {
+			g.addPicture(doc.AddParagraph(), proj.Thumbnail, defaultThumbnailWidthMM)
+		}
+
 		// Highlights bullets
 		for _, desc := range proj.Highlights {
 			bulletPara := doc.AddParagraph()
@@ -357,3 +483,31 @@ func (g *DOCXGenerator) addProjects(doc *docx.Docx, projects resume.ProjectList)
 
 	doc.AddParagraph() // spacing
 }
+
+// emuPerMM is the number of English Metric Units in a millimetre.
+const emuPerMM = 36000
+
+// addPicture inserts an inline image into para at its resolved size. The alt
+// text is stored as the drawing name.
+func (g *DOCXGenerator) addPicture(para *docx.Paragraph, img *resume.Image, defaultWidth float64) {
+	prepared, err := prepareImage(img, defaultWidth)
+	if err != nil {
+		g.logger.Warnf("Skipping image %s: %v", img.Path, err)
+		return
+	}
+	run, err := para.AddInlineDrawing(prepared.Data)
+	if err != nil {
+		g.logger.Warnf("Skipping image %s: %v", img.Path, err)
+		return
+	}
+	for _, child := range run.Children {
+		drawing, ok := child.(*docx.Drawing)
+		if !ok || drawing.Inline == nil {
+			continue
+		}
+		drawing.Inline.Size(int64(prepared.WidthMM*emuPerMM), int64(prepared.HeightMM*emuPerMM))
+		if drawing.Inline.DocPr != nil {
+			drawing.Inline.DocPr.Name = img.Alt
+		}
+	}
+}
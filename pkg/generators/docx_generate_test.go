@@ -44,7 +44,7 @@ func TestDOCXGenerate(t *testing.T) {
 		},
 		Skills: resume.Skills{
 			Categories: []resume.SkillCategory{
-				{Category: "Languages", Items: []string{"Go", "Rust"}},
+				{Category: "Languages", Items: resume.SkillItemsFromNames("Go", "Rust")},
 			},
 		},
 		Experience: resume.ExperienceList{
@@ -96,7 +96,7 @@ func TestDOCXGenerate(t *testing.T) {
 				Sections: []string{"experience", "education", "skills"},
 			},
 			Skills: resume.Skills{
-				Categories: []resume.SkillCategory{{Category: "Lang", Items: []string{"Go"}}},
+				Categories: []resume.SkillCategory{{Category: "Lang", Items: resume.SkillItemsFromNames("Go")}},
 			},
 			Experience: resume.ExperienceList{
 				Positions: []resume.Experience{{Title: "Dev", Company: "Co", Dates: resume.DateRange{Start: expStart}}},
@@ -32,7 +32,7 @@ type Formatter interface {
 	FormatGPA(gpa, max string) string
 
 	// SkillNames returns skill names for display.
-	SkillNames([]string) []string
+	SkillNames([]resume.SkillItem) []string
 
 	// Join concatenates strings using a separator.
 	Join(sep string, items []string) string
@@ -115,8 +115,76 @@ func (f *baseFormatter) FormatList(values []string) string {
 }
 
 // SkillNames returns filtered skill names for display.
-func (f *baseFormatter) SkillNames(items []string) []string {
-	return filterStrings(items)
+func (f *baseFormatter) SkillNames(items []resume.SkillItem) []string {
+	return filterStrings(resume.SkillNames(items))
+}
+
+// formatSkillsWith joins skills with their level and years according to the
+// resume layout. level renders the bars or dots for a 1-based level rank.
+func formatSkillsWith(r *resume.Resume, items []resume.SkillItem, escape func(string) string, level func(style string, rank int, name string) string) string {
+	var style string
+	var showYears bool
+	if r != nil && r.Layout != nil {
+		style = r.Layout.SkillLevels
+		showYears = r.Layout.SkillYears
+	}
+	var tenure resume.TenureIndex
+	if showYears {
+		tenure = resume.IndexSkillTenure(resume.ComputeSkillTenure(r, time.Now()))
+	}
+
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			continue
+		}
+		text := escape(name)
+
+		var notes []string
+		if rank := item.LevelRank(); rank > 0 {
+			levelName := resume.SkillLevels[rank-1]
+			switch style {
+			case "text":
+				notes = append(notes, escape(strings.ToUpper(levelName[:1])+levelName[1:]))
+			case "bars", "dots":
+				text += " " + level(style, rank, levelName)
+			}
+		}
+		if showYears {
+			if years, _ := tenure.Years(item); years > 0 {
+				notes = append(notes, escape(formatSkillYears(years)))
+			}
+		}
+		if len(notes) > 0 {
+			text += " (" + strings.Join(notes, ", ") + ")"
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSkillYears renders a tenure such as "5 yrs" or "<1 yr".
+func formatSkillYears(years float64) string {
+	rounded := int(years + 0.5)
+	switch {
+	case rounded < 1:
+		return "<1 yr"
+	case rounded == 1:
+		return "1 yr"
+	default:
+		return fmt.Sprintf("%d yrs", rounded)
+	}
+}
+
+// unicodeSkillLevel renders a level as filled and empty glyphs, used by
+// text-only outputs.
+func unicodeSkillLevel(style string, rank int, _ string) string {
+	filled, empty := "●", "○"
+	if style == "bars" {
+		filled, empty = "▰", "▱"
+	}
+	return strings.Repeat(filled, rank) + strings.Repeat(empty, len(resume.SkillLevels)-rank)
 }
 
 // Join concatenates strings using a separator.
@@ -210,6 +278,92 @@ func (f *baseFormatter) SortExperienceByDate(experiences []resume.Experience) []
 	return sorted
 }
 
+// SplitExperience sorts positions newest first and partitions them into
+// detailed entries and collapsed "earlier" entries according to the layout's
+// CollapseBefore and MaxDetailedPositions settings; see
+// resume.SplitPositions.
+func SplitExperience(experiences []resume.Experience, layout *resume.Layout) (detailed, earlier []resume.Experience) {
+	detailedIdx, earlierIdx := resume.SplitPositions(experiences, layout)
+	for _, i := range detailedIdx {
+		detailed = append(detailed, experiences[i])
+	}
+	for _, i := range earlierIdx {
+		earlier = append(earlier, experiences[i])
+	}
+	return detailed, earlier
+}
+
+// DetailedExperience returns the positions rendered with full detail.
+func (f *baseFormatter) DetailedExperience(experiences []resume.Experience, layout *resume.Layout) []resume.Experience {
+	detailed, _ := SplitExperience(experiences, layout)
+	return detailed
+}
+
+// EarlierExperience returns the positions rendered as compact one-line entries.
+func (f *baseFormatter) EarlierExperience(experiences []resume.Experience, layout *resume.Layout) []resume.Experience {
+	_, earlier := SplitExperience(experiences, layout)
+	return earlier
+}
+
+// FormatYearRange renders a date range using years only, e.g. "2008 – 2011".
+func (f *baseFormatter) FormatYearRange(dates resume.DateRange) string {
+	return f.formatYearRangeWith(dates, "–")
+}
+
+// formatYearRangeWith renders a year-only date range joined by dash.
+func (f *baseFormatter) formatYearRangeWith(dates resume.DateRange, dash string) string {
+	if dates.Start.IsZero() && (dates.End == nil || dates.End.IsZero()) {
+		return ""
+	}
+
+	start := ""
+	if !dates.Start.IsZero() {
+		start = dates.Start.Format("2006")
+	}
+	end := "Present"
+	if dates.End != nil && !dates.End.IsZero() {
+		end = dates.End.Format("2006")
+	}
+
+	if start == "" {
+		return end
+	}
+	if start == end {
+		return start
+	}
+	return fmt.Sprintf("%s %s %s", start, dash, end)
+}
+
+// FormatLetterDate renders a cover letter's date, e.g. "March 4, 2025",
+// falling back to today when the letter sets none.
+func (f *baseFormatter) FormatLetterDate(letter *resume.CoverLetter) string {
+	if letter == nil {
+		return ""
+	}
+	return letter.DateOr(time.Now()).Format("January 2, 2006")
+}
+
+// LinkText returns the display label for a contact link, preferring an
+// explicit label and otherwise a canonical short form like "github.com/user".
+func (f *baseFormatter) LinkText(link resume.Link) string {
+	return link.DisplayLabel()
+}
+
+// LinkType returns the explicit or inferred type of a contact link.
+func (f *baseFormatter) LinkType(link resume.Link) string {
+	return link.ResolvedType()
+}
+
+// LinkPrefix returns a plain-text prefix such as "GitHub: " used in place of
+// an icon by text-only outputs. Plain websites get no prefix.
+func (f *baseFormatter) LinkPrefix(link resume.Link) string {
+	t := link.ResolvedType()
+	if t == resume.LinkTypeWebsite {
+		return ""
+	}
+	return resume.LinkTypeName(t) + ": "
+}
+
 // SortEducationByDate returns a copy of education entries sorted by start date descending.
 func (f *baseFormatter) SortEducationByDate(education []resume.Education) []resume.Education {
 	sorted := make([]resume.Education, len(education))
@@ -241,7 +241,7 @@ func TestSkillNames(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := f.SkillNames(tt.items)
+			got := f.SkillNames(resume.SkillItemsFromNames(tt.items...))
 			if len(got) != tt.want {
 				t.Errorf("SkillNames() returned %d items, want %d", len(got), tt.want)
 			}
@@ -249,6 +249,52 @@ func TestSkillNames(t *testing.T) {
 	}
 }
 
+func TestFormatSkills(t *testing.T) {
+	items := []resume.SkillItem{
+		{Name: "Go", Level: "expert", Years: 6},
+		{Name: "Rust", Level: "beginner"},
+		{Name: "C++"},
+	}
+
+	tests := []struct {
+		name   string
+		layout *resume.Layout
+		want   string
+	}{
+		{"no layout", nil, "Go, Rust, C++"},
+		{"text", &resume.Layout{SkillLevels: "text"}, "Go (Expert), Rust (Beginner), C++"},
+		{"text with years", &resume.Layout{SkillLevels: "text", SkillYears: true}, "Go (Expert, 6 yrs), Rust (Beginner), C++"},
+		{"dots", &resume.Layout{SkillLevels: "dots"}, "Go ●●●●, Rust ●○○○, C++"},
+		{"bars", &resume.Layout{SkillLevels: "bars"}, "Go ▰▰▰▰, Rust ▰▱▱▱, C++"},
+	}
+
+	f := newMarkdownFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resume.Resume{Layout: tt.layout}
+			if got := f.FormatSkills(r, items); got != tt.want {
+				t.Errorf("FormatSkills() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("html bars", func(t *testing.T) {
+		r := &resume.Resume{Layout: &resume.Layout{SkillLevels: "bars"}}
+		got := string(newHTMLFormatter().FormatSkills(r, items[:1]))
+		if !strings.Contains(got, `<span class="skill-bar" title="expert"><span style="width: 100%"></span></span>`) {
+			t.Errorf("unexpected HTML bar output: %s", got)
+		}
+	})
+
+	t.Run("latex escapes names", func(t *testing.T) {
+		r := &resume.Resume{Layout: &resume.Layout{SkillLevels: "text"}}
+		got := newLaTeXFormatter().FormatSkills(r, []resume.SkillItem{{Name: "C#", Level: "advanced"}})
+		if got != `C\# (Advanced)` {
+			t.Errorf("FormatSkills() = %q", got)
+		}
+	})
+}
+
 func TestJoin(t *testing.T) {
 	f := &baseFormatter{}
 
@@ -389,6 +435,80 @@ func TestSortExperienceByDate(t *testing.T) {
 	})
 }
 
+func TestSplitExperience(t *testing.T) {
+	date := func(year int) time.Time { return time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC) }
+	end := func(year int) *time.Time { d := date(year); return &d }
+
+	positions := []resume.Experience{
+		{Title: "Oldest", Dates: resume.DateRange{Start: date(2004), End: end(2008)}},
+		{Title: "Current", Dates: resume.DateRange{Start: date(2020)}},
+		{Title: "Middle", Dates: resume.DateRange{Start: date(2012), End: end(2016)}},
+		{Title: "Older", Dates: resume.DateRange{Start: date(2008), End: end(2011)}},
+		{Title: "Recent", Dates: resume.DateRange{Start: date(2016), End: end(2020)}},
+	}
+
+	titles := func(exps []resume.Experience) []string {
+		var out []string
+		for _, e := range exps {
+			out = append(out, e.Title)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		layout       *resume.Layout
+		wantDetailed []string
+		wantEarlier  []string
+	}{
+		{"nil layout", nil, []string{"Current", "Recent", "Middle", "Older", "Oldest"}, nil},
+		{"no collapse settings", &resume.Layout{Density: "compact"}, []string{"Current", "Recent", "Middle", "Older", "Oldest"}, nil},
+		{"collapse before year", &resume.Layout{CollapseBefore: 2012}, []string{"Current", "Recent", "Middle"}, []string{"Older", "Oldest"}},
+		{"max detailed positions", &resume.Layout{MaxDetailedPositions: 2}, []string{"Current", "Recent"}, []string{"Middle", "Older", "Oldest"}},
+		{"both settings", &resume.Layout{CollapseBefore: 2009, MaxDetailedPositions: 4}, []string{"Current", "Recent", "Middle", "Older"}, []string{"Oldest"}},
+		{"current role never collapsed by year", &resume.Layout{CollapseBefore: 2030}, []string{"Current"}, []string{"Recent", "Middle", "Older", "Oldest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detailed, earlier := SplitExperience(positions, tt.layout)
+			if got := strings.Join(titles(detailed), ","); got != strings.Join(tt.wantDetailed, ",") {
+				t.Errorf("detailed = %s, want %s", got, strings.Join(tt.wantDetailed, ","))
+			}
+			if got := strings.Join(titles(earlier), ","); got != strings.Join(tt.wantEarlier, ",") {
+				t.Errorf("earlier = %s, want %s", got, strings.Join(tt.wantEarlier, ","))
+			}
+		})
+	}
+}
+
+func TestFormatYearRange(t *testing.T) {
+	f := &baseFormatter{}
+
+	y2008 := time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC)
+	y2011 := time.Date(2011, time.August, 1, 0, 0, 0, 0, time.UTC)
+	y2008b := time.Date(2008, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		dr   resume.DateRange
+		want string
+	}{
+		{"both dates", resume.DateRange{Start: y2008, End: &y2011}, "2008 – 2011"},
+		{"present", resume.DateRange{Start: y2008}, "2008 – Present"},
+		{"same year", resume.DateRange{Start: y2008, End: &y2008b}, "2008"},
+		{"zero", resume.DateRange{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.FormatYearRange(tt.dr); got != tt.want {
+				t.Errorf("FormatYearRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSortEducationByDate(t *testing.T) {
 	f := &baseFormatter{}
 
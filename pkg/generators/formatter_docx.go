@@ -24,6 +24,12 @@ func (f *docxFormatter) EscapeText(value string) string {
 	return value
 }
 
+// FormatSkills renders skills with their level and years according to the
+// resume layout, drawing levels with Unicode glyphs.
+func (f *docxFormatter) FormatSkills(r *resume.Resume, items []resume.SkillItem) string {
+	return formatSkillsWith(r, items, f.EscapeText, unicodeSkillLevel)
+}
+
 // FormatDateRange formats dates, detecting year-only dates.
 func (f *docxFormatter) FormatDateRange(dr resume.DateRange) string {
 	if dr.End != nil {
@@ -37,6 +37,57 @@ func (f *htmlFormatter) FormatLink(link string) string {
 	return fmt.Sprintf(`<a href="%s">%s</a>`, template.HTMLEscapeString(url), template.HTMLEscapeString(url))
 }
 
+// linkIconSVG holds the inner markup of each contact link icon, drawn on a
+// 16x16 viewBox in currentColor so icons follow the surrounding text color.
+var linkIconSVG = map[string]string{
+	resume.LinkTypeGitHub:   `<path d="M8 0C3.58 0 0 3.58 0 8c0 3.54 2.29 6.53 5.47 7.59.4.07.55-.17.55-.38 0-.19-.01-.82-.01-1.49-2.01.37-2.53-.49-2.69-.94-.09-.23-.48-.94-.82-1.13-.28-.15-.68-.52-.01-.53.63-.01 1.08.58 1.23.82.72 1.21 1.87.87 2.33.66.07-.52.28-.87.51-1.07-1.78-.2-3.64-.89-3.64-3.95 0-.87.31-1.59.82-2.15-.08-.2-.36-1.02.08-2.12 0 0 .67-.21 2.2.82.64-.18 1.32-.27 2-.27.68 0 1.36.09 2 .27 1.53-1.04 2.2-.82 2.2-.82.44 1.1.16 1.92.08 2.12.51.56.82 1.27.82 2.15 0 3.07-1.87 3.75-3.65 3.95.29.25.54.73.54 1.48 0 1.07-.01 1.93-.01 2.2 0 .21.15.46.55.38A8.01 8.01 0 0 0 16 8c0-4.42-3.58-8-8-8z"/>`,
+	resume.LinkTypeLinkedIn: `<path d="M0 1.15C0 .52.53 0 1.18 0h13.64C15.47 0 16 .52 16 1.15v13.7c0 .63-.53 1.15-1.18 1.15H1.18C.53 16 0 15.48 0 14.85zm4.94 12.24V6.17H2.54v7.22zM3.74 5.18c.84 0 1.36-.55 1.36-1.25-.02-.71-.52-1.25-1.34-1.25-.82 0-1.36.54-1.36 1.25 0 .7.52 1.25 1.33 1.25zm4.91 8.21V9.36c0-.22.02-.43.08-.58.17-.43.57-.88 1.23-.88.87 0 1.22.66 1.22 1.64v3.85h2.4V9.25c0-2.22-1.18-3.25-2.76-3.25-1.27 0-1.84.7-2.16 1.19V6.17H6.25c.03.68 0 7.22 0 7.22z"/>`,
+	resume.LinkTypeORCID:    `<path d="M8 0a8 8 0 1 0 0 16A8 8 0 0 0 8 0zM4.6 3.4a.7.7 0 1 1 0 1.4.7.7 0 0 1 0-1.4zM4 5.9h1.2v6.6H4zm2.3 0h3c2.2 0 3.3 1.6 3.3 3.3 0 1.8-1.3 3.3-3.3 3.3h-3zm1.2 1.1v4.4h1.7c1.5 0 2.1-1 2.1-2.2 0-1.1-.7-2.2-2.1-2.2z"/>`,
+	resume.LinkTypeScholar:  `<path d="M8 1 0 6.5l3 2.05V12c0 1.66 2.24 3 5 3s5-1.34 5-3V8.55l2-1.37V11h1V6.5zm0 2.3 4.9 3.2L8 9.7 3.1 6.5z"/>`,
+	resume.LinkTypeMastodon: `<path d="M15.3 5.26c0-3.47-2.27-4.49-2.27-4.49C11.88.25 9.93.03 7.9 0h-.05C5.82.03 3.87.25 2.73.77c0 0-2.28 1.02-2.28 4.49l-.01 2.05c.01 2.05.14 4.08.96 6.16.58 1.47 2.1 3.1 4.8 3.47 1.9.27 3.58-.1 3.58-.1l-.06-1.2s-1.22.38-2.6.34c-1.36-.05-2.8-.15-3.02-1.82a3.4 3.4 0 0 1-.03-.47s1.34.33 3.03.41c1.04.05 2.01-.06 3-.18 1.88-.23 3.53-1.39 3.74-2.46.33-1.68.3-4.1.3-4.1zm-2.52 4.2h-1.56V5.64c0-.8-.34-1.21-1.02-1.21-.75 0-1.12.49-1.12 1.45v2.09H7.53V5.88c0-.96-.38-1.45-1.13-1.45-.67 0-1.01.41-1.01 1.21v3.82H3.83V5.53c0-.8.2-1.44.62-1.91.43-.47.99-.71 1.69-.71.81 0 1.42.31 1.83.93l.39.66.4-.66c.4-.62 1.01-.93 1.82-.93.7 0 1.26.24 1.69.71.41.47.62 1.11.62 1.91z"/>`,
+	resume.LinkTypeWebsite:  `<path d="M8 0a8 8 0 1 0 0 16A8 8 0 0 0 8 0zm5.9 7.25h-2.43a12.3 12.3 0 0 0-.9-4.68 6.5 6.5 0 0 1 3.33 4.68zM8 1.6c.7.9 1.4 2.84 1.5 5.65h-3C6.6 4.44 7.3 2.5 8 1.6zM5.43 2.57a12.3 12.3 0 0 0-.9 4.68H2.1a6.5 6.5 0 0 1 3.33-4.68zM2.1 8.75h2.43c.07 1.8.4 3.4.9 4.68A6.5 6.5 0 0 1 2.1 8.75zM8 14.4c-.7-.9-1.4-2.84-1.5-5.65h3c-.1 2.8-.8 4.75-1.5 5.65zm2.57-.97c.5-1.28.83-2.88.9-4.68h2.43a6.5 6.5 0 0 1-3.33 4.68z"/>`,
+}
+
+// LinkIcon renders an inline SVG icon for a contact link's type.
+func (f *htmlFormatter) LinkIcon(link resume.Link) template.HTML {
+	t := link.ResolvedType()
+	return template.HTML(`<svg class="link-icon link-icon-` + t + `" viewBox="0 0 16 16" width="1em" height="1em" fill="currentColor" aria-hidden="true">` + linkIconSVG[t] + `</svg>`)
+}
+
+// ImageTag renders an <img> element with the image embedded as base64, so
+// the HTML stays self-contained. defaultWidth is in millimetres.
+func (f *htmlFormatter) ImageTag(img *resume.Image, defaultWidth float64, class string) (template.HTML, error) {
+	if img == nil {
+		return "", nil
+	}
+	prepared, err := prepareImage(img, defaultWidth)
+	if err != nil {
+		return "", err
+	}
+	style := fmt.Sprintf("width: %.2fmm; height: %.2fmm;", prepared.WidthMM, prepared.HeightMM)
+	if img.CropMode() == resume.ImageCropCircle {
+		style += " border-radius: 50%;"
+	}
+	return template.HTML(fmt.Sprintf(`<img class="%s" src="%s" alt="%s" style="%s">`,
+		template.HTMLEscapeString(class),
+		prepared.dataURI(),
+		template.HTMLEscapeString(img.Alt),
+		style,
+	)), nil
+}
+
+// FormatSkills renders skills with their level and years according to the
+// resume layout. Levels render as CSS bars or dots.
+func (f *htmlFormatter) FormatSkills(r *resume.Resume, items []resume.SkillItem) template.HTML {
+	return template.HTML(formatSkillsWith(r, items, template.HTMLEscapeString, func(style string, rank int, levelName string) string {
+		if style == "bars" {
+			return fmt.Sprintf(`<span class="skill-bar" title="%s"><span style="width: %d%%"></span></span>`,
+				levelName, rank*100/len(resume.SkillLevels))
+		}
+		return fmt.Sprintf(`<span class="skill-dots" title="%s">%s</span>`, levelName, unicodeSkillLevel(style, rank, levelName))
+	}))
+}
+
 // layoutClass returns CSS class names derived from a *resume.Layout.
 // A nil layout returns the default classes.
 func (f *htmlFormatter) layoutClass(layout *resume.Layout) string {
@@ -80,6 +131,8 @@ func (f *htmlFormatter) hasSection(name string, r *resume.Resume) bool {
 		return r.Projects != nil && len(r.Projects.Projects) > 0
 	case "languages":
 		return r.Languages != nil && len(r.Languages.Languages) > 0
+	case "references":
+		return r.ShowReferences()
 	default:
 		return false
 	}
@@ -108,6 +161,8 @@ func (f *htmlFormatter) TemplateFuncs() template.FuncMap {
 		"formatDateRange":   f.formatDateRange,
 		"fmtDateRange":      f.FormatDateRange,
 		"fmtOptDateRange":   f.FormatOptionalDateRange,
+		"fmtYearRange":      f.FormatYearRange,
+		"fmtLetterDate":     f.FormatLetterDate,
 		"calculateDuration": f.CalculateDuration,
 
 		// Location formatting
@@ -125,6 +180,7 @@ func (f *htmlFormatter) TemplateFuncs() template.FuncMap {
 
 		// List formatting
 		"formatList":  f.FormatList,
+		"fmtSkills":   f.FormatSkills,
 		"join":        f.Join,
 		"skillNames":  f.SkillNames,
 		"filterEmpty": filterStrings,
@@ -143,6 +199,12 @@ func (f *htmlFormatter) TemplateFuncs() template.FuncMap {
 
 		// Link formatting
 		"formatLink": f.FormatLink,
+		"linkText":   f.LinkText,
+		"linkType":   f.LinkType,
+		"linkIcon":   f.LinkIcon,
+
+		// Images
+		"imageTag": f.ImageTag,
 		"fmtLink": func(value interface{}) string {
 			switch v := value.(type) {
 			case string:
@@ -161,6 +223,8 @@ func (f *htmlFormatter) TemplateFuncs() template.FuncMap {
 		// Sort functions
 		"sortSkillsByOrder":     func(categories []resume.SkillCategory) []resume.SkillCategory { return categories },
 		"sortExperienceByOrder": f.SortExperienceByDate,
+		"detailedExperience":    f.DetailedExperience,
+		"earlierExperience":     f.EarlierExperience,
 		"sortProjectsByOrder":   f.SortProjectsByDate,
 		"sortEducationByOrder":  f.SortEducationByDate,
 		"sortLinksByOrder":      func(links []string) []string { return links },
@@ -1,6 +1,7 @@
 package generators
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/urmzd/resume-generator/pkg/resume"
@@ -56,6 +57,17 @@ func TestHTMLFormatLink(t *testing.T) {
 	}
 }
 
+func TestHTMLLinkIcon(t *testing.T) {
+	f := newHTMLFormatter()
+
+	for _, linkType := range resume.LinkTypes {
+		got := string(f.LinkIcon(resume.Link{URI: "https://example.com", Type: linkType}))
+		if !strings.Contains(got, "link-icon-"+linkType) || !strings.Contains(got, "<path") {
+			t.Errorf("LinkIcon(%s) = %q, want an SVG path tagged with its type", linkType, got)
+		}
+	}
+}
+
 func TestLayoutClass(t *testing.T) {
 	f := newHTMLFormatter()
 
@@ -97,7 +109,7 @@ func TestHasSection(t *testing.T) {
 			Institutions: []resume.Education{{Institution: "MIT"}},
 		},
 		Skills: resume.Skills{
-			Categories: []resume.SkillCategory{{Category: "Languages", Items: []string{"Go"}}},
+			Categories: []resume.SkillCategory{{Category: "Languages", Items: resume.SkillItemsFromNames("Go")}},
 		},
 		Experience: resume.ExperienceList{
 			Positions: []resume.Experience{{Title: "Dev"}},
@@ -51,6 +51,18 @@ func (f *latexFormatter) FormatList(values []string) string {
 	return strings.Join(filtered, ", ")
 }
 
+// FormatSkills renders skills with their level and years according to the
+// resume layout. Bars are drawn with rules and dots with bullets.
+func (f *latexFormatter) FormatSkills(r *resume.Resume, items []resume.SkillItem) string {
+	return formatSkillsWith(r, items, f.EscapeText, func(style string, rank int, _ string) string {
+		empty := len(resume.SkillLevels) - rank
+		if style == "bars" {
+			return fmt.Sprintf(`\textcolor{black!70}{\rule{%dmm}{0.8ex}}\textcolor{black!15}{\rule{%dmm}{0.8ex}}`, rank*2, empty*2)
+		}
+		return strings.Repeat(`\textbullet`, rank) + strings.Repeat(`$\circ$`, empty)
+	})
+}
+
 // FormatGPA renders GPA with LaTeX escaping.
 func (f *latexFormatter) FormatGPA(gpa, max string) string {
 	result := f.baseFormatter.FormatGPA(gpa, max)
@@ -105,6 +117,11 @@ func (f *latexFormatter) formatMonthYear(t time.Time) string {
 	return t.Format("Jan 2006")
 }
 
+// FormatYearRange overrides the base formatter to use LaTeX-specific en-dash.
+func (f *latexFormatter) FormatYearRange(dates resume.DateRange) string {
+	return f.formatYearRangeWith(dates, `\textendash`)
+}
+
 // FormatDates overrides the base formatter to use LaTeX-specific en-dash.
 func (f *latexFormatter) FormatDates(value interface{}) string {
 	switch v := value.(type) {
@@ -154,16 +171,79 @@ func (f *latexFormatter) FormatLinkWithDomain(link string) string {
 	return fmt.Sprintf(`\href{%s}{%s}`, f.EscapeText(url), f.EscapeText(displayURL))
 }
 
+// latexLinkIcons maps link types to fontawesome5 commands.
+var latexLinkIcons = map[string]string{
+	resume.LinkTypeGitHub:   `\faGithub`,
+	resume.LinkTypeLinkedIn: `\faLinkedin`,
+	resume.LinkTypeORCID:    `\faOrcid`,
+	resume.LinkTypeScholar:  `\faGraduationCap`,
+	resume.LinkTypeMastodon: `\faMastodon`,
+	resume.LinkTypeWebsite:  `\faGlobe`,
+}
+
+// LinkIcon returns the fontawesome5 command for a contact link's type.
+func (f *latexFormatter) LinkIcon(link resume.Link) string {
+	return latexLinkIcons[link.ResolvedType()]
+}
+
+// FormatContactLink renders a contact link as an icon followed by a
+// hyperlinked canonical label. Templates using it must load fontawesome5.
+func (f *latexFormatter) FormatContactLink(link resume.Link) string {
+	label := f.FormatPlainContactLink(link)
+	if label == "" {
+		return ""
+	}
+	return f.LinkIcon(link) + `\ ` + label
+}
+
+// FormatPlainContactLink renders a contact link as its hyperlinked canonical
+// label, without an icon.
+func (f *latexFormatter) FormatPlainContactLink(link resume.Link) string {
+	url := strings.TrimSpace(link.URI)
+	if url == "" {
+		return ""
+	}
+	return fmt.Sprintf(`\href{%s}{%s}`, f.EscapeText(url), f.EscapeText(f.LinkText(link)))
+}
+
+// HexColor turns a color param such as #0a66c2 or #06c into the six
+// uppercase digits xcolor's HTML model expects, as in
+// \definecolor{accent}{HTML}{0A66C2}.
+func (f *latexFormatter) HexColor(color string) string {
+	hex := strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return strings.ToUpper(hex)
+}
+
+// IncludeImage renders an \includegraphics command for an image. The file is
+// referenced by ImageAssetName and must be written beside the .tex file with
+// WriteImageAssets. defaultWidth is in millimetres.
+func (f *latexFormatter) IncludeImage(img *resume.Image, defaultWidth float64) (string, error) {
+	if img == nil {
+		return "", nil
+	}
+	prepared, err := prepareImage(img, defaultWidth)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`\includegraphics[width=%.2fmm,height=%.2fmm]{%s}`, prepared.WidthMM, prepared.HeightMM, ImageAssetName(img)), nil
+}
+
 // TemplateFuncs exposes helper functions for LaTeX templates.
 func (f *latexFormatter) TemplateFuncs() template.FuncMap {
 	return template.FuncMap{
 		// Text escaping
 		"escape":           f.EscapeText,
 		"escapeLatexChars": f.EscapeText,
+		"hexColor":         f.HexColor,
 
 		// Date formatting
-		"fmtDateRange": f.FormatDateRange,
-		"fmtDates":     f.FormatDates,
+		"fmtDateRange":  f.FormatDateRange,
+		"fmtDates":      f.FormatDates,
+		"fmtYearRange":  f.FormatYearRange,
+		"fmtLetterDate": f.FormatLetterDate,
 		"formatDateRange": func(start time.Time, end *time.Time) string {
 			return f.formatDateRangeInternal(start, end)
 		},
@@ -183,6 +263,7 @@ func (f *latexFormatter) TemplateFuncs() template.FuncMap {
 			return strings.Join(escaped, sep)
 		},
 		"formatList": f.FormatList,
+		"fmtSkills":  f.FormatSkills,
 		"skillNames": f.SkillNames,
 
 		// Link formatting
@@ -209,7 +290,13 @@ func (f *latexFormatter) TemplateFuncs() template.FuncMap {
 				return ""
 			}
 		},
-		"extractDisplayURL": f.ExtractDisplayURL,
+		"extractDisplayURL":   f.ExtractDisplayURL,
+		"fmtContactLink":      f.FormatContactLink,
+		"fmtPlainContactLink": f.FormatPlainContactLink,
+		"linkText":            f.LinkText,
+		"linkType":            f.LinkType,
+		"linkIcon":            f.LinkIcon,
+		"includeImage":        f.IncludeImage,
 
 		// Location formatting
 		"fmtLocation": func(value interface{}) string {
@@ -277,6 +364,8 @@ func (f *latexFormatter) TemplateFuncs() template.FuncMap {
 
 		// Sort functions
 		"sortExperienceByOrder": f.SortExperienceByDate,
+		"detailedExperience":    f.DetailedExperience,
+		"earlierExperience":     f.EarlierExperience,
 		"sortProjectsByOrder":   f.SortProjectsByDate,
 		"sortEducationByOrder":  f.SortEducationByDate,
 
@@ -202,6 +202,30 @@ func TestLaTeXFormatLinkWithDomain(t *testing.T) {
 	}
 }
 
+func TestLaTeXFormatContactLink(t *testing.T) {
+	f := newLaTeXFormatter()
+
+	tests := []struct {
+		name string
+		link resume.Link
+		want string
+	}{
+		{"github", resume.Link{URI: "https://github.com/jane_doe"}, `\faGithub\ \href{https://github.com/jane\_doe}{github.com/jane\_doe}`},
+		{"website", resume.Link{URI: "https://example.com"}, `\faGlobe\ \href{https://example.com}{example.com}`},
+		{"explicit type and label", resume.Link{URI: "https://example.com/cv", Type: "orcid", Label: "ORCID"}, `\faOrcid\ \href{https://example.com/cv}{ORCID}`},
+		{"empty", resume.Link{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := f.FormatContactLink(tt.link)
+			if got != tt.want {
+				t.Errorf("FormatContactLink(%+v) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLaTeXExtractDisplayURL(t *testing.T) {
 	f := newLaTeXFormatter()
 
@@ -242,6 +266,7 @@ func TestLaTeXTemplateFuncs(t *testing.T) {
 		"trim", "filterEmpty", "default",
 		"sortExperienceByOrder", "sortProjectsByOrder", "sortEducationByOrder",
 		"add", "employmentType", "now", "linkLabel",
+		"fmtContactLink", "linkText", "linkType", "linkIcon",
 	}
 
 	for _, key := range expectedKeys {
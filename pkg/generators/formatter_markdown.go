@@ -71,6 +71,12 @@ func (f *markdownFormatter) ExtractDisplayURL(url string) string {
 	return url
 }
 
+// FormatSkills renders skills with their level and years according to the
+// resume layout, drawing levels with Unicode glyphs.
+func (f *markdownFormatter) FormatSkills(r *resume.Resume, items []resume.SkillItem) string {
+	return formatSkillsWith(r, items, func(s string) string { return s }, unicodeSkillLevel)
+}
+
 // TemplateFuncs exposes helper functions for Markdown templates.
 func (f *markdownFormatter) TemplateFuncs() template.FuncMap {
 	return template.FuncMap{
@@ -81,6 +87,8 @@ func (f *markdownFormatter) TemplateFuncs() template.FuncMap {
 		"fmtDateRange":    f.FormatDateRange,
 		"fmtOptDateRange": f.FormatOptionalDateRange,
 		"fmtDates":        f.FormatDates,
+		"fmtYearRange":    f.FormatYearRange,
+		"fmtLetterDate":   f.FormatLetterDate,
 		"formatDate": func(t time.Time) string {
 			if t.IsZero() {
 				return ""
@@ -108,6 +116,7 @@ func (f *markdownFormatter) TemplateFuncs() template.FuncMap {
 
 		// List formatting
 		"formatList": f.FormatList,
+		"fmtSkills":  f.FormatSkills,
 		"join": func(sep string, items []string) string {
 			return strings.Join(items, sep)
 		},
@@ -139,6 +148,9 @@ func (f *markdownFormatter) TemplateFuncs() template.FuncMap {
 			}
 		},
 		"extractDisplayURL": f.ExtractDisplayURL,
+		"linkText":          f.LinkText,
+		"linkType":          f.LinkType,
+		"linkPrefix":        f.LinkPrefix,
 
 		// GPA formatting
 		"formatGPA": f.FormatGPAStruct,
@@ -177,6 +189,8 @@ func (f *markdownFormatter) TemplateFuncs() template.FuncMap {
 
 		// Sort functions
 		"sortExperienceByOrder": f.SortExperienceByDate,
+		"detailedExperience":    f.DetailedExperience,
+		"earlierExperience":     f.EarlierExperience,
 		"sortProjectsByOrder":   f.SortProjectsByDate,
 		"sortEducationByOrder":  f.SortEducationByDate,
 
@@ -37,6 +37,10 @@ type Template struct {
 	Config      TemplateConfig
 	Embedded    bool   // true when loaded from embedded FS
 	EmbeddedDir string // e.g. "templates/modern-latex" for embedded reads
+	// Parent is the template named by extends in config.yml, or nil.
+	Parent *Template
+
+	dir string // template directory on disk (empty when embedded)
 }
 
 // TemplateConfig contains metadata about a template loaded from config.yml
@@ -49,11 +53,19 @@ type TemplateConfig struct {
 	Author       string   `yaml:"author,omitempty"`
 	Tags         []string `yaml:"tags,omitempty"`
 	TemplateFile string   `yaml:"template_file,omitempty"`
+	LetterFile   string   `yaml:"letter_file,omitempty"`
+	// Extends names a template whose {{define}} blocks, partials, files
+	// and settings this one inherits.
+	Extends string `yaml:"extends,omitempty"`
+	// Params are the settings users can change with run --param or the
+	// resume's layout.params; templates read them as .Params.
+	Params []TemplateParam `yaml:"params,omitempty"`
 }
 
 // Generator renders resumes to PDF using templates
 type Generator struct {
 	logger *zap.SugaredLogger
+	params map[string]string
 }
 
 var embeddedFS embed.FS
@@ -68,19 +80,64 @@ func NewGenerator(logger *zap.SugaredLogger) *Generator {
 	return &Generator{logger: logger}
 }
 
+// SetParams sets template param values that take precedence over the
+// resume's layout.params, keyed by param name.
+func (g *Generator) SetParams(params map[string]string) {
+	g.params = params
+}
+
 // LoadTemplate loads a template by name.
 // It tries the filesystem first (via RESUME_TEMPLATES_DIR or local templates/ dir),
 // then falls back to the embedded FS.
 func LoadTemplate(templateName string) (*Template, error) {
+	return loadTemplate(templateName, nil)
+}
+
+// loadTemplate loads a template; extending lists the templates that extend
+// it, to catch cycles.
+func loadTemplate(templateName string, extending []string) (*Template, error) {
 	// Try filesystem first
 	templateDir, err := utils.ResolveAssetPath(filepath.Join("templates", templateName))
 	if err == nil && utils.DirExists(templateDir) {
-		return loadTemplateFromFS(templateDir, templateName)
+		return loadTemplateFromFS(templateDir, templateName, extending)
 	}
 
 	// Fall back to embedded FS
 	embeddedDir := "templates/" + templateName
-	return loadTemplateFromEmbed(embeddedDir, templateName)
+	return loadTemplateFromEmbed(embeddedDir, templateName, extending)
+}
+
+// loadParent loads the template config extends, and fills in the settings
+// the config leaves to it. templateName is the name the template was loaded
+// by, which extends refers to; it may differ from config.Name.
+func loadParent(config *TemplateConfig, templateName string, extending []string) (*Template, error) {
+	if config.Extends == "" {
+		return nil, nil
+	}
+	extending = append(extending, templateName)
+	for _, name := range extending {
+		if name == config.Extends {
+			return nil, fmt.Errorf("template %s extends itself: %s", config.Name, strings.Join(append(extending, config.Extends), " -> "))
+		}
+	}
+	parent, err := loadTemplate(config.Extends, extending)
+	if err != nil {
+		return nil, fmt.Errorf("template %s extends %s: %w", config.Name, config.Extends, err)
+	}
+	if config.Format == "" {
+		config.Format = parent.Config.Format
+	}
+	if config.Format != parent.Config.Format {
+		return nil, fmt.Errorf("template %s is %s but extends %s, which is %s", config.Name, config.Format, parent.Name, parent.Config.Format)
+	}
+	if config.TemplateFile == "" {
+		config.TemplateFile = parent.Config.TemplateFile
+	}
+	if config.LetterFile == "" {
+		config.LetterFile = parent.Config.LetterFile
+	}
+	config.Params = inheritParams(parent.Config.Params, config.Params)
+	return parent, nil
 }
 
 // ListTemplates returns all available templates.
@@ -107,7 +164,7 @@ func listTemplatesFromFS(templatesDir string) ([]Template, error) {
 		if !entry.IsDir() {
 			continue
 		}
-		tmpl, err := loadTemplateFromFS(filepath.Join(templatesDir, entry.Name()), entry.Name())
+		tmpl, err := loadTemplateFromFS(filepath.Join(templatesDir, entry.Name()), entry.Name(), nil)
 		if err != nil {
 			continue
 		}
@@ -128,7 +185,7 @@ func listTemplatesFromEmbed() ([]Template, error) {
 			continue
 		}
 		embeddedDir := "templates/" + entry.Name()
-		tmpl, err := loadTemplateFromEmbed(embeddedDir, entry.Name())
+		tmpl, err := loadTemplateFromEmbed(embeddedDir, entry.Name(), nil)
 		if err != nil {
 			continue
 		}
@@ -137,11 +194,15 @@ func listTemplatesFromEmbed() ([]Template, error) {
 	return templates, nil
 }
 
-func loadTemplateFromFS(templateDir, templateName string) (*Template, error) {
+func loadTemplateFromFS(templateDir, templateName string, extending []string) (*Template, error) {
 	config, err := loadTemplateConfigFromFS(templateDir, templateName)
 	if err != nil {
 		return nil, err
 	}
+	parent, err := loadParent(&config, templateName, extending)
+	if err != nil {
+		return nil, err
+	}
 
 	tmplType, err := parseTemplateType(config.Format)
 	if err != nil {
@@ -149,9 +210,13 @@ func loadTemplateFromFS(templateDir, templateName string) (*Template, error) {
 	}
 
 	templatePath, err := resolveTemplateFileFS(templateDir, tmplType, config.TemplateFile)
-	if err != nil {
+	if err != nil && parent == nil {
 		return nil, err
 	}
+	if parent != nil && tmplType != TemplateTypeDOCX {
+		// A derived template may inherit its parent's main file.
+		templatePath = filepath.Join(templateDir, resolveTemplateFilename(tmplType, config.TemplateFile))
+	}
 
 	return &Template{
 		Name:        config.Name,
@@ -164,22 +229,29 @@ func loadTemplateFromFS(templateDir, templateName string) (*Template, error) {
 		Tags:        config.Tags,
 		Config:      config,
 		Embedded:    false,
+		Parent:      parent,
+		dir:         templateDir,
 	}, nil
 }
 
-func loadTemplateFromEmbed(embeddedDir, templateName string) (*Template, error) {
+func loadTemplateFromEmbed(embeddedDir, templateName string, extending []string) (*Template, error) {
 	config, err := loadTemplateConfigFromEmbed(embeddedDir, templateName)
 	if err != nil {
 		return nil, err
 	}
+	parent, err := loadParent(&config, templateName, extending)
+	if err != nil {
+		return nil, err
+	}
 
 	tmplType, err := parseTemplateType(config.Format)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify template file exists in embedded FS (skip for DOCX)
-	if tmplType != TemplateTypeDOCX {
+	// Verify template file exists in embedded FS (skip for DOCX and for
+	// derived templates, which may inherit it)
+	if tmplType != TemplateTypeDOCX && parent == nil {
 		filename := resolveTemplateFilename(tmplType, config.TemplateFile)
 		embeddedPath := embeddedDir + "/" + filename
 		if _, err := fs.Stat(embeddedFS, embeddedPath); err != nil {
@@ -199,6 +271,7 @@ func loadTemplateFromEmbed(embeddedDir, templateName string) (*Template, error)
 		Config:      config,
 		Embedded:    true,
 		EmbeddedDir: embeddedDir,
+		Parent:      parent,
 	}, nil
 }
 
@@ -212,50 +285,87 @@ func (g *Generator) Generate(templateName string, resume *resume.Resume) (string
 }
 
 // GenerateWithTemplate renders a resume using an already-loaded template.
+// The template set is assembled from the template's inheritance chain.
 func (g *Generator) GenerateWithTemplate(tmpl *Template, resume *resume.Resume) (string, error) {
 	g.logger.Infof("Generating resume using template: %s (%s)", tmpl.Name, tmpl.Type)
 
-	var content []byte
-	var err error
-
-	if tmpl.Embedded {
-		filename := resolveTemplateFilename(tmpl.Type, tmpl.Config.TemplateFile)
-		content, err = fs.ReadFile(embeddedFS, tmpl.EmbeddedDir+"/"+filename)
-	} else {
-		content, err = os.ReadFile(tmpl.Path)
-	}
+	layers, err := templateLayers(tmpl)
 	if err != nil {
 		return "", fmt.Errorf("failed to read template: %w", err)
 	}
+	if resume, err = g.withParams(tmpl, resume); err != nil {
+		return "", err
+	}
 
 	switch tmpl.Type {
 	case TemplateTypeHTML:
-		return g.renderHTML(string(content), resume)
+		return NewHTMLGenerator(g.logger).generate(layers, resume)
 	case TemplateTypeLaTeX:
-		return g.renderLaTeX(string(content), resume)
+		if resume, err = latexParams(tmpl, resume); err != nil {
+			return "", err
+		}
+		return NewLaTeXGenerator(g.logger).generate(layers, resume)
 	case TemplateTypeMarkdown:
-		return g.renderMarkdown(string(content), resume)
+		return NewMarkdownGenerator(g.logger).generate(layers, resume)
 	default:
 		return "", fmt.Errorf("unknown template type: %s", tmpl.Type)
 	}
 }
 
-// renderHTML renders an HTML template
-func (g *Generator) renderHTML(templateContent string, resume *resume.Resume) (string, error) {
-	htmlGen := NewHTMLGenerator(g.logger)
-	return htmlGen.Generate(templateContent, resume)
+// SupportsCoverLetter reports whether the template, or a template it
+// extends, can render a cover letter.
+func (t *Template) SupportsCoverLetter() bool {
+	if t.Type == TemplateTypeDOCX {
+		return true
+	}
+	_, err := letterLayer(t)
+	return err == nil
+}
+
+// GenerateCoverLetter renders a cover letter using an already-loaded
+// template. The letter file is parsed together with the resume template so
+// it can reuse the resume's header and styles.
+func (g *Generator) GenerateCoverLetter(tmpl *Template, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	g.logger.Infof("Generating cover letter using template: %s (%s)", tmpl.Name, tmpl.Type)
+
+	layers, err := templateLayers(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	letterFile, err := letterLayer(tmpl)
+	if err != nil {
+		return "", err
+	}
+	if r, err = g.withParams(tmpl, r); err != nil {
+		return "", err
+	}
+
+	switch tmpl.Type {
+	case TemplateTypeHTML:
+		return NewHTMLGenerator(g.logger).generateLetter(layers, letterFile, r, letter)
+	case TemplateTypeLaTeX:
+		if r, err = latexParams(tmpl, r); err != nil {
+			return "", err
+		}
+		return NewLaTeXGenerator(g.logger).generateLetter(layers, letterFile, r, letter)
+	case TemplateTypeMarkdown:
+		return NewMarkdownGenerator(g.logger).generateLetter(layers, letterFile, r, letter)
+	default:
+		return "", fmt.Errorf("unknown template type: %s", tmpl.Type)
+	}
 }
 
-// renderLaTeX renders a LaTeX template
-func (g *Generator) renderLaTeX(templateContent string, resume *resume.Resume) (string, error) {
-	latexGen := NewLaTeXGenerator(g.logger)
-	return latexGen.Generate(templateContent, resume)
+// GenerateCoverLetterDOCX generates a DOCX cover letter sharing the resume's header.
+func (g *Generator) GenerateCoverLetterDOCX(r *resume.Resume, letter *resume.CoverLetter) ([]byte, error) {
+	g.logger.Info("Generating DOCX cover letter")
+	return NewDOCXGenerator(g.logger).GenerateLetter(r, letter)
 }
 
-// renderMarkdown renders a Markdown template
-func (g *Generator) renderMarkdown(templateContent string, resume *resume.Resume) (string, error) {
-	mdGen := NewMarkdownGenerator(g.logger)
-	return mdGen.Generate(templateContent, resume)
+// letterPayload is the data passed to cover letter templates: every resume
+// field plus the letter itself as .Letter.
+type letterPayload struct {
+	*resume.Resume
+	Letter *resume.CoverLetter
 }
 
 // GenerateDOCX generates a DOCX document from the resume.
@@ -300,32 +410,18 @@ func FormatTemplateName(name string) string {
 // ExtractEmbeddedTemplateDir extracts all files from an embedded template directory
 // to a temporary directory on disk. Returns the temp directory path.
 func ExtractEmbeddedTemplateDir(embeddedDir string) (string, error) {
+	files, err := fs.Sub(embeddedFS, embeddedDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read embedded dir %s: %w", embeddedDir, err)
+	}
 	tmpDir, err := os.MkdirTemp("", "resume-template-*")
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp dir: %w", err)
 	}
-
-	entries, err := fs.ReadDir(embeddedFS, embeddedDir)
-	if err != nil {
+	if err := copyTemplateFiles(files, tmpDir, false); err != nil {
 		_ = os.RemoveAll(tmpDir)
-		return "", fmt.Errorf("failed to read embedded dir %s: %w", embeddedDir, err)
+		return "", fmt.Errorf("embedded dir %s: %w", embeddedDir, err)
 	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		data, err := fs.ReadFile(embeddedFS, embeddedDir+"/"+entry.Name())
-		if err != nil {
-			_ = os.RemoveAll(tmpDir)
-			return "", fmt.Errorf("failed to read embedded file %s: %w", entry.Name(), err)
-		}
-		if err := os.WriteFile(filepath.Join(tmpDir, entry.Name()), data, 0644); err != nil {
-			_ = os.RemoveAll(tmpDir)
-			return "", fmt.Errorf("failed to write file %s: %w", entry.Name(), err)
-		}
-	}
-
 	return tmpDir, nil
 }
 
@@ -368,10 +464,14 @@ func parseTemplateConfig(data []byte, templateName string) (TemplateConfig, erro
 		cfg.DisplayName = cfg.Name
 	}
 
+	cfg.Extends = strings.TrimSpace(cfg.Extends)
 	cfg.Format = strings.ToLower(strings.TrimSpace(cfg.Format))
-	if cfg.Format == "" {
+	if cfg.Format == "" && cfg.Extends == "" {
 		return TemplateConfig{}, fmt.Errorf("template %s config missing format", cfg.Name)
 	}
+	if err := validateParams(cfg.Params); err != nil {
+		return TemplateConfig{}, fmt.Errorf("template %s: %w", cfg.Name, err)
+	}
 
 	return cfg, nil
 }
@@ -408,6 +508,23 @@ func resolveTemplateFilename(tmplType TemplateType, override string) string {
 	}
 }
 
+func resolveLetterFilename(tmplType TemplateType, override string) string {
+	filename := strings.TrimSpace(override)
+	if filename != "" {
+		return filename
+	}
+	switch tmplType {
+	case TemplateTypeHTML:
+		return "letter.html"
+	case TemplateTypeLaTeX:
+		return "letter.tex"
+	case TemplateTypeMarkdown:
+		return "letter.md"
+	default:
+		return ""
+	}
+}
+
 func resolveTemplateFileFS(templateDir string, tmplType TemplateType, override string) (string, error) {
 	if tmplType == TemplateTypeDOCX {
 		return "", nil
@@ -3,6 +3,7 @@ package generators
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/urmzd/resume-generator/pkg/resume"
@@ -272,3 +273,168 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestGenerateCollapsedExperience(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	inputData, err := resume.LoadResumeFromFile(filepath.Join("testdata", "input", "software_engineer.yml"))
+	if err != nil {
+		t.Fatalf("failed to load input: %v", err)
+	}
+	r := inputData.ToResume()
+	r.Layout = &resume.Layout{MaxDetailedPositions: 2}
+
+	// The two oldest positions lose their highlights.
+	collapsedHighlight := "Won second place in a company-wide hackathon"
+	keptHighlight := "Implemented a company-wide upgrade of network security protocols"
+
+	gen := NewGenerator(zap.NewNop().Sugar())
+	for _, name := range []string{"modern-html", "modern-latex", "modern-cv", "modern-markdown", "modern-docx"} {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := LoadTemplate(name)
+			if err != nil {
+				t.Fatalf("LoadTemplate(%s) error: %v", name, err)
+			}
+
+			var got string
+			if tmpl.Type == TemplateTypeDOCX {
+				docxBytes, err := gen.GenerateDOCX(r)
+				if err != nil {
+					t.Fatalf("GenerateDOCX() error: %v", err)
+				}
+				xml, err := extractDocumentXML(docxBytes)
+				if err != nil {
+					t.Fatalf("failed to extract document.xml: %v", err)
+				}
+				got = string(xml)
+			} else {
+				got, err = gen.GenerateWithTemplate(tmpl, r)
+				if err != nil {
+					t.Fatalf("GenerateWithTemplate() error: %v", err)
+				}
+			}
+
+			if !strings.Contains(strings.ToUpper(got), "EARLIER EXPERIENCE") {
+				t.Error("expected an Earlier Experience section")
+			}
+			if !strings.Contains(got, "Junior Developer") {
+				t.Error("expected collapsed position title to be listed")
+			}
+			if strings.Contains(got, collapsedHighlight) {
+				t.Error("collapsed position should not render its highlights")
+			}
+			if !strings.Contains(got, keptHighlight) {
+				t.Error("detailed position should keep its highlights")
+			}
+		})
+	}
+}
+
+func TestGenerateReferenceModes(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	inputData, err := resume.LoadResumeFromFile(filepath.Join("testdata", "input", "software_engineer.yml"))
+	if err != nil {
+		t.Fatalf("failed to load input: %v", err)
+	}
+	r := inputData.ToResume()
+
+	gen := NewGenerator(zap.NewNop().Sugar())
+	render := func(t *testing.T, name string) string {
+		tmpl, err := LoadTemplate(name)
+		if err != nil {
+			t.Fatalf("LoadTemplate(%s) error: %v", name, err)
+		}
+		if tmpl.Type == TemplateTypeDOCX {
+			docxBytes, err := gen.GenerateDOCX(r)
+			if err != nil {
+				t.Fatalf("GenerateDOCX() error: %v", err)
+			}
+			xml, err := extractDocumentXML(docxBytes)
+			if err != nil {
+				t.Fatalf("failed to extract document.xml: %v", err)
+			}
+			return string(xml)
+		}
+		got, err := gen.GenerateWithTemplate(tmpl, r)
+		if err != nil {
+			t.Fatalf("GenerateWithTemplate() error: %v", err)
+		}
+		return got
+	}
+
+	for _, name := range []string{"modern-html", "modern-latex", "modern-cv", "modern-markdown", "modern-docx"} {
+		t.Run(name, func(t *testing.T) {
+			r.Layout = &resume.Layout{ShowReferences: resume.ReferencesRedacted}
+			got := render(t, name)
+			for _, want := range []string{"John Smith", "Tech Solutions Inc.", "Maria Garcia"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("redacted references missing %q", want)
+				}
+			}
+			for _, secret := range []string{"john.smith@example.com", "555) 010-0199", "Former manager", "Engineering Manager"} {
+				if strings.Contains(got, secret) {
+					t.Errorf("redacted references leaked %q", secret)
+				}
+			}
+
+			r.Layout = &resume.Layout{References: true}
+			got = render(t, name)
+			if strings.Contains(got, "John Smith") {
+				t.Error("references should be hidden by default")
+			}
+			if !strings.Contains(got, "References available upon request") {
+				t.Error("expected the references note when the section is hidden")
+			}
+		})
+	}
+}
+
+func TestGenerateLaTeXSkillColumns(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	inputData, err := resume.LoadResumeFromFile(filepath.Join("testdata", "input", "software_engineer.yml"))
+	if err != nil {
+		t.Fatalf("failed to load input: %v", err)
+	}
+	r := inputData.ToResume()
+
+	gen := NewGenerator(zap.NewNop().Sugar())
+	for _, name := range []string{"modern-latex", "modern-cv"} {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := LoadTemplate(name)
+			if err != nil {
+				t.Fatalf("LoadTemplate(%s) error: %v", name, err)
+			}
+
+			r.Layout = nil
+			got, err := gen.GenerateWithTemplate(tmpl, r)
+			if err != nil {
+				t.Fatalf("GenerateWithTemplate() error: %v", err)
+			}
+			if strings.Contains(got, `\begin{multicols}`) {
+				t.Error("skills should be a single column by default")
+			}
+
+			r.Layout = &resume.Layout{SkillColumns: 2}
+			if got, err = gen.GenerateWithTemplate(tmpl, r); err != nil {
+				t.Fatalf("GenerateWithTemplate() error: %v", err)
+			}
+			if !strings.Contains(got, "\\begin{multicols}{2}\n\\begin{description}") || !strings.Contains(got, "\\end{description}\n\\end{multicols}") {
+				t.Error("expected the skills list in two columns")
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package generators
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"html/template"
+	"regexp"
 	"strings"
 	"time"
 
@@ -36,15 +38,19 @@ func NewHTMLGenerator(logger *zap.SugaredLogger) *HTMLGenerator {
 
 // Generate creates an HTML resume from the resume data and template
 func (g *HTMLGenerator) Generate(templateContent string, r *resume.Resume) (string, error) {
+	return g.generate(singleLayer(templateContent), r)
+}
+
+// generate renders the template set assembled from layers.
+func (g *HTMLGenerator) generate(layers []templateLayer, r *resume.Resume) (string, error) {
 	g.logger.Info("Generating HTML resume")
+	r = cssParams(r)
 
-	// Parse the template
-	tmpl, err := template.New("resume").Funcs(g.funcs).Parse(templateContent)
+	tmpl, err := parseHTMLLayers(template.New("resume").Funcs(g.funcs), layers)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse HTML template: %w", err)
 	}
 
-	// Execute the template - passing resume directly
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, r); err != nil {
 		return "", fmt.Errorf("failed to execute HTML template: %w", err)
@@ -54,6 +60,57 @@ func (g *HTMLGenerator) Generate(templateContent string, r *resume.Resume) (stri
 	return buf.String(), nil
 }
 
+// GenerateLetter renders an HTML cover letter. The resume template is parsed
+// first so the letter can call its named templates, such as "header" and
+// "styles".
+func (g *HTMLGenerator) GenerateLetter(templateContent, letterContent string, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	return g.generateLetter(singleLayer(templateContent), templateLayer{name: "letter", content: letterContent}, r, letter)
+}
+
+// generateLetter renders the letter on top of the template set assembled
+// from layers.
+func (g *HTMLGenerator) generateLetter(layers []templateLayer, letterFile templateLayer, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	g.logger.Info("Generating HTML cover letter")
+	r = cssParams(r)
+
+	base, err := parseHTMLLayers(template.New("resume").Funcs(g.funcs), layers)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML template: %w", err)
+	}
+	tmpl, err := base.New("letter").Parse(letterFile.content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML cover letter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, letterPayload{Resume: r, Letter: letter}); err != nil {
+		return "", fmt.Errorf("failed to execute HTML cover letter: %w", err)
+	}
+
+	g.logger.Info("Successfully generated HTML cover letter")
+	return buf.String(), nil
+}
+
+// cssParams returns a copy of the resume whose string params are typed as
+// CSS, so values such as 'Inter', sans-serif survive html/template's CSS
+// filter. Their declared types have already excluded characters that could
+// leave the CSS value; in HTML text they are still escaped.
+func cssParams(r *resume.Resume) *resume.Resume {
+	if len(r.Params) == 0 {
+		return r
+	}
+	params := make(map[string]any, len(r.Params))
+	for name, value := range r.Params {
+		if s, ok := value.(string); ok {
+			value = template.CSS(s)
+		}
+		params[name] = value
+	}
+	out := *r
+	out.Params = params
+	return &out
+}
+
 // GenerateWithCSS creates an HTML resume with embedded CSS
 func (g *HTMLGenerator) GenerateWithCSS(templateContent, cssContent string, r *resume.Resume) (string, error) {
 	g.logger.Info("Generating HTML resume with embedded CSS")
@@ -254,3 +311,22 @@ func (g *AdvancedHTMLGenerator) Generate(templateContent string, resume *resume.
 	g.logger.Info("Successfully generated advanced HTML resume")
 	return buf.String(), nil
 }
+
+// reSectionTitle matches the heading of a rendered section: any element with
+// the "section-title" class.
+var reSectionTitle = regexp.MustCompile(`(?s)<[a-zA-Z0-9]+[^>]*\bclass="(?:[^"]*\s)?section-title(?:\s[^"]*)?"[^>]*>(.*?)</`)
+
+var reTag = regexp.MustCompile(`<[^>]*>`)
+
+// SectionTitles returns the headings of the sections in rendered HTML, in
+// document order. They become the PDF bookmarks for HTML templates.
+func SectionTitles(content string) []string {
+	var titles []string
+	for _, m := range reSectionTitle.FindAllStringSubmatch(content, -1) {
+		title := strings.Join(strings.Fields(html.UnescapeString(reTag.ReplaceAllString(m[1], ""))), " ")
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
@@ -67,3 +67,16 @@ func TestHTMLGenerator_GenerateStandalone(t *testing.T) {
 		t.Error("GenerateStandalone() missing rendered resume content")
 	}
 }
+
+func TestSectionTitles(t *testing.T) {
+	content := `<body><h1 class="name">Jane</h1>
+<div class="section"><div class="section-title">Professional Summary</div></div>
+<div class="section"><h2 class="section-title muted">Skills &amp; <em>Tools</em></h2></div>
+<div class="section-titles">Not a section</div>`
+
+	got := SectionTitles(content)
+	want := []string{"Professional Summary", "Skills & Tools"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("SectionTitles() = %q, want %q", got, want)
+	}
+}
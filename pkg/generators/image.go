@@ -0,0 +1,179 @@
+package generators
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// Default rendered widths in millimetres when an image sets no size.
+const (
+	defaultPhotoWidthMM     = 30
+	defaultLogoWidthMM      = 8
+	defaultThumbnailWidthMM = 35
+)
+
+// preparedImage is an image read from disk, cropped and sized for output.
+type preparedImage struct {
+	Data     []byte
+	MIME     string
+	WidthMM  float64
+	HeightMM float64
+}
+
+// imageExt returns the file extension a prepared image is written with.
+// Circular crops need transparency and are always re-encoded as PNG.
+func imageExt(img *resume.Image) string {
+	if img.CropMode() == resume.ImageCropCircle {
+		return ".png"
+	}
+	switch strings.ToLower(filepath.Ext(img.Path)) {
+	case ".jpg", ".jpeg":
+		return ".jpg"
+	default:
+		return ".png"
+	}
+}
+
+// ImageAssetName returns the file name an image is copied to beside a
+// generated .tex file. It is stable for a given source path and crop.
+func ImageAssetName(img *resume.Image) string {
+	sum := sha256.Sum256([]byte(img.ResolvedPath() + "\x00" + img.CropMode()))
+	return "img-" + hex.EncodeToString(sum[:6]) + imageExt(img)
+}
+
+// WriteImageAssets writes every image referenced by the resume into dir
+// under its ImageAssetName, so LaTeX output can include it by name.
+func WriteImageAssets(r *resume.Resume, dir string) error {
+	for _, fi := range r.Images() {
+		prepared, err := prepareImage(fi.Image, 0)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fi.Field, err)
+		}
+		dst := filepath.Join(dir, ImageAssetName(fi.Image))
+		if err := os.WriteFile(dst, prepared.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write image %s: %w", dst, err)
+		}
+	}
+	return nil
+}
+
+// prepareImage loads an image, applies its crop and resolves its rendered
+// size. defaultWidth is used when the image sets neither width nor height.
+func prepareImage(img *resume.Image, defaultWidth float64) (*preparedImage, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image is nil")
+	}
+	path := img.ResolvedPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s: %w", path, err)
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, fmt.Errorf("image %s has no pixels", path)
+	}
+
+	prepared := &preparedImage{Data: data, MIME: "image/" + format}
+	pxWidth, pxHeight := cfg.Width, cfg.Height
+
+	if crop := img.CropMode(); crop != resume.ImageCropNone {
+		src, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image %s: %w", path, err)
+		}
+		cropped := cropImage(src, crop)
+
+		var buf bytes.Buffer
+		if imageExt(img) == ".jpg" {
+			err = jpeg.Encode(&buf, cropped, &jpeg.Options{Quality: 90})
+			prepared.MIME = "image/jpeg"
+		} else {
+			err = png.Encode(&buf, cropped)
+			prepared.MIME = "image/png"
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode image %s: %w", path, err)
+		}
+		prepared.Data = buf.Bytes()
+		pxWidth, pxHeight = cropped.Bounds().Dx(), cropped.Bounds().Dy()
+	}
+
+	prepared.WidthMM, prepared.HeightMM = imageSize(img, defaultWidth, float64(pxHeight)/float64(pxWidth))
+	return prepared, nil
+}
+
+// imageSize resolves the rendered size in millimetres, filling in whichever
+// dimension is missing from the aspect ratio (height / width).
+func imageSize(img *resume.Image, defaultWidth, aspect float64) (width, height float64) {
+	width, height = img.Width, img.Height
+	switch {
+	case width == 0 && height == 0:
+		width = defaultWidth
+		height = width * aspect
+	case width == 0:
+		width = height / aspect
+	case height == 0:
+		height = width * aspect
+	}
+	return width, height
+}
+
+// cropImage returns the centered square of src, masked to a circle when
+// mode is circle.
+func cropImage(src image.Image, mode string) image.Image {
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	origin := image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2)
+	rect := image.Rect(0, 0, side, side)
+
+	dst := image.NewRGBA(rect)
+	if mode == resume.ImageCropCircle {
+		draw.DrawMask(dst, rect, src, origin, circleMask{side: side}, image.Point{}, draw.Over)
+	} else {
+		draw.Draw(dst, rect, src, origin, draw.Src)
+	}
+	return dst
+}
+
+// circleMask is an alpha mask for a circle inscribed in a side x side square.
+type circleMask struct {
+	side int
+}
+
+func (m circleMask) ColorModel() color.Model { return color.AlphaModel }
+
+func (m circleMask) Bounds() image.Rectangle { return image.Rect(0, 0, m.side, m.side) }
+
+func (m circleMask) At(x, y int) color.Color {
+	r := float64(m.side) / 2
+	dx, dy := float64(x)+0.5-r, float64(y)+0.5-r
+	if dx*dx+dy*dy <= r*r {
+		return color.Alpha{A: 255}
+	}
+	return color.Alpha{}
+}
+
+// dataURI encodes a prepared image as a base64 data URI.
+func (p *preparedImage) dataURI() string {
+	return "data:" + p.MIME + ";base64," + base64.StdEncoding.EncodeToString(p.Data)
+}
@@ -0,0 +1,192 @@
+package generators
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+// writeTestPNG writes a solid w x h PNG into dir and returns its path.
+func writeTestPNG(t *testing.T, dir, name string, w, h int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode test PNG: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write test PNG: %v", err)
+	}
+	return path
+}
+
+func TestPrepareImage(t *testing.T) {
+	path := writeTestPNG(t, t.TempDir(), "photo.png", 200, 100)
+
+	tests := []struct {
+		name       string
+		img        resume.Image
+		wantWidth  float64
+		wantHeight float64
+		wantPixels int // cropped output width in pixels, 0 to skip
+	}{
+		{"default width keeps aspect", resume.Image{Path: path}, 30, 15, 0},
+		{"explicit height derives width", resume.Image{Path: path, Height: 20}, 40, 20, 0},
+		{"explicit size wins", resume.Image{Path: path, Width: 10, Height: 10}, 10, 10, 0},
+		{"square crop", resume.Image{Path: path, Crop: "square"}, 30, 30, 100},
+		{"circle crop", resume.Image{Path: path, Crop: "circle", Width: 25}, 25, 25, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prepareImage(&tt.img, 30)
+			if err != nil {
+				t.Fatalf("prepareImage() error = %v", err)
+			}
+			if math.Abs(got.WidthMM-tt.wantWidth) > 0.01 || math.Abs(got.HeightMM-tt.wantHeight) > 0.01 {
+				t.Errorf("size = %.2fx%.2f, want %.2fx%.2f", got.WidthMM, got.HeightMM, tt.wantWidth, tt.wantHeight)
+			}
+			if tt.wantPixels > 0 {
+				decoded, _, err := image.Decode(bytes.NewReader(got.Data))
+				if err != nil {
+					t.Fatalf("cropped image does not decode: %v", err)
+				}
+				if b := decoded.Bounds(); b.Dx() != tt.wantPixels || b.Dy() != tt.wantPixels {
+					t.Errorf("cropped to %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantPixels, tt.wantPixels)
+				}
+				if tt.img.Crop == "circle" {
+					if _, _, _, a := decoded.At(0, 0).RGBA(); a != 0 {
+						t.Error("circle crop should leave corners transparent")
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := prepareImage(&resume.Image{Path: filepath.Join(t.TempDir(), "missing.png")}, 30); err == nil {
+		t.Error("expected an error for a missing image")
+	}
+}
+
+func TestWriteImageAssets(t *testing.T) {
+	path := writeTestPNG(t, t.TempDir(), "logo.png", 40, 40)
+	r := &resume.Resume{
+		Contact: resume.Contact{Name: "Jane", Photo: &resume.Image{Path: path, Alt: "Jane", Crop: "circle"}},
+	}
+
+	dir := t.TempDir()
+	if err := WriteImageAssets(r, dir); err != nil {
+		t.Fatalf("WriteImageAssets() error = %v", err)
+	}
+	name := ImageAssetName(r.Contact.Photo)
+	if !strings.HasSuffix(name, ".png") {
+		t.Errorf("ImageAssetName() = %q, want a .png name for circle crops", name)
+	}
+	if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+		t.Errorf("expected %s to be written: %v", name, err)
+	}
+}
+
+func TestGenerateWithImages(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	dir := t.TempDir()
+	photo := &resume.Image{Path: writeTestPNG(t, dir, "photo.png", 60, 80), Alt: "Portrait of Jane", Crop: "square"}
+	logo := &resume.Image{Path: writeTestPNG(t, dir, "logo.png", 32, 32), Alt: "Acme logo"}
+	thumb := &resume.Image{Path: writeTestPNG(t, dir, "thumb.png", 160, 90), Alt: "Tool screenshot"}
+
+	r := &resume.Resume{
+		Contact: resume.Contact{Name: "Jane Doe", Email: "jane@example.com", Photo: photo},
+		Experience: resume.ExperienceList{Positions: []resume.Experience{
+			{Title: "Engineer", Company: "Acme", Logo: logo},
+		}},
+		Projects: &resume.ProjectList{Projects: []resume.Project{
+			{Name: "Tool", Thumbnail: thumb},
+		}},
+	}
+
+	gen := NewGenerator(zap.NewNop().Sugar())
+
+	t.Run("modern-html", func(t *testing.T) {
+		tmpl, err := LoadTemplate("modern-html")
+		if err != nil {
+			t.Fatalf("LoadTemplate() error: %v", err)
+		}
+		got, err := gen.GenerateWithTemplate(tmpl, r)
+		if err != nil {
+			t.Fatalf("GenerateWithTemplate() error: %v", err)
+		}
+		if n := strings.Count(got, `src="data:image/png;base64,`); n != 3 {
+			t.Errorf("expected 3 embedded images, found %d", n)
+		}
+		for _, alt := range []string{"Portrait of Jane", "Acme logo", "Tool screenshot"} {
+			if !strings.Contains(got, `alt="`+alt+`"`) {
+				t.Errorf("missing alt text %q", alt)
+			}
+		}
+	})
+
+	t.Run("modern-latex", func(t *testing.T) {
+		tmpl, err := LoadTemplate("modern-latex")
+		if err != nil {
+			t.Fatalf("LoadTemplate() error: %v", err)
+		}
+		got, err := gen.GenerateWithTemplate(tmpl, r)
+		if err != nil {
+			t.Fatalf("GenerateWithTemplate() error: %v", err)
+		}
+		for _, img := range []*resume.Image{photo, logo, thumb} {
+			if !strings.Contains(got, "{"+ImageAssetName(img)+"}") {
+				t.Errorf("expected \\includegraphics of %s", ImageAssetName(img))
+			}
+		}
+	})
+
+	t.Run("modern-docx", func(t *testing.T) {
+		docxBytes, err := gen.GenerateDOCX(r)
+		if err != nil {
+			t.Fatalf("GenerateDOCX() error: %v", err)
+		}
+		xml, err := extractDocumentXML(docxBytes)
+		if err != nil {
+			t.Fatalf("failed to extract document.xml: %v", err)
+		}
+		if n := strings.Count(string(xml), "<wp:inline"); n != 3 {
+			t.Errorf("expected 3 inline pictures, found %d", n)
+		}
+		if !strings.Contains(string(xml), `name="Portrait of Jane"`) {
+			t.Error("expected the photo alt text on the drawing")
+		}
+	})
+
+	t.Run("missing image fails", func(t *testing.T) {
+		broken := *r
+		broken.Contact.Photo = &resume.Image{Path: filepath.Join(dir, "missing.png"), Alt: "Missing"}
+		tmpl, err := LoadTemplate("modern-html")
+		if err != nil {
+			t.Fatalf("LoadTemplate() error: %v", err)
+		}
+		if _, err := gen.GenerateWithTemplate(tmpl, &broken); err == nil {
+			t.Error("expected an error for a missing image")
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package generators
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+// partialsDir is the directory of a template whose files are parsed into
+// the template set before its main file. Templates that extend it inherit
+// them.
+const partialsDir = "partials"
+
+// templateLayer is one file of a template set.
+type templateLayer struct {
+	name    string
+	content string
+	// partial layers are parsed under their own name; main layers are
+	// parsed as the root template.
+	partial bool
+}
+
+// files returns the template's own directory, on disk or in the embedded FS.
+func (t *Template) files() (fs.FS, error) {
+	if t.Embedded {
+		return fs.Sub(embeddedFS, t.EmbeddedDir)
+	}
+	return os.DirFS(t.dir), nil
+}
+
+// Chain returns the template and the templates it extends, base last.
+func (t *Template) Chain() []*Template {
+	var chain []*Template
+	for c := t; c != nil; c = c.Parent {
+		chain = append(chain, c)
+	}
+	return chain
+}
+
+// templateLayers returns the files a template set is assembled from: for
+// each template of the inheritance chain, base first, its partials then
+// its main file. A {{define}} block in a later file replaces the block of
+// the same name from an earlier one, and a main file holding only
+// {{define}} blocks keeps the inherited body. A derived template without
+// a main file of its own inherits its parent's as is.
+func templateLayers(t *Template) ([]templateLayer, error) {
+	var layers []templateLayer
+	if t.Parent != nil {
+		inherited, err := templateLayers(t.Parent)
+		if err != nil {
+			return nil, err
+		}
+		layers = inherited
+	}
+
+	files, err := t.files()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(files, partialsDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read partials of template %s: %w", t.Name, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(files, partialsDir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, templateLayer{name: t.Name + "/" + partialsDir + "/" + entry.Name(), content: string(data), partial: true})
+	}
+
+	filename := resolveTemplateFilename(t.Type, t.Config.TemplateFile)
+	data, err := fs.ReadFile(files, filename)
+	switch {
+	case err == nil:
+		layers = append(layers, templateLayer{name: t.Name + "/" + filename, content: string(data)})
+	case errors.Is(err, fs.ErrNotExist) && t.Parent != nil:
+	default:
+		return nil, fmt.Errorf("failed to read template %s: %w", t.Name, err)
+	}
+	return layers, nil
+}
+
+// letterLayer returns the cover letter file of the nearest template in the
+// inheritance chain that has one.
+func letterLayer(t *Template) (templateLayer, error) {
+	var tried []string
+	for _, c := range t.Chain() {
+		filename := resolveLetterFilename(c.Type, c.Config.LetterFile)
+		if filename == "" {
+			break
+		}
+		files, err := c.files()
+		if err != nil {
+			return templateLayer{}, err
+		}
+		data, err := fs.ReadFile(files, filename)
+		if err == nil {
+			return templateLayer{name: c.Name + "/" + filename, content: string(data)}, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return templateLayer{}, err
+		}
+		tried = append(tried, c.Name+"/"+filename)
+	}
+	return templateLayer{}, fmt.Errorf("template %s has no cover letter (looked for %s)", t.Name, strings.Join(tried, ", "))
+}
+
+// parseTextLayers parses the layers of a text/template set into root.
+func parseTextLayers(root *texttemplate.Template, layers []templateLayer) (*texttemplate.Template, error) {
+	for _, layer := range layers {
+		target := root
+		if layer.partial {
+			target = root.New(layer.name)
+		}
+		if _, err := target.Parse(layer.content); err != nil {
+			return nil, fmt.Errorf("%s: %w", layer.name, err)
+		}
+	}
+	return root, nil
+}
+
+// parseHTMLLayers parses the layers of an html/template set into root.
+func parseHTMLLayers(root *htmltemplate.Template, layers []templateLayer) (*htmltemplate.Template, error) {
+	for _, layer := range layers {
+		target := root
+		if layer.partial {
+			target = root.New(layer.name)
+		}
+		if _, err := target.Parse(layer.content); err != nil {
+			return nil, fmt.Errorf("%s: %w", layer.name, err)
+		}
+	}
+	return root, nil
+}
+
+// singleLayer wraps the content of a standalone template file.
+func singleLayer(content string) []templateLayer {
+	return []templateLayer{{name: "template", content: content}}
+}
+
+// ExtractTemplateFiles writes the support files of a template and of the
+// templates it extends, such as LaTeX class files, to a temporary
+// directory; a derived template's files replace inherited ones of the same
+// name. Returns the directory, which the caller removes.
+func ExtractTemplateFiles(t *Template) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "resume-template-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	chain := t.Chain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		files, err := chain[i].files()
+		if err == nil {
+			err = copyTemplateFiles(files, tmpDir, false)
+		}
+		if err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return "", fmt.Errorf("template %s: %w", chain[i].Name, err)
+		}
+	}
+	return tmpDir, nil
+}
+
+// copyTemplateFiles writes the files of a template directory to dest,
+// replacing files of the same name. Subdirectories such as partials/ are
+// only copied when recursive is set: compiling a rendered template needs
+// just the top-level support files, while an ejected copy needs them all.
+func copyTemplateFiles(files fs.FS, dest string, recursive bool) error {
+	return fs.WalkDir(files, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(path))
+		if entry.IsDir() {
+			if path != "." && !recursive {
+				return fs.SkipDir
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", target, err)
+			}
+			return nil
+		}
+		data, err := fs.ReadFile(files, path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", path, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,144 @@
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+// writeTemplate writes the files of a template under root/templates/name.
+func writeTemplate(t *testing.T, root, name string, files map[string]string) {
+	t.Helper()
+	for file, content := range files {
+		path := filepath.Join(root, "templates", name, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTemplateInheritance(t *testing.T) {
+	root := t.TempDir()
+	if err := os.CopyFS(filepath.Join(root, "templates", "modern-html"), os.DirFS(filepath.Join("..", "..", "templates", "modern-html"))); err != nil {
+		t.Fatal(err)
+	}
+	writeTemplate(t, root, "compact-html", map[string]string{
+		"config.yml": "name: compact-html\nextends: modern-html\n",
+		"template.html": `{{define "section-summary"}}<div class="section summary">{{template "summary-lead" .}}</div>{{end}}
+{{define "section-references"}}<!-- no references -->{{end}}`,
+		"partials/lead.html": `{{define "summary-lead"}}<p class="lead">{{.Summary}}</p>{{end}}`,
+	})
+	writeTemplate(t, root, "compact-html-extra", map[string]string{
+		"config.yml":         "name: compact-html-extra\nextends: compact-html\n",
+		"partials/lead.html": `{{define "summary-lead"}}<p class="extra">{{.Summary}}</p>{{end}}`,
+		"extra.sty":          "% support file",
+	})
+	t.Setenv("RESUME_TEMPLATES_DIR", root)
+
+	r := &resume.Resume{
+		Contact: resume.Contact{Name: "Jane Doe", Email: "jane@example.com"},
+		Summary: "Backend engineer.",
+		References: &resume.ReferenceList{
+			Referees: []resume.Referee{{Name: "Alex Smith"}},
+		},
+		Layout: &resume.Layout{ShowReferences: resume.ReferencesFull},
+	}
+	gen := NewGenerator(zap.NewNop().Sugar())
+
+	base, err := LoadTemplate("modern-html")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	got, err := gen.GenerateWithTemplate(base, r)
+	if err != nil || !strings.Contains(got, "Professional Summary") || !strings.Contains(got, "Alex Smith") {
+		t.Fatalf("modern-html does not render the blocks the test overrides (error %v)", err)
+	}
+
+	tmpl, err := LoadTemplate("compact-html")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	if tmpl.Type != TemplateTypeHTML || tmpl.Parent == nil || tmpl.Parent.Name != "modern-html" {
+		t.Fatalf("LoadTemplate() = %s extending %v", tmpl.Type, tmpl.Parent)
+	}
+	got, err = gen.GenerateWithTemplate(tmpl, r)
+	if err != nil {
+		t.Fatalf("GenerateWithTemplate() error = %v", err)
+	}
+	for _, want := range []string{`<p class="lead">Backend engineer.</p>`, `<div class="header">`, "Jane Doe"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("derived template output does not contain %q", want)
+		}
+	}
+	if strings.Contains(got, "Professional Summary") || strings.Contains(got, "Alex Smith") {
+		t.Error("derived template output still renders the overridden blocks")
+	}
+
+	extra, err := LoadTemplate("compact-html-extra")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	got, err = gen.GenerateWithTemplate(extra, r)
+	if err != nil {
+		t.Fatalf("GenerateWithTemplate() error = %v", err)
+	}
+	if !strings.Contains(got, `<p class="extra">Backend engineer.</p>`) {
+		t.Error("partial of the derived template does not replace the inherited one")
+	}
+
+	if !extra.SupportsCoverLetter() {
+		t.Error("SupportsCoverLetter() = false, want the letter of modern-html")
+	}
+	r, letter := testCoverLetter()
+	if got, err = gen.GenerateCoverLetter(extra, r, letter); err != nil || !strings.Contains(got, "Dear Alex Smith,") {
+		t.Errorf("GenerateCoverLetter() error = %v", err)
+	}
+
+	dir, err := ExtractTemplateFiles(extra)
+	if err != nil {
+		t.Fatalf("ExtractTemplateFiles() error = %v", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	for _, file := range []string{"letter.html", "extra.sty"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("ExtractTemplateFiles() did not write %s", file)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "template.html")); !strings.Contains(string(data), "summary-lead") {
+		t.Error("ExtractTemplateFiles() did not let the derived template.html replace the inherited one")
+	}
+}
+
+func TestTemplateInheritanceErrors(t *testing.T) {
+	root := t.TempDir()
+	writeTemplate(t, root, "loop-a", map[string]string{"config.yml": "name: loop-a\nextends: loop-b\n"})
+	writeTemplate(t, root, "loop-b", map[string]string{"config.yml": "name: loop-b\nextends: loop-a\n"})
+	writeTemplate(t, root, "base-md", map[string]string{"config.yml": "name: base-md\nformat: markdown\n", "template.md": "# {{.Contact.Name}}"})
+	writeTemplate(t, root, "wrong-format", map[string]string{"config.yml": "name: wrong-format\nformat: html\nextends: base-md\n"})
+	writeTemplate(t, root, "orphan", map[string]string{"config.yml": "name: orphan\nextends: missing-template\n"})
+	// extends names a template directory, which need not match name:.
+	writeTemplate(t, root, "self", map[string]string{"config.yml": "name: Alpha\nextends: self\n"})
+	writeTemplate(t, root, "mutual-a", map[string]string{"config.yml": "name: Beta\nextends: mutual-b\n"})
+	writeTemplate(t, root, "mutual-b", map[string]string{"config.yml": "name: Gamma\nextends: mutual-a\n"})
+	t.Setenv("RESUME_TEMPLATES_DIR", root)
+
+	tests := map[string]string{
+		"loop-a":       "loop-a -> loop-b -> loop-a",
+		"wrong-format": "extends base-md, which is markdown",
+		"orphan":       "extends missing-template",
+		"self":         "self -> self",
+		"mutual-a":     "mutual-a -> mutual-b -> mutual-a",
+	}
+	for name, want := range tests {
+		if _, err := LoadTemplate(name); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadTemplate(%s) error = %v, want %q", name, err, want)
+		}
+	}
+}
@@ -25,11 +25,14 @@ func NewLaTeXGenerator(logger *zap.SugaredLogger) *LaTeXGenerator {
 
 // Generate renders a LaTeX template with resume data using the formatter's helper functions.
 func (g *LaTeXGenerator) Generate(templateContent string, r *resume.Resume) (string, error) {
-	g.logger.Info("Rendering LaTeX template")
+	return g.generate(singleLayer(templateContent), r)
+}
 
-	funcs := g.formatter.TemplateFuncs()
+// generate renders the template set assembled from layers.
+func (g *LaTeXGenerator) generate(layers []templateLayer, r *resume.Resume) (string, error) {
+	g.logger.Info("Rendering LaTeX template")
 
-	tmpl, err := template.New("latex").Funcs(funcs).Parse(templateContent)
+	tmpl, err := parseTextLayers(template.New("latex").Funcs(g.formatter.TemplateFuncs()), layers)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse LaTeX template: %w", err)
 	}
@@ -42,3 +45,58 @@ func (g *LaTeXGenerator) Generate(templateContent string, r *resume.Resume) (str
 	g.logger.Info("Successfully rendered LaTeX template")
 	return output.String(), nil
 }
+
+// GenerateLetter renders a LaTeX cover letter. The resume template is parsed
+// first so the letter can call its named templates, such as "latex-header".
+func (g *LaTeXGenerator) GenerateLetter(templateContent, letterContent string, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	return g.generateLetter(singleLayer(templateContent), templateLayer{name: "letter", content: letterContent}, r, letter)
+}
+
+// generateLetter renders the letter on top of the template set assembled
+// from layers.
+func (g *LaTeXGenerator) generateLetter(layers []templateLayer, letterFile templateLayer, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	g.logger.Info("Rendering LaTeX cover letter")
+
+	base, err := parseTextLayers(template.New("latex").Funcs(g.formatter.TemplateFuncs()), layers)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse LaTeX template: %w", err)
+	}
+	tmpl, err := base.New("letter").Parse(letterFile.content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse LaTeX cover letter: %w", err)
+	}
+
+	var output strings.Builder
+	if err := tmpl.Execute(&output, letterPayload{Resume: r, Letter: letter}); err != nil {
+		return "", fmt.Errorf("failed to execute LaTeX cover letter: %w", err)
+	}
+
+	g.logger.Info("Successfully rendered LaTeX cover letter")
+	return output.String(), nil
+}
+
+// latexParams returns a copy of the resume whose string params are escaped
+// for LaTeX, so values such as R&D or 100% print as written. Lengths in rem,
+// which TeX does not know, are rejected.
+func latexParams(tmpl *Template, r *resume.Resume) (*resume.Resume, error) {
+	if len(r.Params) == 0 {
+		return r, nil
+	}
+	escape := newLaTeXFormatter().EscapeText
+	params := make(map[string]any, len(r.Params))
+	for name, value := range r.Params {
+		p, _ := tmpl.Param(name)
+		switch s, _ := value.(string); p.Type {
+		case ParamString:
+			value = escape(s)
+		case ParamLength:
+			if strings.HasSuffix(s, "rem") {
+				return nil, fmt.Errorf("param %s: %q is not a LaTeX length; use pt, mm, cm, in or em", name, s)
+			}
+		}
+		params[name] = value
+	}
+	out := *r
+	out.Params = params
+	return &out, nil
+}
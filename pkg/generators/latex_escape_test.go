@@ -47,7 +47,7 @@ func makeProfessionCases() []professionTestCase {
 				},
 				Skills: resume.Skills{
 					Categories: []resume.SkillCategory{
-						{Category: "Clinical & Technical", Items: []string{"ICU Monitoring", "Ventilator Management"}},
+						{Category: "Clinical & Technical", Items: resume.SkillItemsFromNames("ICU Monitoring", "Ventilator Management")},
 					},
 				},
 				Experience: resume.ExperienceList{
@@ -93,7 +93,7 @@ func makeProfessionCases() []professionTestCase {
 				},
 				Skills: resume.Skills{
 					Categories: []resume.SkillCategory{
-						{Category: "Financial Analysis & Modeling", Items: []string{"DCF Models", "M&A Valuation"}},
+						{Category: "Financial Analysis & Modeling", Items: resume.SkillItemsFromNames("DCF Models", "M&A Valuation")},
 					},
 				},
 				Experience: resume.ExperienceList{
@@ -145,8 +145,8 @@ func makeProfessionCases() []professionTestCase {
 				},
 				Skills: resume.Skills{
 					Categories: []resume.SkillCategory{
-						{Category: "Programming", Items: []string{"C++", "Python", "R"}},
-						{Category: "Research", Items: []string{"NLP", "ML^2 Framework"}},
+						{Category: "Programming", Items: resume.SkillItemsFromNames("C++", "Python", "R")},
+						{Category: "Research", Items: resume.SkillItemsFromNames("NLP", "ML^2 Framework")},
 					},
 				},
 				Experience: resume.ExperienceList{
@@ -214,7 +214,7 @@ func makeProfessionCases() []professionTestCase {
 				},
 				Skills: resume.Skills{
 					Categories: []resume.SkillCategory{
-						{Category: "Practice Areas", Items: []string{"Mergers & Acquisitions", "Securities & Exchange Compliance"}},
+						{Category: "Practice Areas", Items: resume.SkillItemsFromNames("Mergers & Acquisitions", "Securities & Exchange Compliance")},
 					},
 				},
 				Experience: resume.ExperienceList{
@@ -266,7 +266,7 @@ func makeProfessionCases() []professionTestCase {
 				},
 				Skills: resume.Skills{
 					Categories: []resume.SkillCategory{
-						{Category: "Languages & Frameworks", Items: []string{"C#", "F#", "ASP.NET"}},
+						{Category: "Languages & Frameworks", Items: resume.SkillItemsFromNames("C#", "F#", "ASP.NET")},
 					},
 				},
 				Experience: resume.ExperienceList{
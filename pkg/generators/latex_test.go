@@ -78,10 +78,10 @@ func TestLaTeXGeneratorGenerate(t *testing.T) {
 			Categories: []resume.SkillCategory{
 				{
 					Category: "Languages",
-					Items: []string{
+					Items: resume.SkillItemsFromNames(
 						"Go",
 						"Rust",
-					},
+					),
 				},
 			},
 		},
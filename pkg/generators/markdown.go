@@ -25,9 +25,14 @@ func NewMarkdownGenerator(logger *zap.SugaredLogger) *MarkdownGenerator {
 
 // Generate renders a Markdown template with resume data using the formatter's helper functions.
 func (g *MarkdownGenerator) Generate(templateContent string, r *resume.Resume) (string, error) {
+	return g.generate(singleLayer(templateContent), r)
+}
+
+// generate renders the template set assembled from layers.
+func (g *MarkdownGenerator) generate(layers []templateLayer, r *resume.Resume) (string, error) {
 	g.logger.Info("Rendering Markdown template")
 
-	tmpl, err := template.New("markdown").Funcs(g.formatter.TemplateFuncs()).Parse(templateContent)
+	tmpl, err := parseTextLayers(template.New("markdown").Funcs(g.formatter.TemplateFuncs()), layers)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse Markdown template: %w", err)
 	}
@@ -40,3 +45,32 @@ func (g *MarkdownGenerator) Generate(templateContent string, r *resume.Resume) (
 	g.logger.Info("Successfully rendered Markdown template")
 	return output.String(), nil
 }
+
+// GenerateLetter renders a Markdown cover letter. The resume template is
+// parsed first so the letter can call its named templates, such as "header".
+func (g *MarkdownGenerator) GenerateLetter(templateContent, letterContent string, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	return g.generateLetter(singleLayer(templateContent), templateLayer{name: "letter", content: letterContent}, r, letter)
+}
+
+// generateLetter renders the letter on top of the template set assembled
+// from layers.
+func (g *MarkdownGenerator) generateLetter(layers []templateLayer, letterFile templateLayer, r *resume.Resume, letter *resume.CoverLetter) (string, error) {
+	g.logger.Info("Rendering Markdown cover letter")
+
+	base, err := parseTextLayers(template.New("markdown").Funcs(g.formatter.TemplateFuncs()), layers)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Markdown template: %w", err)
+	}
+	tmpl, err := base.New("letter").Parse(letterFile.content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Markdown cover letter: %w", err)
+	}
+
+	var output strings.Builder
+	if err := tmpl.Execute(&output, letterPayload{Resume: r, Letter: letter}); err != nil {
+		return "", fmt.Errorf("failed to execute Markdown cover letter: %w", err)
+	}
+
+	g.logger.Info("Successfully rendered Markdown cover letter")
+	return output.String(), nil
+}
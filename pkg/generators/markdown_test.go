@@ -113,7 +113,7 @@ func TestMarkdownGeneratorGenerate(t *testing.T) {
 			Categories: []resume.SkillCategory{
 				{
 					Category: "Languages",
-					Items:    []string{"Go", "Rust", "Python"},
+					Items:    resume.SkillItemsFromNames("Go", "Rust", "Python"),
 				},
 			},
 		},
@@ -200,3 +200,44 @@ func TestMarkdownGeneratorGenerate(t *testing.T) {
 		}
 	}
 }
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	inputData, err := resume.LoadResumeFromFile(filepath.Join("testdata", "input", "minimal.yml"))
+	if err != nil {
+		t.Fatalf("failed to load input: %v", err)
+	}
+	original := inputData.ToResume()
+	resume.Canonicalize(original)
+
+	tmpl, err := LoadTemplate("modern-markdown")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error: %v", err)
+	}
+	content, err := NewGenerator(zap.NewNop().Sugar()).GenerateWithTemplate(tmpl, original)
+	if err != nil {
+		t.Fatalf("GenerateWithTemplate() error: %v", err)
+	}
+
+	parsed, err := resume.LoadResumeFromBytes([]byte(content), "md")
+	if err != nil {
+		t.Fatalf("failed to parse generated Markdown: %v", err)
+	}
+	roundTripped := parsed.ToResume()
+	resume.Canonicalize(roundTripped)
+
+	diffs, err := resume.FieldDifferences(original, roundTripped)
+	if err != nil {
+		t.Fatalf("FieldDifferences() error: %v", err)
+	}
+	for _, d := range diffs {
+		if strings.HasPrefix(d, "contact") {
+			t.Errorf("contact details should survive a Markdown round trip, %s changed", d)
+		}
+	}
+}
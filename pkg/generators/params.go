@@ -0,0 +1,199 @@
+package generators
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// ParamType is the type of a template parameter.
+type ParamType string
+
+const (
+	ParamString ParamType = "string"
+	// ParamColor is a hex color such as #0a66c2.
+	ParamColor  ParamType = "color"
+	ParamNumber ParamType = "number"
+	// ParamLength is a number with a unit, such as 0.5in or 10.5pt.
+	ParamLength ParamType = "length"
+	ParamBool   ParamType = "bool"
+)
+
+// TemplateParam is a parameter a template declares in config.yml. A param
+// with no default is unset unless the user gives it a value, and templates
+// test for it with {{with .Params.name}}.
+type TemplateParam struct {
+	Name        string    `yaml:"name"`
+	Type        ParamType `yaml:"type"`
+	Default     string    `yaml:"default,omitempty"`
+	Description string    `yaml:"description,omitempty"`
+}
+
+var (
+	paramName   = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	colorValue  = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	lengthValue = regexp.MustCompile(`^\d+(\.\d+)?(pt|px|mm|cm|in|em|rem)$`)
+	// stringValue keeps values from closing the CSS, LaTeX or HTML context
+	// they are written into.
+	stringValue = regexp.MustCompile(`^[^{};<>\\"]*$`)
+)
+
+// Parse converts a value given for the param to its type: string for
+// string, color and length params, float64 for numbers and bool for bools.
+func (p TemplateParam) Parse(value string) (any, error) {
+	value = strings.TrimSpace(value)
+	switch p.Type {
+	case ParamString:
+		if !stringValue.MatchString(value) {
+			return nil, fmt.Errorf("%s: %q contains one of { } ; < > \\ \"", p.Name, value)
+		}
+		return value, nil
+	case ParamColor:
+		if !colorValue.MatchString(value) {
+			return nil, fmt.Errorf("%s: %q is not a hex color such as #0a66c2", p.Name, value)
+		}
+		return strings.ToLower(value), nil
+	case ParamNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a number", p.Name, value)
+		}
+		return n, nil
+	case ParamLength:
+		if !lengthValue.MatchString(value) {
+			return nil, fmt.Errorf("%s: %q is not a length such as 0.5in or 10pt", p.Name, value)
+		}
+		return value, nil
+	case ParamBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not true or false", p.Name, value)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown type %q", p.Name, p.Type)
+	}
+}
+
+// validateParams checks the names, types and defaults of declared params.
+func validateParams(params []TemplateParam) error {
+	seen := map[string]bool{}
+	for _, p := range params {
+		if !paramName.MatchString(p.Name) {
+			return fmt.Errorf("param name %q must be lowercase letters, digits and underscores", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("param %s is declared twice", p.Name)
+		}
+		seen[p.Name] = true
+		switch p.Type {
+		case ParamString, ParamColor, ParamNumber, ParamLength, ParamBool:
+		default:
+			return fmt.Errorf("param %s has unknown type %q (want string, color, number, length or bool)", p.Name, p.Type)
+		}
+		if p.Default != "" {
+			if _, err := p.Parse(p.Default); err != nil {
+				return fmt.Errorf("default of param %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// inheritParams returns the parent's params with those of the derived
+// template replacing the ones of the same name, followed by its new ones.
+func inheritParams(parent, own []TemplateParam) []TemplateParam {
+	index := map[string]int{}
+	out := append([]TemplateParam(nil), parent...)
+	for i, p := range out {
+		index[p.Name] = i
+	}
+	for _, p := range own {
+		if i, ok := index[p.Name]; ok {
+			out[i] = p
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Param returns the declared param of the given name.
+func (t *Template) Param(name string) (TemplateParam, bool) {
+	for _, p := range t.Config.Params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return TemplateParam{}, false
+}
+
+// ResolveParams returns the values templates receive as .Params: each
+// declared param's default, replaced by the resume's layout.params, then by
+// overrides. Values for params the template does not declare are ignored,
+// so one resume can carry the params of several templates.
+func (t *Template) ResolveParams(layout map[string]any, overrides map[string]string) (map[string]any, error) {
+	values := map[string]any{}
+	for _, p := range t.Config.Params {
+		if p.Default == "" {
+			continue
+		}
+		v, err := p.Parse(p.Default)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Name, err)
+		}
+		values[p.Name] = v
+	}
+	for _, name := range sortedKeys(layout) {
+		p, ok := t.Param(name)
+		if !ok {
+			continue
+		}
+		v, err := p.Parse(fmt.Sprint(layout[name]))
+		if err != nil {
+			return nil, fmt.Errorf("layout.params.%w", err)
+		}
+		values[name] = v
+	}
+	for _, name := range sortedKeys(overrides) {
+		p, ok := t.Param(name)
+		if !ok {
+			continue
+		}
+		v, err := p.Parse(overrides[name])
+		if err != nil {
+			return nil, fmt.Errorf("--param %w", err)
+		}
+		values[name] = v
+	}
+	return values, nil
+}
+
+// withParams returns a copy of the resume carrying the template's resolved
+// params.
+func (g *Generator) withParams(tmpl *Template, r *resume.Resume) (*resume.Resume, error) {
+	var layout map[string]any
+	if r.Layout != nil {
+		layout = r.Layout.Params
+	}
+	params, err := tmpl.ResolveParams(layout, g.params)
+	if err != nil {
+		return nil, err
+	}
+	out := *r
+	out.Params = params
+	return &out, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
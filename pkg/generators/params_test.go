@@ -0,0 +1,158 @@
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+func TestTemplateParams(t *testing.T) {
+	root := t.TempDir()
+	if err := os.CopyFS(filepath.Join(root, "templates", "modern-html"), os.DirFS(filepath.Join("..", "..", "templates", "modern-html"))); err != nil {
+		t.Fatal(err)
+	}
+	writeTemplate(t, root, "branded-html", map[string]string{
+		"config.yml": `name: branded-html
+extends: modern-html
+params:
+  - name: accent
+    type: color
+    default: "#0A66C2"
+  - name: tagline
+    type: string
+    description: Line under the name
+`,
+		"template.html": `{{define "section-summary"}}{{with .Params.tagline}}<p class="tagline">{{.}}</p>{{end}}{{end}}`,
+	})
+	t.Setenv("RESUME_TEMPLATES_DIR", root)
+
+	tmpl, err := LoadTemplate("branded-html")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	var names []string
+	for _, p := range tmpl.Config.Params {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, " "); got != "accent font_family font_size margin show_icons tagline" {
+		t.Errorf("inherited params = %s", got)
+	}
+
+	r := &resume.Resume{
+		Contact: resume.Contact{
+			Name:  "Jane Doe",
+			Links: []resume.Link{{URI: "https://github.com/janedoe"}},
+		},
+		Summary: "Backend engineer.",
+		Layout: &resume.Layout{Params: map[string]any{
+			"margin":     "0.5in",
+			"show_icons": false,
+			"tagline":    "Builds reliable systems",
+			"unknown":    "ignored",
+		}},
+	}
+	gen := NewGenerator(zap.NewNop().Sugar())
+	gen.SetParams(map[string]string{"font_family": "'Inter', sans-serif", "tagline": "Ships on time"})
+
+	got, err := gen.GenerateWithTemplate(tmpl, r)
+	if err != nil {
+		t.Fatalf("GenerateWithTemplate() error = %v", err)
+	}
+	for _, want := range []string{"--accent: #0a66c2;", "--page-margin: 0.5in;", "--body-font: 'Inter', sans-serif;", `<p class="tagline">Ships on time</p>`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	if strings.Contains(got, `<svg class="link-icon`) || strings.Contains(got, "ZgotmplZ") {
+		t.Error("output has link icons or a rejected CSS value")
+	}
+	if r.Params != nil {
+		t.Error("GenerateWithTemplate() modified the caller's resume")
+	}
+
+	gen.SetParams(map[string]string{"margin": "wide"})
+	if _, err := gen.GenerateWithTemplate(tmpl, r); err == nil || !strings.Contains(err.Error(), `--param margin: "wide" is not a length`) {
+		t.Errorf("GenerateWithTemplate() with an invalid --param error = %v", err)
+	}
+	gen.SetParams(nil)
+	r.Layout.Params["accent"] = "blue"
+	if _, err := gen.GenerateWithTemplate(tmpl, r); err == nil || !strings.Contains(err.Error(), "layout.params.accent") {
+		t.Errorf("GenerateWithTemplate() with an invalid layout param error = %v", err)
+	}
+}
+
+func TestLaTeXTemplateParams(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	r := &resume.Resume{
+		Contact: resume.Contact{
+			Name:  "Jane Doe",
+			Email: "jane@example.com",
+			Links: []resume.Link{{URI: "https://github.com/janedoe"}},
+		},
+	}
+	gen := NewGenerator(zap.NewNop().Sugar())
+	gen.SetParams(map[string]string{
+		"accent":      "#06c",
+		"font_family": "R&D Sans_100%",
+		"margin":      "0.5in",
+		"font_size":   "10.5pt",
+		"show_icons":  "false",
+	})
+
+	tests := map[string][]string{
+		"modern-latex": {`\definecolor{accent}{HTML}{0066CC}`, `\setmainfont{R\&D Sans\_100\%}`, `\setmargin{0.5in}`, `\setfontsize{10.5pt}`, `\href{https://github.com/janedoe}`},
+		"modern-cv":    {`\definecolor{accent}{HTML}{0066CC}`, `\setmainfont{R\&D Sans\_100\%}`, `\geometry{margin=0.5in}`, `\fontsize{10.5pt}`, `\href{https://github.com/janedoe}`},
+	}
+	for name, want := range tests {
+		tmpl, err := LoadTemplate(name)
+		if err != nil {
+			t.Fatalf("LoadTemplate(%s) error = %v", name, err)
+		}
+		got, err := gen.GenerateWithTemplate(tmpl, r)
+		if err != nil {
+			t.Fatalf("GenerateWithTemplate(%s) error = %v", name, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(got, w) {
+				t.Errorf("%s output does not contain %q", name, w)
+			}
+		}
+		if strings.Contains(got, `\faGithub`) {
+			t.Errorf("%s output has link icons with show_icons=false", name)
+		}
+	}
+
+	gen.SetParams(map[string]string{"margin": "2rem"})
+	tmpl, err := LoadTemplate("modern-latex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.GenerateWithTemplate(tmpl, r); err == nil || !strings.Contains(err.Error(), "not a LaTeX length") {
+		t.Errorf("GenerateWithTemplate() with a rem margin error = %v", err)
+	}
+}
+
+func TestParseTemplateConfigParams(t *testing.T) {
+	tests := map[string]string{
+		"params:\n  - name: Accent\n    type: color\n":                                  "lowercase",
+		"params:\n  - name: accent\n    type: colour\n":                                 "unknown type",
+		"params:\n  - name: accent\n    type: color\n    default: red\n":                "not a hex color",
+		"params:\n  - name: size\n    type: number\n  - name: size\n    type: number\n": "declared twice",
+		"params:\n  - name: note\n    type: string\n    default: \"a;b\"\n":             "contains",
+	}
+	for config, want := range tests {
+		_, err := parseTemplateConfig([]byte("format: html\n"+config), "test")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseTemplateConfig(%q) error = %v, want %q", config, err, want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package generators
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// redlineAuthor is recorded as the author of DOCX tracked changes.
+const redlineAuthor = "resume-generator"
+
+const redlineCSS = `<style>
+ins.redline { color: #116329; background: #dafbe1; text-decoration: underline; }
+del.redline { color: #a40e26; background: #ffebe9; text-decoration: line-through; }
+.redline-summary { border: 1px solid #d0d7de; padding: 0.5em 1em; margin-bottom: 1em; font-size: 0.85em; }
+.redline-summary h2 { font-size: 1em; margin: 0 0 0.4em; }
+.redline-summary ul { margin: 0; padding-left: 1.2em; }
+</style>
+`
+
+// redlineTags maps each redline marker to its HTML markup.
+var redlineTags = map[string]string{
+	resume.RedlineDeleteStart: `<del class="redline">`,
+	resume.RedlineDeleteEnd:   `</del>`,
+	resume.RedlineInsertStart: `<ins class="redline">`,
+	resume.RedlineInsertEnd:   `</ins>`,
+}
+
+var redlineMarkers = strings.NewReplacer(
+	resume.RedlineDeleteStart, "",
+	resume.RedlineDeleteEnd, "",
+	resume.RedlineInsertStart, "",
+	resume.RedlineInsertEnd, "",
+)
+
+// GenerateRedlineHTML renders new with an HTML template, marking text added
+// since old as inserted and text removed from old as struck through. A list
+// of every change, including ones that have no inline text such as date
+// edits, precedes the resume.
+func (g *Generator) GenerateRedlineHTML(tmpl *Template, old, new *resume.Resume) (string, error) {
+	if tmpl.Type != TemplateTypeHTML {
+		return "", fmt.Errorf("redline output requires an HTML template, %s is %s", tmpl.Name, tmpl.Type)
+	}
+	content, err := g.GenerateWithTemplate(tmpl, resume.Redline(old, new))
+	if err != nil {
+		return "", err
+	}
+	return redlineHTML(content, resume.DiffResumes(old, new)), nil
+}
+
+// redlineHTML turns redline markers in rendered HTML into <ins> and <del>
+// elements, then adds the redline styles and the change summary. Markers
+// inside the head or inside tags (attribute values) are dropped, since markup
+// is not allowed there.
+func redlineHTML(content string, changes []resume.Change) string {
+	bodyStart := strings.Index(content, "<body")
+	if bodyStart < 0 {
+		bodyStart = 0
+	}
+	head := redlineMarkers.Replace(content[:bodyStart])
+	body := content[bodyStart:]
+
+	var out strings.Builder
+	inTag := false
+	for _, r := range body {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		}
+		if tag, ok := redlineTags[string(r)]; ok {
+			if !inTag {
+				out.WriteString(tag)
+			}
+			continue
+		}
+		out.WriteRune(r)
+	}
+	body = out.String()
+
+	if i := strings.Index(head, "</head>"); i >= 0 {
+		head = head[:i] + redlineCSS + head[i:]
+	} else {
+		head += redlineCSS
+	}
+
+	if i := strings.Index(body, ">"); i >= 0 && strings.HasPrefix(body, "<body") {
+		body = body[:i+1] + "\n" + redlineSummaryHTML(changes) + body[i+1:]
+	} else {
+		body = redlineSummaryHTML(changes) + body
+	}
+	return head + body
+}
+
+func redlineSummaryHTML(changes []resume.Change) string {
+	var b strings.Builder
+	b.WriteString(`<section class="redline-summary"><h2>Changes</h2>`)
+	if len(changes) == 0 {
+		b.WriteString("<p>No changes.</p>")
+	} else {
+		b.WriteString("<ul>")
+		for _, c := range changes {
+			b.WriteString("<li>")
+			b.WriteString(html.EscapeString(c.String()))
+			b.WriteString("</li>")
+		}
+		b.WriteString("</ul>")
+	}
+	b.WriteString("</section>\n")
+	return b.String()
+}
+
+// GenerateRedlineDOCX renders new as a DOCX document whose differences from
+// old are Word tracked changes, so reviewers can accept or reject each one.
+func (g *Generator) GenerateRedlineDOCX(old, new *resume.Resume) ([]byte, error) {
+	docxBytes, err := g.GenerateDOCX(resume.Redline(old, new))
+	if err != nil {
+		return nil, err
+	}
+	return rewriteDocumentXML(docxBytes, func(documentXML []byte) []byte {
+		return trackChanges(documentXML, time.Now().UTC())
+	})
+}
+
+// reTextRun matches a DOCX run holding a single text element.
+var reTextRun = regexp.MustCompile(`<w:r>(<w:rPr>.*?</w:rPr>)?<w:t(?: [^>]*)?>([^<]*)</w:t></w:r>`)
+
+// trackChanges splits runs at redline markers and wraps the marked segments
+// in <w:ins> and <w:del> elements. The marker state carries across runs, so a
+// marked span may cover several runs.
+func trackChanges(documentXML []byte, date time.Time) []byte {
+	const (
+		modeNone = iota
+		modeInsert
+		modeDelete
+	)
+	mode := modeNone
+	nextID := 1
+	stamp := date.Format(time.RFC3339)
+
+	out := reTextRun.ReplaceAllFunc(documentXML, func(run []byte) []byte {
+		m := reTextRun.FindSubmatch(run)
+		rPr, text := string(m[1]), string(m[2])
+		if mode == modeNone && !strings.ContainsAny(text, resume.RedlineDeleteStart+resume.RedlineInsertStart) {
+			return run
+		}
+
+		var b strings.Builder
+		var segment strings.Builder
+		flush := func() {
+			if segment.Len() == 0 {
+				return
+			}
+			t := segment.String()
+			segment.Reset()
+			switch mode {
+			case modeInsert:
+				fmt.Fprintf(&b, `<w:ins w:id="%d" w:author="%s" w:date="%s"><w:r>%s<w:t xml:space="preserve">%s</w:t></w:r></w:ins>`, nextID, redlineAuthor, stamp, rPr, t)
+				nextID++
+			case modeDelete:
+				fmt.Fprintf(&b, `<w:del w:id="%d" w:author="%s" w:date="%s"><w:r>%s<w:delText xml:space="preserve">%s</w:delText></w:r></w:del>`, nextID, redlineAuthor, stamp, rPr, t)
+				nextID++
+			default:
+				fmt.Fprintf(&b, `<w:r>%s<w:t xml:space="preserve">%s</w:t></w:r>`, rPr, t)
+			}
+		}
+		for _, r := range text {
+			switch string(r) {
+			case resume.RedlineInsertStart:
+				flush()
+				mode = modeInsert
+			case resume.RedlineDeleteStart:
+				flush()
+				mode = modeDelete
+			case resume.RedlineInsertEnd, resume.RedlineDeleteEnd:
+				flush()
+				mode = modeNone
+			default:
+				segment.WriteRune(r)
+			}
+		}
+		flush()
+		return []byte(b.String())
+	})
+	// Markers outside plain text runs have no tracked-change equivalent.
+	return []byte(redlineMarkers.Replace(string(out)))
+}
+
+// rewriteDocumentXML returns a copy of a DOCX archive with word/document.xml
+// passed through rewrite.
+func rewriteDocumentXML(docxBytes []byte, rewrite func([]byte) []byte) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(docxBytes), int64(len(docxBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open docx: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		if f.Name == "word/document.xml" {
+			data = rewrite(data)
+		}
+
+		fw, err := w.CreateHeader(&zip.FileHeader{Name: f.Name, Method: f.Method, Modified: f.Modified})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+		if _, err := fw.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize docx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package generators
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+func redlineFixtures(t *testing.T) (*resume.Resume, *resume.Resume) {
+	t.Helper()
+	load := func() *resume.Resume {
+		data, err := resume.LoadResumeFromFile(filepath.Join("testdata", "input", "software_engineer.yml"))
+		if err != nil {
+			t.Fatalf("failed to load input: %v", err)
+		}
+		return data.ToResume()
+	}
+	old, new := load(), load()
+	new.Experience.Positions[0].Highlights[0] = "Rewrote the network security stack, cutting incidents by 50%."
+	old.Experience.Positions[0].Highlights[1] = "Automated key processes using Python scripts."
+	new.Experience.Positions[0].Highlights[1] = "Automated key processes using Go scripts."
+	new.Experience.Positions = append(new.Experience.Positions, resume.Experience{Company: "Initech", Title: "Staff Engineer", Dates: old.Experience.Positions[0].Dates})
+	return old, new
+}
+
+func TestGenerateRedlineHTML(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve project root: %v", err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	old, new := redlineFixtures(t)
+	tmpl, err := LoadTemplate("modern-html")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error: %v", err)
+	}
+	got, err := NewGenerator(zap.NewNop().Sugar()).GenerateRedlineHTML(tmpl, old, new)
+	if err != nil {
+		t.Fatalf("GenerateRedlineHTML() error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<del class="redline">Python</del> <ins class="redline">Go</ins>`,
+		`<ins class="redline">Staff Engineer</ins>`,
+		`<section class="redline-summary">`,
+		"ins.redline",
+		"Staff Engineer at Initech added",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("redline HTML missing %q", want)
+		}
+	}
+	if strings.ContainsAny(got, resume.RedlineDeleteStart+resume.RedlineDeleteEnd+resume.RedlineInsertStart+resume.RedlineInsertEnd) {
+		t.Error("redline markers left in HTML output")
+	}
+
+	markdown, err := LoadTemplate("modern-markdown")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error: %v", err)
+	}
+	if _, err := NewGenerator(zap.NewNop().Sugar()).GenerateRedlineHTML(markdown, old, new); err == nil {
+		t.Error("expected an error for a non-HTML template")
+	}
+}
+
+func TestGenerateRedlineDOCX(t *testing.T) {
+	old, new := redlineFixtures(t)
+	docxBytes, err := NewGenerator(zap.NewNop().Sugar()).GenerateRedlineDOCX(old, new)
+	if err != nil {
+		t.Fatalf("GenerateRedlineDOCX() error: %v", err)
+	}
+	xml, err := extractDocumentXML(docxBytes)
+	if err != nil {
+		t.Fatalf("failed to extract document.xml: %v", err)
+	}
+	doc := string(xml)
+
+	for _, want := range []string{
+		`<w:t xml:space="preserve">Go</w:t></w:r></w:ins>`,
+		`<w:delText xml:space="preserve">Python</w:delText>`,
+		`w:author="resume-generator"`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document.xml missing %q", want)
+		}
+	}
+	if strings.ContainsAny(doc, resume.RedlineDeleteStart+resume.RedlineInsertStart) {
+		t.Error("redline markers left in document.xml")
+	}
+}
@@ -0,0 +1,103 @@
+package generators
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/utils"
+)
+
+// starterFS holds the starter template of each text format, used by
+// NewTemplate.
+//
+//go:embed starters
+var starterFS embed.FS
+
+var templateName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// starterParams are the params the starter config of each format declares.
+var starterParams = map[TemplateType]string{
+	TemplateTypeHTML: `params:
+  - name: accent
+    type: color
+    default: "#000000"
+    description: Color of the name and section titles
+`,
+}
+
+// NewTemplate writes a working template named name to destDir/name: a
+// config.yml and a starter template of the given format with one {{define}}
+// block per section. Returns the template directory.
+func NewTemplate(name string, tmplType TemplateType, destDir string) (string, error) {
+	if !templateName.MatchString(name) {
+		return "", fmt.Errorf("template name %q must be lowercase letters, digits and dashes", name)
+	}
+	if tmplType == TemplateTypeDOCX {
+		return "", fmt.Errorf("docx templates are generated in code; choose html, latex or markdown")
+	}
+	filename := resolveTemplateFilename(tmplType, "")
+	starter, err := starterFS.ReadFile("starters/" + string(tmplType) + "/" + filename)
+	if err != nil {
+		return "", fmt.Errorf("no starter template for format %q", tmplType)
+	}
+
+	dir := filepath.Join(destDir, name)
+	if utils.DirExists(dir) {
+		return "", fmt.Errorf("%s already exists", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	config := fmt.Sprintf(`name: %s
+display_name: %s
+description: Starter %s template
+format: %s
+version: "0.1.0"
+# extends: modern-html   # inherit another template and override its blocks
+%s`, name, displayName(name), tmplType, tmplType, starterParams[tmplType])
+	files := map[string]string{"config.yml": config, filename: string(starter)}
+	for file, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return dir, nil
+}
+
+// displayName turns a template name such as my-theme into My Theme.
+func displayName(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// EjectTemplate copies every file of a template, including support files
+// such as LaTeX classes and the files of subdirectories like partials/, to
+// destDir/<name>, so that it can be customized; templates on disk take
+// precedence over the embedded ones of the same name. A derived template's
+// copy still extends its parent. Returns the template directory.
+func EjectTemplate(t *Template, destDir string) (string, error) {
+	dir := filepath.Join(destDir, t.Name)
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("%s already exists", dir)
+	}
+	files, err := t.files()
+	if err != nil {
+		return "", err
+	}
+	if err := copyTemplateFiles(files, dir, true); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to copy template %s: %w", t.Name, err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,133 @@
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"go.uber.org/zap"
+)
+
+func TestNewTemplate(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("RESUME_TEMPLATES_DIR", root)
+
+	start := time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
+	r := &resume.Resume{
+		Contact: resume.Contact{
+			Name:  "Jane Doe",
+			Email: "jane@example.com",
+			Links: []resume.Link{{URI: "https://github.com/janedoe"}},
+		},
+		Summary: "Backend engineer & mentor.",
+		Experience: resume.ExperienceList{Positions: []resume.Experience{{
+			Title:      "Software Engineer",
+			Company:    "Acme",
+			Highlights: []string{"Cut latency by 40%"},
+			Dates:      resume.DateRange{Start: start},
+		}}},
+		Skills: resume.Skills{Categories: []resume.SkillCategory{{
+			Category: "Languages",
+			Items:    []resume.SkillItem{{Name: "Go"}},
+		}}},
+	}
+	gen := NewGenerator(zap.NewNop().Sugar())
+
+	tests := []struct {
+		format TemplateType
+		want   []string
+	}{
+		{TemplateTypeHTML, []string{"<h1>Jane Doe</h1>", "color: #000000;", "Backend engineer &amp; mentor.", "<li>Cut latency by 40%</li>", "Go"}},
+		{TemplateTypeLaTeX, []string{`{\LARGE\bfseries Jane Doe}`, `Backend engineer \& mentor.`, `\item Cut latency by 40\%`, `\end{document}`}},
+		{TemplateTypeMarkdown, []string{"# Jane Doe", "## Summary", "### Software Engineer, Acme", "- Cut latency by 40%", "- **Languages:** Go"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			name := "my-" + string(tt.format)
+			dir, err := NewTemplate(name, tt.format, filepath.Join(root, "templates"))
+			if err != nil {
+				t.Fatalf("NewTemplate() error = %v", err)
+			}
+			tmpl, err := LoadTemplate(name)
+			if err != nil {
+				t.Fatalf("LoadTemplate() error = %v", err)
+			}
+			if tmpl.Type != tt.format || tmpl.DisplayName != "My "+strings.ToUpper(string(tt.format[:1]))+string(tt.format[1:]) {
+				t.Errorf("LoadTemplate() = %s %q", tmpl.Type, tmpl.DisplayName)
+			}
+			got, err := gen.GenerateWithTemplate(tmpl, r)
+			if err != nil {
+				t.Fatalf("GenerateWithTemplate() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output does not contain %q:\n%s", want, got)
+				}
+			}
+			if _, err := NewTemplate(name, tt.format, filepath.Dir(dir)); err == nil || !strings.Contains(err.Error(), "already exists") {
+				t.Errorf("NewTemplate() over an existing template error = %v", err)
+			}
+		})
+	}
+
+	for _, name := range []string{"My Theme", "-theme", ""} {
+		if _, err := NewTemplate(name, TemplateTypeHTML, root); err == nil {
+			t.Errorf("NewTemplate(%q) succeeded", name)
+		}
+	}
+	if _, err := NewTemplate("word", TemplateTypeDOCX, root); err == nil {
+		t.Error("NewTemplate() succeeded for docx")
+	}
+}
+
+func TestEjectTemplate(t *testing.T) {
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RESUME_TEMPLATES_DIR", projectRoot)
+
+	tmpl, err := LoadTemplate("modern-latex")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	dest := t.TempDir()
+
+	dir, err := EjectTemplate(tmpl, dest)
+	if err != nil {
+		t.Fatalf("EjectTemplate() error = %v", err)
+	}
+	for _, file := range []string{"config.yml", "template.tex", "letter.tex", "default.cls"} {
+		want, _ := os.ReadFile(filepath.Join(projectRoot, "templates", "modern-latex", file))
+		got, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil || string(got) != string(want) {
+			t.Errorf("EjectTemplate() did not copy %s (error %v)", file, err)
+		}
+	}
+	if _, err := EjectTemplate(tmpl, dest); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("EjectTemplate() over an existing directory error = %v", err)
+	}
+
+	// Subdirectories such as partials/ are copied too.
+	src := t.TempDir()
+	writeTemplate(t, src, "with-partials", map[string]string{
+		"config.yml":         "name: with-partials\nformat: markdown\n",
+		"template.md":        "{{template \"name\" .}}",
+		"partials/name.md":   "{{define \"name\"}}# {{.Contact.Name}}{{end}}",
+		"partials/deep/x.md": "{{define \"x\"}}{{end}}",
+	})
+	t.Setenv("RESUME_TEMPLATES_DIR", src)
+	tmpl, err = LoadTemplate("with-partials")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	if dir, err = EjectTemplate(tmpl, dest); err != nil {
+		t.Fatalf("EjectTemplate() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "partials", "deep", "x.md")); err != nil {
+		t.Errorf("EjectTemplate() did not copy subdirectories: %v", err)
+	}
+}
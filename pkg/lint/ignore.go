@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ignoreDirective marks a YAML comment that suppresses lint issues.
+const ignoreDirective = "lint:ignore"
+
+// Source maps the paths of a YAML resume to their lines and to the rules
+// suppressed there by "# lint:ignore" comments.
+//
+// A comment on a value, or on its own line above it, applies to that value
+// and everything nested in it:
+//
+//	highlights:
+//	  - Responsible for the build  # lint:ignore filler
+//	  # lint:ignore
+//	  - Helped with various things
+//
+// "# lint:ignore" with no rule names suppresses every rule. A comment on
+// the first line of a list item, as in "- company: Acme  # lint:ignore
+// tense", applies to the whole item.
+type Source struct {
+	lines   map[string]int
+	ignores map[string][]string
+}
+
+// ParseSource reads the comments of a YAML resume.
+func ParseSource(data []byte) (*Source, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	s := &Source{lines: map[string]int{}, ignores: map[string][]string{}}
+	s.walk(&root, "")
+	return s, nil
+}
+
+func (s *Source) walk(node *yaml.Node, path string) {
+	if node == nil {
+		return
+	}
+	if _, ok := s.lines[path]; !ok && node.Line > 0 {
+		s.lines[path] = node.Line
+	}
+	s.comment(path, node.HeadComment, node.LineComment)
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			s.walk(child, path)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+			s.lines[childPath] = key.Line
+			s.comment(childPath, key.HeadComment, key.LineComment)
+			if i == 0 && key.Line == node.Line {
+				// The line comment of a list item's first line belongs
+				// to the item.
+				s.comment(path, "", value.LineComment)
+			}
+			s.walk(value, childPath)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			s.walk(child, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (s *Source) comment(path string, comments ...string) {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+			rest, ok := strings.CutPrefix(line, ignoreDirective)
+			if !ok || rest != "" && rest[0] != ' ' {
+				continue
+			}
+			rules := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' })
+			if len(rules) == 0 {
+				rules = []string{"*"}
+			}
+			s.ignores[path] = append(s.ignores[path], rules...)
+		}
+	}
+}
+
+// ignored reports whether a comment on path or one of its parents
+// suppresses rule.
+func (s *Source) ignored(path, rule string) bool {
+	for p := path; ; {
+		for _, r := range s.ignores[p] {
+			if r == "*" || r == rule {
+				return true
+			}
+		}
+		if p == "" {
+			return false
+		}
+		i := strings.LastIndexAny(p, ".[")
+		if i < 0 {
+			i = 0
+		}
+		p = p[:i]
+	}
+}
+
+// Apply drops the issues suppressed by comments and sets their lines.
+func (s *Source) Apply(issues []Issue) []Issue {
+	var kept []Issue
+	for _, issue := range issues {
+		if s.ignored(issue.Path, issue.Rule) {
+			continue
+		}
+		issue.Line = s.lines[issue.Path]
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// UnknownRules returns the rule names in ignore comments that are not
+// rules, so typos do not silently suppress nothing.
+func (s *Source) UnknownRules() []string {
+	known := map[string]bool{"*": true}
+	for _, name := range ruleNames() {
+		known[name] = true
+	}
+	var unknown []string
+	seen := map[string]bool{}
+	for _, rules := range s.ignores {
+		for _, r := range rules {
+			if !known[r] && !seen[r] {
+				seen[r] = true
+				unknown = append(unknown, r)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,239 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how a rule's findings are reported. Off disables the rule.
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	Info    Severity = "info"
+	Off     Severity = "off"
+)
+
+// Rule names.
+const (
+	RuleActionVerb     = "action-verb"
+	RuleTense          = "tense"
+	RuleQuantification = "quantification"
+	RuleLength         = "length"
+	RuleDuplicate      = "duplicate"
+	RuleFiller         = "filler"
+	RulePassiveVoice   = "passive-voice"
+	RuleReadability    = "readability"
+)
+
+// Rule describes a check and its default severity.
+type Rule struct {
+	Name        string
+	Severity    Severity
+	Description string
+}
+
+// Rules lists every check in the order they run.
+var Rules = []Rule{
+	{RuleActionVerb, Warning, "bullets start with an action verb"},
+	{RuleTense, Warning, "past roles are written in the past tense and the current role in the present"},
+	{RuleQuantification, Info, "bullets contain a number, percentage or amount"},
+	{RuleLength, Warning, "bullets are at most max_words words"},
+	{RuleDuplicate, Warning, "no bullet repeats or nearly repeats another"},
+	{RuleFiller, Warning, "no filler phrases such as \"responsible for\""},
+	{RulePassiveVoice, Info, "bullets use the active voice"},
+	{RuleReadability, Info, "the summary and bullets read at or below grade max_grade"},
+}
+
+// Config sets the severity of each rule and the limits of the length and
+// readability rules.
+type Config struct {
+	Rules    map[string]Severity `yaml:"rules"`
+	MaxWords int                 `yaml:"max_words"`
+	MaxGrade float64             `yaml:"max_grade"`
+}
+
+// DefaultConfig returns every rule at its default severity.
+func DefaultConfig() Config {
+	cfg := Config{Rules: map[string]Severity{}, MaxWords: 30, MaxGrade: 16}
+	for _, rule := range Rules {
+		cfg.Rules[rule.Name] = rule.Severity
+	}
+	return cfg
+}
+
+// LoadConfig reads a YAML config file over the defaults:
+//
+//	rules:
+//	  quantification: warning
+//	  passive-voice: off
+//	max_words: 25
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	var file Config
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	for name, severity := range file.Rules {
+		if _, ok := cfg.Rules[name]; !ok {
+			return cfg, fmt.Errorf("%s: unknown rule %q (rules: %s)", path, name, strings.Join(ruleNames(), ", "))
+		}
+		switch severity {
+		case Error, Warning, Info, Off:
+		default:
+			return cfg, fmt.Errorf("%s: rule %s has unknown severity %q (supported: error, warning, info, off)", path, name, severity)
+		}
+		cfg.Rules[name] = severity
+	}
+	if file.MaxWords < 0 || file.MaxGrade < 0 {
+		return cfg, fmt.Errorf("%s: max_words and max_grade must not be negative", path)
+	}
+	if file.MaxWords > 0 {
+		cfg.MaxWords = file.MaxWords
+	}
+	if file.MaxGrade > 0 {
+		cfg.MaxGrade = file.MaxGrade
+	}
+	return cfg, nil
+}
+
+func ruleNames() []string {
+	names := make([]string, len(Rules))
+	for i, rule := range Rules {
+		names[i] = rule.Name
+	}
+	return names
+}
+
+// Issue is a finding of a rule. Path locates the text in the resume data,
+// as in "experience.positions[0].highlights[2]"; Line is its line in a
+// YAML source, or 0 when unknown.
+type Issue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", i.Severity, i.Rule, i.Path, i.Message)
+}
+
+// bullet is a highlight of a position or project.
+type bullet struct {
+	path  string
+	owner string
+	text  string
+	// current is set for the highlights of a position without an end
+	// date, or with a zero one; tense only applies to positions.
+	current  bool
+	position bool
+}
+
+func bullets(r *resume.Resume) []bullet {
+	var out []bullet
+	for i, p := range r.Experience.Positions {
+		owner := strings.TrimSpace(p.Title + " at " + p.Company)
+		for j, h := range p.Highlights {
+			out = append(out, bullet{
+				path:     fmt.Sprintf("experience.positions[%d].highlights[%d]", i, j),
+				owner:    owner,
+				text:     h,
+				current:  p.Dates.End == nil || p.Dates.End.IsZero(),
+				position: true,
+			})
+		}
+	}
+	if r.Projects != nil {
+		for i, p := range r.Projects.Projects {
+			for j, h := range p.Highlights {
+				out = append(out, bullet{
+					path:  fmt.Sprintf("projects.projects[%d].highlights[%d]", i, j),
+					owner: p.Name,
+					text:  h,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// Lint runs the enabled rules over the summary and every highlight of the
+// resume. Issues are ordered by path, then by rule.
+func Lint(r *resume.Resume, cfg Config) []Issue {
+	var issues []Issue
+	report := func(rule, path, format string, args ...any) {
+		severity := cfg.Rules[rule]
+		if severity == "" || severity == Off {
+			return
+		}
+		issues = append(issues, Issue{Rule: rule, Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	bs := bullets(r)
+	for _, b := range bs {
+		checkBullet(b, cfg, report)
+	}
+	checkDuplicates(bs, report)
+	if r.Summary != "" {
+		checkFiller("summary", r.Summary, report)
+		checkReadability("summary", r.Summary, cfg.MaxGrade, report)
+	}
+
+	order := map[string]int{}
+	for i, name := range ruleNames() {
+		order[name] = i
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Path != b.Path {
+			return pathLess(a.Path, b.Path)
+		}
+		return order[a.Rule] < order[b.Rule]
+	})
+	return issues
+}
+
+// pathLess orders the summary first, then by section and numeric indices.
+func pathLess(a, b string) bool {
+	rank := func(p string) int {
+		switch {
+		case p == "summary":
+			return 0
+		case strings.HasPrefix(p, "experience"):
+			return 1
+		}
+		return 2
+	}
+	if rank(a) != rank(b) {
+		return rank(a) < rank(b)
+	}
+	ai, bi := indices(a), indices(b)
+	for k := 0; k < len(ai) && k < len(bi); k++ {
+		if ai[k] != bi[k] {
+			return ai[k] < bi[k]
+		}
+	}
+	return len(ai) < len(bi)
+}
+
+func indices(path string) []int {
+	var out []int
+	for _, part := range strings.Split(path, "[")[1:] {
+		var n int
+		fmt.Sscanf(part, "%d]", &n)
+		out = append(out, n)
+	}
+	return out
+}
@@ -0,0 +1,183 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+const source = `summary: Results-driven engineer.
+experience:
+  positions:
+    - company: Acme
+      title: Staff Engineer
+      dates:
+        start: 2021-01-01
+      highlights:
+        - Led the migration of 40 services to Kubernetes
+        - Reduced build times by 60% with remote caching
+    - company: Globex  # lint:ignore tense
+      title: Engineer
+      dates:
+        start: 2017-01-01
+        end: 2020-12-31
+      highlights:
+        - Responsible for the billing service  # lint:ignore filler
+        - Builds the deploy pipeline for 12 teams
+        # lint:ignore
+        - The dashboard was adopted by every product team
+        - Led the migration of 40 services to Kubernetes
+`
+
+func testResume() *resume.Resume {
+	end := time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)
+	return &resume.Resume{
+		Summary: "Results-driven engineer.",
+		Experience: resume.ExperienceList{Positions: []resume.Experience{
+			{
+				Company: "Acme", Title: "Staff Engineer",
+				Dates: resume.DateRange{Start: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				Highlights: []string{
+					"Led the migration of 40 services to Kubernetes",
+					"Reduced build times by 60% with remote caching",
+				},
+			},
+			{
+				Company: "Globex", Title: "Engineer",
+				Dates: resume.DateRange{Start: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), End: &end},
+				Highlights: []string{
+					"Responsible for the billing service",
+					"Builds the deploy pipeline for 12 teams",
+					"The dashboard was adopted by every product team",
+					"Led the migration of 40 services to Kubernetes",
+				},
+			},
+		}},
+	}
+}
+
+func summarize(issues []Issue) []string {
+	var out []string
+	for _, i := range issues {
+		out = append(out, i.Rule+" "+i.Path)
+	}
+	return out
+}
+
+func TestLint(t *testing.T) {
+	issues := Lint(testResume(), DefaultConfig())
+	want := []string{
+		"filler summary",
+		"tense experience.positions[0].highlights[0]",
+		"tense experience.positions[0].highlights[1]",
+		"action-verb experience.positions[1].highlights[0]",
+		"quantification experience.positions[1].highlights[0]",
+		"filler experience.positions[1].highlights[0]",
+		"tense experience.positions[1].highlights[1]",
+		"action-verb experience.positions[1].highlights[2]",
+		"quantification experience.positions[1].highlights[2]",
+		"passive-voice experience.positions[1].highlights[2]",
+		"duplicate experience.positions[1].highlights[3]",
+	}
+	if got := summarize(issues); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, i := range issues {
+		if i.Rule == RulePassiveVoice && !strings.Contains(i.Message, `"was adopted"`) {
+			t.Errorf("passive voice message = %q", i.Message)
+		}
+		if i.Rule == RuleDuplicate && !strings.Contains(i.Message, "repeats experience.positions[0].highlights[0]") {
+			t.Errorf("duplicate message = %q", i.Message)
+		}
+	}
+}
+
+func TestLintZeroEndIsCurrent(t *testing.T) {
+	r := testResume()
+	r.Experience.Positions[0].Dates.End = &time.Time{}
+	got := summarize(Lint(r, DefaultConfig()))
+	for _, want := range []string{"tense experience.positions[0].highlights[0]", "tense experience.positions[0].highlights[1]"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("Lint() with a zero end date does not report %s:\n%s", want, strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".resumelint.yml")
+	if err := os.WriteFile(path, []byte("rules:\n  tense: off\n  duplicate: error\nmax_words: 5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	issues := Lint(testResume(), cfg)
+	var lengths int
+	for _, i := range issues {
+		switch i.Rule {
+		case RuleTense:
+			t.Errorf("disabled rule reported: %s", i)
+		case RuleDuplicate:
+			if i.Severity != Error {
+				t.Errorf("duplicate severity = %s, want error", i.Severity)
+			}
+		case RuleLength:
+			lengths++
+		}
+	}
+	if lengths != 5 {
+		t.Errorf("%d length issues, want one per bullet over 5 words", lengths)
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n  tence: off\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), `unknown rule "tence"`) {
+		t.Errorf("LoadConfig() error = %v, want unknown rule", err)
+	}
+}
+
+func TestSourceIgnores(t *testing.T) {
+	src, err := ParseSource([]byte(source))
+	if err != nil {
+		t.Fatalf("ParseSource() error = %v", err)
+	}
+	issues := src.Apply(Lint(testResume(), DefaultConfig()))
+	want := []string{
+		"filler summary",
+		"tense experience.positions[0].highlights[0]",
+		"tense experience.positions[0].highlights[1]",
+		"action-verb experience.positions[1].highlights[0]",
+		"quantification experience.positions[1].highlights[0]",
+		"duplicate experience.positions[1].highlights[3]",
+	}
+	if got := summarize(issues); !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if issues[0].Line != 1 || issues[len(issues)-1].Line != 21 {
+		t.Errorf("lines = %d, %d; want 1, 21", issues[0].Line, issues[len(issues)-1].Line)
+	}
+
+	src, err = ParseSource([]byte("summary: x  # lint:ignore filer\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := src.UnknownRules(); !reflect.DeepEqual(got, []string{"filer"}) {
+		t.Errorf("UnknownRules() = %q", got)
+	}
+}
+
+func TestGrade(t *testing.T) {
+	simple := grade("Led the team. Cut the costs.")
+	dense := grade("Operationalized organizational interoperability initiatives, institutionalizing comprehensive infrastructural modernization methodologies.")
+	if simple > 4 || dense < 14 {
+		t.Errorf("grade() = %.1f and %.1f, want a simple and a dense reading level", simple, dense)
+	}
+}
@@ -0,0 +1,192 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+type reporter func(rule, path, format string, args ...any)
+
+// duplicateSimilarity is the share of distinct words two bullets must have
+// in common to count as near-duplicates.
+const duplicateSimilarity = 0.8
+
+// words splits text into words, keeping apostrophes, hyphens and the
+// characters of numbers such as "3.5", "40%" and "$2M".
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("'’-.%$", r))
+	})
+}
+
+func cleanWord(w string) string {
+	return strings.ToLower(strings.Trim(w, "'’-."))
+}
+
+func checkBullet(b bullet, cfg Config, report reporter) {
+	ws := words(b.text)
+	if len(ws) == 0 {
+		return
+	}
+	first := cleanWord(ws[0])
+	tense := verbTense(first)
+
+	if tense == "" {
+		report(RuleActionVerb, b.path, "starts with %q rather than an action verb such as \"Led\" or \"Built\"", ws[0])
+	}
+	if b.position {
+		switch {
+		case b.current && tense == "past":
+			report(RuleTense, b.path, "current role %s is written in the past tense (%q)", b.owner, ws[0])
+		case !b.current && tense == "present":
+			report(RuleTense, b.path, "past role %s is written in the present tense (%q)", b.owner, ws[0])
+		}
+	}
+	if !quantified(ws) {
+		report(RuleQuantification, b.path, "has no number, percentage or amount to show scale or impact")
+	}
+	if cfg.MaxWords > 0 && len(ws) > cfg.MaxWords {
+		report(RuleLength, b.path, "has %d words (at most %d)", len(ws), cfg.MaxWords)
+	}
+	checkFiller(b.path, b.text, report)
+	if phrase := passive(ws); phrase != "" {
+		report(RulePassiveVoice, b.path, "uses the passive voice (%q); say who did it", phrase)
+	}
+	checkReadability(b.path, b.text, cfg.MaxGrade, report)
+}
+
+func quantified(ws []string) bool {
+	for _, w := range ws {
+		if strings.ContainsAny(w, "0123456789%$€£") || numberWords[cleanWord(w)] {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFiller(path, text string, report reporter) {
+	lower := " " + strings.Join(words(strings.ToLower(text)), " ") + " "
+	for _, phrase := range fillerPhrases {
+		if strings.Contains(lower, " "+phrase+" ") {
+			report(RuleFiller, path, "%q is filler; state what was achieved", phrase)
+		}
+	}
+}
+
+var beVerbs = map[string]bool{
+	"am": true, "is": true, "are": true, "was": true, "were": true,
+	"be": true, "been": true, "being": true,
+}
+
+// passive returns the first "be + participle" phrase in ws, allowing one
+// adverb in between, as in "was quickly adopted".
+func passive(ws []string) string {
+	for i, w := range ws {
+		if !beVerbs[cleanWord(w)] {
+			continue
+		}
+		for j := i + 1; j < len(ws) && j <= i+2; j++ {
+			next := cleanWord(ws[j])
+			if participle(next) {
+				return strings.Join(ws[i:j+1], " ")
+			}
+			if !strings.HasSuffix(next, "ly") {
+				break
+			}
+		}
+	}
+	return ""
+}
+
+func participle(w string) bool {
+	return len(w) > 4 && strings.HasSuffix(w, "ed") || irregularParticiples[w]
+}
+
+// minGradeWords is the length from which a grade level means anything;
+// the formula is erratic for a handful of words.
+const minGradeWords = 8
+
+var sentenceEnd = regexp.MustCompile(`[.!?;]+(\s|$)`)
+
+// grade is the Flesch-Kincaid grade level of text.
+func grade(text string) float64 {
+	ws := words(text)
+	if len(ws) == 0 {
+		return 0
+	}
+	sentences := len(sentenceEnd.FindAllString(strings.TrimSpace(text)+" ", -1))
+	if sentences == 0 {
+		sentences = 1
+	}
+	syllableCount := 0
+	for _, w := range ws {
+		syllableCount += syllables(cleanWord(w))
+	}
+	return 0.39*float64(len(ws))/float64(sentences) + 11.8*float64(syllableCount)/float64(len(ws)) - 15.59
+}
+
+// syllables estimates the syllables of a word by counting vowel groups.
+func syllables(w string) int {
+	count, inVowel := 0, false
+	for _, r := range w {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !inVowel {
+			count++
+		}
+		inVowel = vowel
+	}
+	if strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+func checkReadability(path, text string, maxGrade float64, report reporter) {
+	if maxGrade <= 0 || len(words(text)) < minGradeWords {
+		return
+	}
+	if g := grade(text); g > maxGrade {
+		report(RuleReadability, path, "reads at grade %.1f (at most %.0f); use shorter words and sentences", g, maxGrade)
+	}
+}
+
+// checkDuplicates reports bullets that repeat, or nearly repeat, an earlier
+// bullet anywhere in the resume.
+func checkDuplicates(bs []bullet, report reporter) {
+	sets := make([]map[string]bool, len(bs))
+	for i, b := range bs {
+		sets[i] = map[string]bool{}
+		for _, w := range words(b.text) {
+			sets[i][cleanWord(w)] = true
+		}
+	}
+	for i := range bs {
+		for j := 0; j < i; j++ {
+			if sim := jaccard(sets[i], sets[j]); sim >= duplicateSimilarity {
+				if sim == 1 {
+					report(RuleDuplicate, bs[i].path, "repeats %s (%s)", bs[j].path, bs[j].owner)
+				} else {
+					report(RuleDuplicate, bs[i].path, "is nearly the same as %s (%s)", bs[j].path, bs[j].owner)
+				}
+				break
+			}
+		}
+	}
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
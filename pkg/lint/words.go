@@ -0,0 +1,240 @@
+package lint
+
+import "strings"
+
+// verbForms lists action verbs as "base past" pairs. Bullets of past
+// roles start with the past form and bullets of the current role with the
+// base form, so the pair also drives the tense rule.
+const verbForms = `
+accelerate accelerated
+achieve achieved
+acquire acquired
+adapt adapted
+administer administered
+advise advised
+align aligned
+analyze analyzed
+analyse analysed
+architect architected
+arrange arranged
+assemble assembled
+assess assessed
+audit audited
+author authored
+automate automated
+build built
+calculate calculated
+champion championed
+coach coached
+collaborate collaborated
+compile compiled
+complete completed
+conceive conceived
+conduct conducted
+configure configured
+consolidate consolidated
+contribute contributed
+coordinate coordinated
+create created
+cultivate cultivated
+cut cut
+debug debugged
+decrease decreased
+define defined
+deliver delivered
+deploy deployed
+design designed
+develop developed
+devise devised
+diagnose diagnosed
+direct directed
+document documented
+double doubled
+drive drove
+eliminate eliminated
+enable enabled
+engineer engineered
+enhance enhanced
+establish established
+evaluate evaluated
+execute executed
+expand expanded
+expedite expedited
+facilitate facilitated
+forecast forecasted
+formulate formulated
+found founded
+generate generated
+grow grew
+guide guided
+halve halved
+hire hired
+identify identified
+implement implemented
+improve improved
+increase increased
+initiate initiated
+innovate innovated
+instrument instrumented
+integrate integrated
+introduce introduced
+invent invented
+investigate investigated
+launch launched
+lead led
+maintain maintained
+manage managed
+maximize maximized
+mentor mentored
+migrate migrated
+minimize minimized
+model modeled
+modernize modernized
+monitor monitored
+negotiate negotiated
+optimize optimized
+orchestrate orchestrated
+organize organized
+overhaul overhauled
+own owned
+partner partnered
+pilot piloted
+pioneer pioneered
+plan planned
+prepare prepared
+present presented
+prioritize prioritized
+produce produced
+profile profiled
+program programmed
+prototype prototyped
+publish published
+raise raised
+rebuild rebuilt
+recruit recruited
+redesign redesigned
+reduce reduced
+refactor refactored
+remove removed
+replace replaced
+research researched
+resolve resolved
+restructure restructured
+revamp revamped
+review reviewed
+rewrite rewrote
+run ran
+save saved
+scale scaled
+secure secured
+ship shipped
+simplify simplified
+solve solved
+spearhead spearheaded
+standardize standardized
+streamline streamlined
+strengthen strengthened
+supervise supervised
+support supported
+teach taught
+test tested
+train trained
+transform transformed
+translate translated
+triage triaged
+troubleshoot troubleshot
+tune tuned
+unify unified
+upgrade upgraded
+validate validated
+win won
+write wrote
+`
+
+var (
+	baseVerbs = map[string]bool{}
+	pastVerbs = map[string]bool{}
+)
+
+func init() {
+	for _, line := range strings.Split(verbForms, "\n") {
+		forms := strings.Fields(line)
+		if len(forms) != 2 {
+			continue
+		}
+		base, past := forms[0], forms[1]
+		baseVerbs[base] = true
+		pastVerbs[past] = true
+	}
+}
+
+// verbTense classifies the first word of a bullet as an action verb in
+// the "present" (base or third person) or "past" form. Verbs whose forms
+// are spelled the same, such as "cut", are "either".
+func verbTense(word string) string {
+	w := strings.ToLower(word)
+	present := baseVerbs[w] || strings.HasSuffix(w, "s") && baseVerbs[strings.TrimSuffix(w, "s")] ||
+		strings.HasSuffix(w, "es") && baseVerbs[strings.TrimSuffix(w, "es")] ||
+		strings.HasSuffix(w, "ies") && baseVerbs[strings.TrimSuffix(w, "ies")+"y"]
+	past := pastVerbs[w]
+	switch {
+	case present && past:
+		return "either"
+	case present:
+		return "present"
+	case past:
+		return "past"
+	}
+	return ""
+}
+
+// fillerPhrases describe duties rather than results, or are clichés
+// recruiters skip over.
+var fillerPhrases = []string{
+	"responsible for",
+	"duties included",
+	"tasked with",
+	"in charge of",
+	"worked on",
+	"helped with",
+	"helped to",
+	"assisted with",
+	"assisted in",
+	"involved in",
+	"participated in",
+	"various",
+	"etc",
+	"team player",
+	"results-driven",
+	"results-oriented",
+	"detail-oriented",
+	"hard-working",
+	"hardworking",
+	"go-getter",
+	"self-starter",
+	"synergy",
+	"think outside the box",
+	"proven track record",
+	"best of breed",
+}
+
+// numberWords quantify a bullet without digits.
+var numberWords = map[string]bool{
+	"one": true, "two": true, "three": true, "four": true, "five": true,
+	"six": true, "seven": true, "eight": true, "nine": true, "ten": true,
+	"twelve": true, "twenty": true, "dozen": true, "dozens": true,
+	"hundred": true, "hundreds": true, "thousand": true, "thousands": true,
+	"million": true, "millions": true, "billion": true, "billions": true,
+	"twice": true, "double": true, "doubled": true, "doubling": true,
+	"triple": true, "tripled": true, "half": true, "halved": true,
+	"percent": true,
+}
+
+// irregularParticiples complete the "-ed" rule for passive voice.
+var irregularParticiples = map[string]bool{
+	"built": true, "done": true, "driven": true, "given": true, "grown": true,
+	"held": true, "kept": true, "known": true, "led": true, "made": true,
+	"run": true, "seen": true, "sent": true, "shown": true, "spent": true,
+	"taken": true, "taught": true, "told": true, "won": true, "written": true,
+	"chosen": true, "begun": true, "brought": true, "bought": true, "found": true,
+}
@@ -0,0 +1,104 @@
+package match
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed dictionary.txt
+var dictionaryText string
+
+// entry is a term of the bundled skills dictionary.
+type entry struct {
+	name     string
+	category string
+	aliases  []alias
+}
+
+// alias is one way of writing a term, as a sequence of stemmed words.
+// Exact aliases only match when written with the same capitalisation.
+type alias struct {
+	words []string
+	exact bool
+}
+
+// dictionary holds the skills dictionary, built once from dictionary.txt.
+var dictionary = parseDictionary(dictionaryText)
+
+func parseDictionary(text string) []*entry {
+	var entries []*entry
+	category := ""
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			category = line[1 : len(line)-1]
+			continue
+		}
+		e := &entry{category: category}
+		for i, name := range strings.Split(line, "|") {
+			name = strings.TrimSpace(name)
+			exact := strings.HasPrefix(name, "!")
+			name = strings.TrimPrefix(name, "!")
+			if i == 0 {
+				e.name = name
+			}
+			a := alias{exact: exact}
+			for _, t := range tokenize(name) {
+				if exact {
+					a.words = append(a.words, t.original)
+				} else {
+					a.words = append(a.words, stem(t.text))
+				}
+			}
+			if len(a.words) > 0 {
+				e.aliases = append(e.aliases, a)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// scan finds dictionary terms in tokens, taking the longest alias at each
+// position so "AWS Certified Developer" is not also counted as "AWS". It
+// returns the matching entries in order of appearance and marks the
+// tokens they cover in used.
+func scan(tokens []token) (found []*entry, used []bool) {
+	used = make([]bool, len(tokens))
+	for i := 0; i < len(tokens); {
+		var best *entry
+		bestLen := 0
+		for _, e := range dictionary {
+			for _, a := range e.aliases {
+				if len(a.words) > bestLen && a.matches(tokens[i:]) {
+					best, bestLen = e, len(a.words)
+				}
+			}
+		}
+		if best == nil {
+			i++
+			continue
+		}
+		found = append(found, best)
+		for j := i; j < i+bestLen; j++ {
+			used[j] = true
+		}
+		i += bestLen
+	}
+	return found, used
+}
+
+func (a alias) matches(tokens []token) bool {
+	if len(tokens) < len(a.words) {
+		return false
+	}
+	for i, w := range a.words {
+		if a.exact && tokens[i].original != w || !a.exact && stem(tokens[i].text) != w {
+			return false
+		}
+	}
+	return true
+}
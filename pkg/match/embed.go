@@ -0,0 +1,132 @@
+package match
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// DefaultSimilarity is the cosine similarity from which Relate counts
+// resume text as related to a missing term.
+const DefaultSimilarity = 0.7
+
+// Embedder turns texts into embedding vectors.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// OllamaEmbedder embeds texts with a local Ollama model such as
+// nomic-embed-text.
+type OllamaEmbedder struct {
+	URL    string
+	Model  string
+	Client *http.Client
+}
+
+// Embed calls Ollama's /api/embed endpoint.
+func (o *OllamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(map[string]any{"model": o.Model, "input": texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(o.URL, "/")+"/api/embed", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding with %s: %w", o.Model, err)
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Embeddings [][]float64 `json:"embeddings"`
+		Error      string      `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("embedding with %s: %s: %w", o.Model, resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedding with %s: %s: %s", o.Model, resp.Status, out.Error)
+	}
+	if len(out.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("embedding with %s: got %d embeddings for %d texts", o.Model, len(out.Embeddings), len(texts))
+	}
+	return out.Embeddings, nil
+}
+
+// Relate compares each missing term with the highlights, summary, skills
+// and technologies of the resume by embedding similarity. A term whose
+// closest resume text reaches minSimilarity is a paraphrase the keyword
+// match could not see: it becomes weakly covered and Related names that
+// text. The score is updated to match.
+func Relate(ctx context.Context, report *Report, r *resume.Resume, e Embedder, minSimilarity float64) error {
+	var missing []int
+	var texts []string
+	for i, t := range report.Terms {
+		if t.Coverage == Missing {
+			missing = append(missing, i)
+			texts = append(texts, t.Term)
+		}
+	}
+	var candidates []string
+	for _, s := range collect(r) {
+		for _, line := range strings.Split(s.text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				candidates = append(candidates, line)
+			}
+		}
+	}
+	if len(missing) == 0 || len(candidates) == 0 {
+		return nil
+	}
+
+	vectors, err := e.Embed(ctx, append(texts, candidates...))
+	if err != nil {
+		return err
+	}
+	terms, resumeVectors := vectors[:len(texts)], vectors[len(texts):]
+	for i, idx := range missing {
+		best, bestSim := -1, 0.0
+		for j, v := range resumeVectors {
+			if sim := cosine(terms[i], v); best < 0 || sim > bestSim {
+				best, bestSim = j, sim
+			}
+		}
+		if best >= 0 && bestSim >= minSimilarity {
+			t := &report.Terms[idx]
+			t.Coverage = Weak
+			t.Related = candidates[best]
+			t.Similarity = math.Round(bestSim*100) / 100
+		}
+	}
+	report.score()
+	return nil
+}
+
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
@@ -0,0 +1,354 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+// Coverage is how well a resume covers a key phrase of a job posting.
+type Coverage string
+
+const (
+	// Covered terms are listed as a skill or technology and backed by a
+	// highlight or the summary, or mentioned in two or more places.
+	Covered Coverage = "covered"
+	// Weak terms are mentioned only once, or listed without being backed
+	// by any highlight.
+	Weak Coverage = "weak"
+	// Missing terms do not appear in the resume.
+	Missing Coverage = "missing"
+)
+
+// maxKeywords caps the key phrases taken from outside the dictionary.
+const maxKeywords = 10
+
+// Term is a key phrase of a job posting and its coverage in the resume.
+type Term struct {
+	Term     string   `json:"term"`
+	Category string   `json:"category"`
+	Weight   float64  `json:"weight"`
+	Coverage Coverage `json:"coverage"`
+	// Evidence names the parts of the resume that mention the term.
+	Evidence []string `json:"evidence,omitempty"`
+	// Related is the resume text most similar to an otherwise missing
+	// term, set by Relate.
+	Related    string  `json:"related,omitempty"`
+	Similarity float64 `json:"similarity,omitempty"`
+}
+
+// Report is the coverage of a job posting's key phrases, most important
+// first.
+type Report struct {
+	Score int    `json:"score"`
+	Terms []Term `json:"terms"`
+}
+
+// Filter returns the terms with the given coverage.
+func (r *Report) Filter(c Coverage) []Term {
+	var out []Term
+	for _, t := range r.Terms {
+		if t.Coverage == c {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// score sets Score to the weighted share of covered terms, counting weak
+// terms as half covered.
+func (r *Report) score() {
+	var total, got float64
+	for _, t := range r.Terms {
+		total += t.Weight
+		switch t.Coverage {
+		case Covered:
+			got += t.Weight
+		case Weak:
+			got += t.Weight / 2
+		}
+	}
+	if total == 0 {
+		r.Score = 0
+		return
+	}
+	r.Score = int(math.Round(100 * got / total))
+}
+
+// Analyze extracts the key phrases of a job posting and reports how well
+// the resume covers each one. Key phrases are the skills, tools,
+// practices and certifications of the bundled dictionary that the posting
+// mentions, plus the words and word pairs that the posting repeats and
+// that are uncommon in job postings in general. The result depends only
+// on its inputs.
+func Analyze(posting string, r *resume.Resume) *Report {
+	tokens := tokenize(posting)
+	found, used := scan(tokens)
+
+	report := &Report{Terms: []Term{}}
+	sources := collect(r)
+
+	counts := map[*entry]int{}
+	var order []*entry
+	for _, e := range found {
+		if counts[e] == 0 {
+			order = append(order, e)
+		}
+		counts[e]++
+	}
+	for _, e := range order {
+		t := Term{
+			Term:     e.name,
+			Category: e.category,
+			Weight:   weight(counts[e], background.maxIDF()),
+		}
+		t.Coverage, t.Evidence = sources.cover(func(s *source) bool { return s.terms[e] })
+		report.Terms = append(report.Terms, t)
+	}
+
+	for _, k := range keywords(tokens, used) {
+		phrase := k.words
+		t := Term{Term: k.display, Category: "keyword", Weight: k.weight}
+		t.Coverage, t.Evidence = sources.cover(func(s *source) bool { return containsPhrase(s.words, phrase) })
+		report.Terms = append(report.Terms, t)
+	}
+
+	sort.SliceStable(report.Terms, func(i, j int) bool {
+		return report.Terms[i].Weight > report.Terms[j].Weight
+	})
+	report.score()
+	return report
+}
+
+// weight is the TF-IDF weight of a phrase seen tf times in the posting.
+func weight(tf int, idf float64) float64 {
+	return math.Round((1+math.Log(float64(tf)))*idf*100) / 100
+}
+
+// keyword is a repeated word or word pair of the posting.
+type keyword struct {
+	words   []string
+	display string
+	tf      int
+	weight  float64
+}
+
+// keywords returns the highest weighted words and word pairs of the
+// posting outside the dictionary terms, skipping stopwords, numbers and
+// words that are common across job postings.
+func keywords(tokens []token, used []bool) []keyword {
+	type candidate struct {
+		keyword
+		first int
+	}
+	candidates := map[string]*candidate{}
+	add := func(i int, ws []string, display string) {
+		key := strings.Join(ws, " ")
+		c := candidates[key]
+		if c == nil {
+			c = &candidate{keyword: keyword{words: ws, display: display}, first: i}
+			candidates[key] = c
+		}
+		c.tf++
+	}
+	usable := func(i int) bool {
+		w := tokens[i].text
+		return !used[i] && len(w) >= 3 && !stopwords[w] && strings.ContainsFunc(w, func(r rune) bool { return r < '0' || r > '9' })
+	}
+	for i := range tokens {
+		if !usable(i) {
+			continue
+		}
+		w := stem(tokens[i].text)
+		add(i, []string{w}, tokens[i].text)
+		if i+1 < len(tokens) && usable(i+1) {
+			add(i, []string{w, stem(tokens[i+1].text)}, tokens[i].text+" "+tokens[i+1].text)
+		}
+	}
+
+	var ranked []*candidate
+	for _, c := range candidates {
+		if c.tf < 2 || generic(c.words) {
+			continue
+		}
+		idf := 0.0
+		for _, w := range c.words {
+			idf += background.idf(w)
+		}
+		c.weight = weight(c.tf, idf/float64(len(c.words)))
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].weight != ranked[j].weight {
+			return ranked[i].weight > ranked[j].weight
+		}
+		if len(ranked[i].words) != len(ranked[j].words) {
+			return len(ranked[i].words) > len(ranked[j].words)
+		}
+		return ranked[i].first < ranked[j].first
+	})
+
+	// A pair absorbs the words it is made of when they are not repeated
+	// on their own.
+	var picked []keyword
+	for _, c := range ranked {
+		if len(picked) == maxKeywords {
+			break
+		}
+		if len(c.words) == 1 && absorbed(picked, c.keyword) {
+			continue
+		}
+		if len(c.words) == 2 {
+			kept := picked[:0]
+			for _, p := range picked {
+				if !(len(p.words) == 1 && p.tf <= c.tf && contains(c.words, p.words[0])) {
+					kept = append(kept, p)
+				}
+			}
+			picked = kept
+		}
+		picked = append(picked, c.keyword)
+	}
+	return picked
+}
+
+func absorbed(picked []keyword, k keyword) bool {
+	for _, p := range picked {
+		if len(p.words) == 2 && p.tf >= k.tf && contains(p.words, k.words[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// generic reports whether every word of a phrase occurs in more than a
+// fifth of the background postings.
+func generic(ws []string) bool {
+	for _, w := range ws {
+		if background.df[w]*5 <= background.docs {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(ws []string, w string) bool {
+	for _, x := range ws {
+		if x == w {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPhrase reports whether the stemmed words ws contain phrase.
+func containsPhrase(ws, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(ws); i++ {
+		match := true
+		for j, p := range phrase {
+			if ws[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// source is a part of the resume a term can be found in.
+type source struct {
+	name string
+	// listed sources name skills outright: the skills section,
+	// technologies and certifications. Others mention them in prose.
+	listed bool
+	terms  map[*entry]bool
+	words  []string
+	text   string
+}
+
+func newSource(name string, listed bool, text string) *source {
+	tokens := tokenize(text)
+	found, _ := scan(tokens)
+	s := &source{name: name, listed: listed, terms: map[*entry]bool{}, text: text}
+	for _, e := range found {
+		s.terms[e] = true
+	}
+	for _, t := range tokens {
+		s.words = append(s.words, stem(t.text))
+	}
+	return s
+}
+
+type sources []*source
+
+// collect splits the resume into the sources terms are looked up in.
+func collect(r *resume.Resume) sources {
+	var out sources
+	var skills []string
+	for _, cat := range r.Skills.Categories {
+		for _, item := range cat.Items {
+			skills = append(skills, item.Name)
+		}
+	}
+	if len(skills) > 0 {
+		out = append(out, newSource("skills", true, strings.Join(skills, "\n")))
+	}
+	if r.Certifications != nil {
+		for _, c := range r.Certifications.Items {
+			out = append(out, newSource("certification "+c.Name, true, c.Name))
+		}
+	}
+	if r.Summary != "" {
+		out = append(out, newSource("summary", false, r.Summary))
+	}
+	for _, p := range r.Experience.Positions {
+		name := p.Title + " at " + p.Company
+		if len(p.Technologies) > 0 {
+			out = append(out, newSource("technologies of "+name, true, strings.Join(p.Technologies, "\n")))
+		}
+		for i, h := range p.Highlights {
+			out = append(out, newSource(fmt.Sprintf("highlight %d of %s", i+1, name), false, h))
+		}
+	}
+	if r.Projects != nil {
+		for _, p := range r.Projects.Projects {
+			if len(p.Technologies) > 0 {
+				out = append(out, newSource("technologies of "+p.Name, true, strings.Join(p.Technologies, "\n")))
+			}
+			for i, h := range p.Highlights {
+				out = append(out, newSource(fmt.Sprintf("highlight %d of %s", i+1, p.Name), false, h))
+			}
+		}
+	}
+	return out
+}
+
+// cover grades a term by the sources it is found in.
+func (ss sources) cover(has func(*source) bool) (Coverage, []string) {
+	listed, mentions := false, 0
+	var evidence []string
+	for _, s := range ss {
+		if !has(s) {
+			continue
+		}
+		evidence = append(evidence, s.name)
+		if s.listed {
+			listed = true
+		} else {
+			mentions++
+		}
+	}
+	switch {
+	case mentions >= 2 || listed && mentions >= 1:
+		return Covered, evidence
+	case listed || mentions == 1:
+		return Weak, evidence
+	}
+	return Missing, nil
+}
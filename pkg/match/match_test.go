@@ -0,0 +1,174 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+const posting = `Senior Backend Engineer
+
+We are looking for a backend engineer to build the payments platform.
+You will design Go microservices on Kubernetes, run them on AWS and own
+their observability. Experience with Kafka and PostgreSQL is required;
+Terraform is a plus. Our payments platform processes card settlement for
+merchants, so card settlement experience helps.
+
+- 5+ years building distributed systems in Go or Java
+- Experience with Kubernetes, Docker and CI/CD
+- AWS Certified Solutions Architect preferred`
+
+func testResume() *resume.Resume {
+	return &resume.Resume{
+		Summary: "Backend engineer building distributed systems in Go.",
+		Skills: resume.Skills{Categories: []resume.SkillCategory{
+			{Category: "Languages", Items: resume.SkillItemsFromNames("Go", "Python")},
+			{Category: "Tools", Items: resume.SkillItemsFromNames("Docker", "Kubernetes", "PostgreSQL")},
+		}},
+		Experience: resume.ExperienceList{Positions: []resume.Experience{{
+			Company: "Acme", Title: "Staff Engineer",
+			Technologies: []string{"AWS", "Terraform"},
+			Highlights: []string{
+				"Moved the billing platform to Kubernetes microservices",
+				"Cut deploy time in half with a new CI/CD pipeline on Docker",
+			},
+		}}},
+	}
+}
+
+func coverage(report *Report) map[string]Coverage {
+	out := map[string]Coverage{}
+	for _, t := range report.Terms {
+		out[t.Term] = t.Coverage
+	}
+	return out
+}
+
+func TestAnalyze(t *testing.T) {
+	report := Analyze(posting, testResume())
+
+	want := map[string]Coverage{
+		"Go":                                Covered,
+		"Java":                              Missing,
+		"Kubernetes":                        Covered,
+		"Docker":                            Covered,
+		"AWS":                               Weak,
+		"Terraform":                         Weak,
+		"Kafka":                             Missing,
+		"PostgreSQL":                        Weak,
+		"Microservices":                     Weak,
+		"Distributed Systems":               Weak,
+		"Observability":                     Missing,
+		"Backend":                           Weak,
+		"CI/CD":                             Weak,
+		"AWS Certified Solutions Architect": Missing,
+		"payments platform":                 Missing,
+		"card settlement":                   Missing,
+		"engineer":                          Weak,
+	}
+	if got := coverage(report); !reflect.DeepEqual(got, want) {
+		t.Errorf("coverage =\n%v\nwant\n%v", got, want)
+	}
+	for _, term := range report.Terms {
+		if term.Term == "Go" && term.Category != "language" {
+			t.Errorf("Go category = %q", term.Category)
+		}
+		if term.Term == "Kubernetes" && !reflect.DeepEqual(term.Evidence, []string{"skills", "highlight 1 of Staff Engineer at Acme"}) {
+			t.Errorf("Kubernetes evidence = %q", term.Evidence)
+		}
+	}
+	if report.Score <= 0 || report.Score >= 100 {
+		t.Errorf("Score = %d, want partial coverage", report.Score)
+	}
+
+	// The same inputs give the same report.
+	if again := Analyze(posting, testResume()); !reflect.DeepEqual(again, report) {
+		t.Error("Analyze() is not deterministic")
+	}
+}
+
+func TestCaseSensitiveTerms(t *testing.T) {
+	report := Analyze("You will go above and beyond to rest easy. Go, R and REST.", &resume.Resume{})
+	var terms []string
+	for _, term := range report.Terms {
+		terms = append(terms, term.Term)
+	}
+	if strings.Join(terms, ",") != "Go,R,REST" {
+		t.Errorf("terms = %q, want only the capitalised Go, R and REST", terms)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	var got []string
+	for _, tok := range tokenize("C++, C#, Node.js and CI/CD. Event-driven R&D.") {
+		got = append(got, tok.text)
+	}
+	want := []string{"c++", "c#", "node.js", "and", "ci", "cd", "event", "driven", "r&d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestRelate(t *testing.T) {
+	// A fake embedding model that puts "Kafka" next to the resume's
+	// streaming highlight and everything else far apart.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "nomic-embed-text" {
+			http.Error(w, `{"error":"bad request"}`, http.StatusBadRequest)
+			return
+		}
+		var out [][]float64
+		for i, text := range req.Input {
+			switch {
+			case text == "Kafka", strings.Contains(text, "event streams"):
+				out = append(out, []float64{1, 0.1})
+			default:
+				out = append(out, []float64{0, float64(i + 1)})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": out})
+	}))
+	defer server.Close()
+
+	r := &resume.Resume{Experience: resume.ExperienceList{Positions: []resume.Experience{{
+		Company: "Acme", Title: "Engineer",
+		Highlights: []string{"Replaced nightly batch jobs with event streams"},
+	}}}}
+	report := Analyze("Kafka and Kafka Streams; Erlang.", r)
+	before := report.Score
+
+	embedder := &OllamaEmbedder{URL: server.URL, Model: "nomic-embed-text"}
+	if err := Relate(context.Background(), report, r, embedder, DefaultSimilarity); err != nil {
+		t.Fatalf("Relate() error = %v", err)
+	}
+	got := coverage(report)
+	if got["Kafka"] != Weak || got["Erlang"] != Missing {
+		t.Errorf("coverage = %v, want Kafka related and Erlang missing", got)
+	}
+	for _, term := range report.Terms {
+		if term.Term == "Kafka" && term.Related != "Replaced nightly batch jobs with event streams" {
+			t.Errorf("Kafka related = %q", term.Related)
+		}
+	}
+	if report.Score <= before {
+		t.Errorf("Score = %d, want more than %d after relating", report.Score, before)
+	}
+
+	if err := Relate(context.Background(), Analyze("Kafka", r), r, &OllamaEmbedder{URL: server.URL, Model: "missing"}, DefaultSimilarity); err == nil {
+		t.Error("Relate() with a failing model succeeded")
+	}
+}
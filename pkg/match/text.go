@@ -0,0 +1,120 @@
+package match
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// token is a word of text: its lower-case form for matching and the form
+// it was written in for case-sensitive terms.
+type token struct {
+	text     string
+	original string
+}
+
+// tokenize splits s into words. Letters, digits and the characters that
+// occur inside technology names ("c++", "c#", "node.js", "r&d") are kept
+// together; a trailing dot ends a sentence rather than a word.
+func tokenize(s string) []token {
+	var tokens []token
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("+#.&", r))
+	}) {
+		field = strings.Trim(field, ".")
+		field = strings.TrimLeft(field, "&")
+		if strings.Trim(field, "+#.&") == "" {
+			continue
+		}
+		tokens = append(tokens, token{text: strings.ToLower(field), original: field})
+	}
+	return tokens
+}
+
+// words returns the lower-case words of s.
+func words(s string) []string {
+	tokens := tokenize(s)
+	out := make([]string, len(tokens))
+	for i, t := range tokens {
+		out[i] = t.text
+	}
+	return out
+}
+
+// stem strips plural endings so "pipelines" matches "pipeline".
+func stem(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// stopwords are never key phrases on their own.
+var stopwords = toSet(strings.Fields(`
+a about above across after again against all also am an and any are as at
+be because been before being below between both but by can could did do
+does doing down during each either etc few for from further had has have
+having he her here hers him his how i if in into is it its itself just
+least less like may me might more most must my no nor not now of off on
+once only or other our ours out over own per plus same she should so some
+such than that the their theirs them then there these they this those
+through to too under until up upon us very via was we well were what when
+where which while who whom why will with within without would yet you your
+yours able ability across etc e.g i.e including new role years year using
+use used work working join looking ideal candidate strong excellent great
+preferred required requirements responsibilities qualifications
+`))
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+//go:embed background.txt
+var backgroundText string
+
+// background holds document frequencies from a bundled corpus of generic
+// job postings. Words that are common across postings in every field, such
+// as "team" or "experience", get a low inverse document frequency.
+var background = newCorpus(strings.Split(backgroundText, "\n\n"))
+
+type corpus struct {
+	docs int
+	df   map[string]int
+}
+
+func newCorpus(docs []string) *corpus {
+	c := &corpus{df: make(map[string]int)}
+	for _, doc := range docs {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		c.docs++
+		seen := map[string]bool{}
+		for _, w := range words(doc) {
+			w = stem(w)
+			if !seen[w] {
+				seen[w] = true
+				c.df[w]++
+			}
+		}
+	}
+	return c
+}
+
+// idf returns the smoothed inverse document frequency of a stemmed word.
+func (c *corpus) idf(w string) float64 {
+	return math.Log(float64(c.docs+1)/float64(c.df[w]+1)) + 1
+}
+
+// maxIDF is the inverse document frequency of a word the corpus lacks.
+func (c *corpus) maxIDF() float64 {
+	return c.idf("")
+}
@@ -0,0 +1,195 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// decodeStream applies the stream's filters in order.
+func decodeStream(dict Dict, raw []byte) ([]byte, error) {
+	var filters []Name
+	switch f := dict["Filter"].(type) {
+	case nil:
+	case Name:
+		filters = []Name{f}
+	case Array:
+		for _, item := range f {
+			name, ok := item.(Name)
+			if !ok {
+				return nil, fmt.Errorf("invalid filter %v", item)
+			}
+			filters = append(filters, name)
+		}
+	default:
+		return nil, fmt.Errorf("invalid filter %v", f)
+	}
+
+	var params []Dict
+	switch p := dict["DecodeParms"].(type) {
+	case Dict:
+		params = []Dict{p}
+	case Array:
+		for _, item := range p {
+			d, _ := item.(Dict)
+			params = append(params, d)
+		}
+	}
+
+	data := raw
+	for i, filter := range filters {
+		var param Dict
+		if i < len(params) {
+			param = params[i]
+		}
+		var err error
+		if data, err = applyFilter(filter, param, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func applyFilter(filter Name, param Dict, data []byte) ([]byte, error) {
+	switch filter {
+	case "FlateDecode", "Fl":
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("FlateDecode: %w", err)
+		}
+		defer func() { _ = zr.Close() }()
+		out, err := io.ReadAll(zr)
+		if err != nil && len(out) == 0 {
+			// Truncated streams are common; keep whatever was decoded.
+			return nil, fmt.Errorf("FlateDecode: %w", err)
+		}
+		return unpredict(param, out)
+	case "ASCIIHexDecode", "AHx":
+		if end := bytes.IndexByte(data, '>'); end >= 0 {
+			data = data[:end]
+		}
+		digits := bytes.Map(func(r rune) rune {
+			if r < 0x80 && isSpace(byte(r)) {
+				return -1
+			}
+			return r
+		}, data)
+		if len(digits)%2 == 1 {
+			digits = append(digits, '0')
+		}
+		out := make([]byte, len(digits)/2)
+		_, err := hex.Decode(out, digits)
+		return out, err
+	case "ASCII85Decode", "A85":
+		data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+		if end := bytes.Index(data, []byte("~>")); end >= 0 {
+			data = data[:end]
+		}
+		out := make([]byte, 4*len(data)/5+4)
+		n, _, err := ascii85.Decode(out, data, true)
+		return out[:n], err
+	case "RunLengthDecode", "RL":
+		var out []byte
+		for i := 0; i < len(data); {
+			n := int(data[i])
+			i++
+			switch {
+			case n == 128:
+				return out, nil
+			case n < 128:
+				end := min(i+n+1, len(data))
+				out = append(out, data[i:end]...)
+				i = end
+			default:
+				if i < len(data) {
+					out = append(out, bytes.Repeat(data[i:i+1], 257-n)...)
+				}
+				i++
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported filter %s", filter)
+	}
+}
+
+// unpredict reverses the TIFF or PNG predictor named in param. PNG
+// predictors are how cross-reference streams are usually compressed.
+func unpredict(param Dict, data []byte) ([]byte, error) {
+	predictor, _ := param.Int("Predictor")
+	if predictor < 10 {
+		if predictor == 2 {
+			return nil, fmt.Errorf("TIFF predictor is not supported")
+		}
+		return data, nil
+	}
+	columns, ok := param.Int("Columns")
+	if !ok || columns <= 0 {
+		columns = 1
+	}
+	colors, ok := param.Int("Colors")
+	if !ok || colors <= 0 {
+		colors = 1
+	}
+	bpc, ok := param.Int("BitsPerComponent")
+	if !ok || bpc <= 0 {
+		bpc = 8
+	}
+	bpp := max(1, colors*bpc/8)
+	rowLen := (columns*colors*bpc + 7) / 8
+
+	out := make([]byte, 0, len(data))
+	prev := make([]byte, rowLen)
+	for i := 0; i+1 <= len(data); i += rowLen + 1 {
+		kind := data[i]
+		end := min(i+1+rowLen, len(data))
+		row := make([]byte, rowLen)
+		copy(row, data[i+1:end])
+		for j := range row {
+			var left, upLeft byte
+			if j >= bpp {
+				left = row[j-bpp]
+				upLeft = prev[j-bpp]
+			}
+			up := prev[j]
+			switch kind {
+			case 0:
+			case 1:
+				row[j] += left
+			case 2:
+				row[j] += up
+			case 3:
+				row[j] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[j] += paeth(left, up, upLeft)
+			default:
+				return nil, fmt.Errorf("invalid PNG predictor %d", kind)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,408 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// font decodes the strings shown with a font into text and glyph widths.
+type font struct {
+	// composite fonts (Type0) use multi-byte codes.
+	composite bool
+	toUnicode *cmap
+	// encoding maps single-byte codes of simple fonts to text.
+	encoding [256]rune
+	widths   map[int]float64
+	// defaultWidth is used for codes missing from widths, in thousandths of
+	// text space.
+	defaultWidth float64
+}
+
+// glyph is one character code of a shown string.
+type glyph struct {
+	code  int
+	text  string
+	width float64
+	// space is set for the single-byte code 32, which word spacing applies to.
+	space bool
+}
+
+func (r *Reader) loadFont(obj Object) *font {
+	d := r.ResolveDict(obj)
+	f := &font{widths: map[int]float64{}, defaultWidth: 500, encoding: standardEncoding}
+	if d == nil {
+		return f
+	}
+	if s, ok := mustResolve(r, d["ToUnicode"]).(*Stream); ok {
+		if data, err := r.StreamData(s); err == nil {
+			f.toUnicode = parseCMap(data)
+		}
+	}
+
+	if d.Name("Subtype") == "Type0" {
+		f.composite = true
+		f.defaultWidth = 1000
+		descendants, _ := mustResolve(r, d["DescendantFonts"]).(Array)
+		if len(descendants) > 0 {
+			cid := r.ResolveDict(descendants[0])
+			if dw, ok := Number(mustResolve(r, cid["DW"])); ok {
+				f.defaultWidth = dw
+			}
+			f.readCIDWidths(r, cid)
+		}
+		return f
+	}
+
+	f.readEncoding(r, d)
+	first, _ := Number(mustResolve(r, d["FirstChar"]))
+	if widths, ok := mustResolve(r, d["Widths"]).(Array); ok {
+		for i, w := range widths {
+			if n, ok := Number(mustResolve(r, w)); ok {
+				f.widths[int(first)+i] = n
+			}
+		}
+		f.defaultWidth = 0
+		if desc := r.ResolveDict(d["FontDescriptor"]); desc != nil {
+			if n, ok := Number(mustResolve(r, desc["MissingWidth"])); ok {
+				f.defaultWidth = n
+			}
+		}
+	}
+	if d.Name("Subtype") == "Type3" {
+		// Type 3 glyph widths are in glyph space; scale them to thousandths.
+		if m, ok := mustResolve(r, d["FontMatrix"]).(Array); ok && len(m) == 6 {
+			if scale, ok := Number(m[0]); ok && scale != 0 {
+				for code, w := range f.widths {
+					f.widths[code] = w * scale * 1000
+				}
+			}
+		}
+	}
+	return f
+}
+
+func mustResolve(r *Reader, obj Object) Object {
+	v, _ := r.Resolve(obj)
+	return v
+}
+
+// readCIDWidths reads the /W array of a CIDFont: "c [w1 w2 ...]" and
+// "cfirst clast w" entries.
+func (f *font) readCIDWidths(r *Reader, cid Dict) {
+	w, _ := mustResolve(r, cid["W"]).(Array)
+	for i := 0; i+1 < len(w); {
+		first, ok := Number(mustResolve(r, w[i]))
+		if !ok {
+			return
+		}
+		switch next := mustResolve(r, w[i+1]).(type) {
+		case Array:
+			for j, item := range next {
+				if n, ok := Number(mustResolve(r, item)); ok {
+					f.widths[int(first)+j] = n
+				}
+			}
+			i += 2
+		default:
+			last, ok1 := Number(next)
+			if i+2 >= len(w) || !ok1 {
+				return
+			}
+			width, ok2 := Number(mustResolve(r, w[i+2]))
+			if !ok2 {
+				return
+			}
+			for c := int(first); c <= int(last) && c-int(first) < 65536; c++ {
+				f.widths[c] = width
+			}
+			i += 3
+		}
+	}
+}
+
+// readEncoding sets the single-byte encoding of a simple font from its base
+// encoding and /Differences.
+func (f *font) readEncoding(r *Reader, d Dict) {
+	var differences Array
+	switch enc := mustResolve(r, d["Encoding"]).(type) {
+	case Name:
+		f.encoding = namedEncoding(enc)
+	case Dict:
+		if base, ok := enc["BaseEncoding"].(Name); ok {
+			f.encoding = namedEncoding(base)
+		}
+		differences, _ = mustResolve(r, enc["Differences"]).(Array)
+	}
+	code := 0
+	for _, item := range differences {
+		switch v := mustResolve(r, item).(type) {
+		case int:
+			code = v
+		case Name:
+			if code >= 0 && code < 256 {
+				if ch, ok := glyphRune(string(v)); ok {
+					f.encoding[code] = ch
+				}
+			}
+			code++
+		}
+	}
+}
+
+// glyphs splits a shown string into character codes.
+func (f *font) glyphs(s []byte) []glyph {
+	var out []glyph
+	for i := 0; i < len(s); {
+		n := 1
+		if f.composite {
+			n = 2
+		}
+		if f.toUnicode != nil {
+			n = f.toUnicode.codeLength(s[i:], n)
+		}
+		n = min(n, len(s)-i)
+		raw := s[i : i+n]
+		i += n
+
+		code := 0
+		for _, b := range raw {
+			code = code<<8 | int(b)
+		}
+		g := glyph{code: code, space: n == 1 && code == ' '}
+		if w, ok := f.widths[code]; ok {
+			g.width = w
+		} else {
+			g.width = f.defaultWidth
+		}
+		if text, ok := f.toUnicode.lookup(raw); ok {
+			g.text = text
+		} else if !f.composite {
+			if ch := f.encoding[code]; ch != 0 {
+				g.text = string(ch)
+			}
+		}
+		out = append(out, g)
+	}
+	return out
+}
+
+// cmap is a parsed ToUnicode CMap.
+type cmap struct {
+	codespaces []codespace
+	mappings   map[string]string
+}
+
+type codespace struct {
+	lo, hi []byte
+}
+
+// codeLength returns the byte length of the code at the start of s, using
+// the codespace ranges when the CMap declares any.
+func (c *cmap) codeLength(s []byte, fallback int) int {
+	for _, cs := range c.codespaces {
+		n := len(cs.lo)
+		if n == 0 || n > len(s) {
+			continue
+		}
+		match := true
+		for i := 0; i < n; i++ {
+			if s[i] < cs.lo[i] || s[i] > cs.hi[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return n
+		}
+	}
+	return fallback
+}
+
+func (c *cmap) lookup(code []byte) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	text, ok := c.mappings[string(code)]
+	return text, ok
+}
+
+// parseCMap reads the codespace ranges and bfchar/bfrange mappings of a
+// ToUnicode CMap.
+func parseCMap(data []byte) *cmap {
+	c := &cmap{mappings: map[string]string{}}
+	p := newParser(data, 0)
+	p.refs = false
+	var operands []Object
+	for {
+		tok, err := p.next()
+		if err != nil {
+			// Skip over anything the object syntax cannot represent.
+			if p.pos >= len(data) {
+				break
+			}
+			continue
+		}
+		kw, isKeyword := tok.(keyword)
+		if !isKeyword {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				lo, ok1 := operands[i].(String)
+				hi, ok2 := operands[i+1].(String)
+				if ok1 && ok2 && len(lo) == len(hi) {
+					c.codespaces = append(c.codespaces, codespace{lo, hi})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(String)
+				dst, ok2 := operands[i+1].(String)
+				if ok1 && ok2 {
+					c.mappings[string(src)] = utf16Text(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(String)
+				hi, ok2 := operands[i+1].(String)
+				if !ok1 || !ok2 || len(lo) != len(hi) || len(lo) == 0 {
+					continue
+				}
+				start, end := beInt(lo), beInt(hi)
+				if end < start || end-start > 65535 {
+					continue
+				}
+				for code := start; code <= end; code++ {
+					src := make([]byte, len(lo))
+					for k, v := len(src)-1, code; k >= 0; k, v = k-1, v>>8 {
+						src[k] = byte(v)
+					}
+					switch dst := operands[i+2].(type) {
+					case String:
+						c.mappings[string(src)] = utf16Text(incrementLast(dst, code-start))
+					case Array:
+						if code-start < len(dst) {
+							if s, ok := dst[code-start].(String); ok {
+								c.mappings[string(src)] = utf16Text(s)
+							}
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	return c
+}
+
+// incrementLast adds n to the last byte of s, carrying into the byte before
+// it, as bfrange destinations require.
+func incrementLast(s String, n int) String {
+	out := append(String(nil), s...)
+	if len(out) == 0 {
+		return out
+	}
+	v := int(out[len(out)-1]) + n
+	out[len(out)-1] = byte(v)
+	if len(out) >= 2 {
+		out[len(out)-2] += byte(v >> 8)
+	}
+	return out
+}
+
+func utf16Text(s String) string {
+	if len(s) == 1 {
+		return string(rune(s[0]))
+	}
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// namedEncoding returns a predefined simple font encoding. MacRomanEncoding
+// is approximated by WinAnsiEncoding for the characters they share.
+func namedEncoding(name Name) [256]rune {
+	switch name {
+	case "WinAnsiEncoding", "MacRomanEncoding":
+		return winAnsiEncoding
+	}
+	return standardEncoding
+}
+
+var (
+	standardEncoding [256]rune
+	winAnsiEncoding  [256]rune
+	glyphNames       = map[string]rune{}
+)
+
+// Glyph names of printable ASCII from 0x20 and of Latin-1 from 0xA0.
+const (
+	asciiGlyphNames = "space exclam quotedbl numbersign dollar percent ampersand quotesingle parenleft parenright asterisk plus comma hyphen period slash zero one two three four five six seven eight nine colon semicolon less equal greater question at A B C D E F G H I J K L M N O P Q R S T U V W X Y Z bracketleft backslash bracketright asciicircum underscore grave a b c d e f g h i j k l m n o p q r s t u v w x y z braceleft bar braceright asciitilde"
+	latinGlyphNames = "nbspace exclamdown cent sterling currency yen brokenbar section dieresis copyright ordfeminine guillemotleft logicalnot sfthyphen registered macron degree plusminus twosuperior threesuperior acute mu paragraph periodcentered cedilla onesuperior ordmasculine guillemotright onequarter onehalf threequarters questiondown Agrave Aacute Acircumflex Atilde Adieresis Aring AE Ccedilla Egrave Eacute Ecircumflex Edieresis Igrave Iacute Icircumflex Idieresis Eth Ntilde Ograve Oacute Ocircumflex Otilde Odieresis multiply Oslash Ugrave Uacute Ucircumflex Udieresis Yacute Thorn germandbls agrave aacute acircumflex atilde adieresis aring ae ccedilla egrave eacute ecircumflex edieresis igrave iacute icircumflex idieresis eth ntilde ograve oacute ocircumflex otilde odieresis divide oslash ugrave uacute ucircumflex udieresis yacute thorn ydieresis"
+)
+
+// winAnsiHigh lists WinAnsiEncoding from 0x80 to 0x9F; zero marks unused codes.
+var winAnsiHigh = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+var extraGlyphNames = map[string]rune{
+	"Euro": '€', "quotesinglbase": '‚', "florin": 'ƒ', "quotedblbase": '„', "ellipsis": '…',
+	"dagger": '†', "daggerdbl": '‡', "circumflex": 'ˆ', "perthousand": '‰', "Scaron": 'Š',
+	"guilsinglleft": '‹', "OE": 'Œ', "Zcaron": 'Ž', "quoteleft": '‘', "quoteright": '’',
+	"quotedblleft": '“', "quotedblright": '”', "bullet": '•', "endash": '–', "emdash": '—',
+	"tilde": '˜', "trademark": '™', "scaron": 'š', "guilsinglright": '›', "oe": 'œ',
+	"zcaron": 'ž', "Ydieresis": 'Ÿ', "fi": 'ﬁ', "fl": 'ﬂ', "ff": 'ﬀ', "ffi": 'ﬃ', "ffl": 'ﬄ',
+	"minus": '−', "dotlessi": 'ı', "Lslash": 'Ł', "lslash": 'ł', "fraction": '⁄',
+	"space": ' ', "hyphen": '-', "uni00A0": ' ',
+}
+
+func init() {
+	for i, name := range strings.Fields(asciiGlyphNames) {
+		glyphNames[name] = rune(0x20 + i)
+		standardEncoding[0x20+i] = rune(0x20 + i)
+		winAnsiEncoding[0x20+i] = rune(0x20 + i)
+	}
+	for i, name := range strings.Fields(latinGlyphNames) {
+		glyphNames[name] = rune(0xA0 + i)
+		winAnsiEncoding[0xA0+i] = rune(0xA0 + i)
+	}
+	for i, r := range winAnsiHigh {
+		winAnsiEncoding[0x80+i] = r
+	}
+	for name, r := range extraGlyphNames {
+		glyphNames[name] = r
+	}
+	// StandardEncoding uses curly quotes where ASCII has straight ones.
+	standardEncoding['\''] = '’'
+	standardEncoding['`'] = '‘'
+}
+
+// glyphRune maps a glyph name to its character: a known name, uniXXXX,
+// uXXXX[XX] or a ligature name such as f_f_i.
+func glyphRune(name string) (rune, bool) {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i] // "a.sc" is a variant of "a"
+	}
+	if r, ok := glyphNames[name]; ok {
+		return r, true
+	}
+	for _, prefix := range []string{"uni", "u"} {
+		if hexDigits := strings.TrimPrefix(name, prefix); hexDigits != name && len(hexDigits) >= 4 && len(hexDigits) <= 6 {
+			if n, err := strconv.ParseUint(hexDigits[:4], 16, 32); err == nil && prefix == "uni" {
+				return rune(n), true
+			}
+			if n, err := strconv.ParseUint(hexDigits, 16, 32); err == nil {
+				return rune(n), true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,289 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// keyword is a bare token such as obj, stream or a content stream operator.
+type keyword string
+
+// parser reads objects from PDF syntax.
+type parser struct {
+	data []byte
+	pos  int
+	// refs enables "num gen R" references; content streams have none.
+	refs bool
+}
+
+func newParser(data []byte, pos int) *parser {
+	return &parser{data: data, pos: pos, refs: true}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case isSpace(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// regular returns the run of regular characters at the current position.
+func (p *parser) regular() []byte {
+	start := p.pos
+	for p.pos < len(p.data) && !isSpace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	return p.data[start:p.pos]
+}
+
+// next returns the next object, or a keyword for bare tokens. It returns
+// io.EOF at the end of the data.
+func (p *parser) next() (Object, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, io.EOF
+	}
+	switch c := p.data[p.pos]; c {
+	case '/':
+		p.pos++
+		return decodeName(p.regular()), nil
+	case '(':
+		return p.literalString()
+	case '<':
+		if hasPrefixAt(p.data, p.pos, "<<") {
+			return p.dict()
+		}
+		return p.hexString()
+	case '[':
+		p.pos++
+		arr := Array{}
+		for {
+			p.skipSpace()
+			if p.pos >= len(p.data) {
+				return nil, fmt.Errorf("unterminated array")
+			}
+			if p.data[p.pos] == ']' {
+				p.pos++
+				return arr, nil
+			}
+			item, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+			if kw, ok := item.(keyword); ok {
+				return nil, fmt.Errorf("unexpected %q in array", kw)
+			}
+			arr = append(arr, item)
+		}
+	case ']', '>', ')':
+		p.pos++
+		return nil, fmt.Errorf("unexpected %q at offset %d", c, p.pos-1)
+	case '{', '}':
+		p.pos++
+		return keyword(c), nil
+	}
+
+	start := p.pos
+	tok := p.regular()
+	switch string(tok) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.Atoi(string(tok)); err == nil {
+		if p.refs {
+			if ref, ok := p.tryRef(n); ok {
+				return ref, nil
+			}
+		}
+		return n, nil
+	}
+	if isNumeric(tok) {
+		f, err := strconv.ParseFloat(string(tok), 64)
+		if err != nil {
+			// Malformed numbers such as "--5" are read as zero, like most readers.
+			return 0.0, nil
+		}
+		return f, nil
+	}
+	if len(tok) == 0 {
+		p.pos++
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.data[start], start)
+	}
+	return keyword(tok), nil
+}
+
+// tryRef reads "gen R" after num when present.
+func (p *parser) tryRef(num int) (Ref, bool) {
+	save := p.pos
+	p.skipSpace()
+	gen, err := strconv.Atoi(string(p.regular()))
+	if err == nil {
+		p.skipSpace()
+		if r := p.regular(); len(r) == 1 && r[0] == 'R' {
+			return Ref{Num: num, Gen: gen}, true
+		}
+	}
+	p.pos = save
+	return Ref{}, false
+}
+
+func isNumeric(tok []byte) bool {
+	if len(tok) == 0 {
+		return false
+	}
+	for _, c := range tok {
+		if (c < '0' || c > '9') && c != '.' && c != '-' && c != '+' {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) dict() (Object, error) {
+	p.pos += 2
+	d := Dict{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, fmt.Errorf("unterminated dictionary")
+		}
+		if hasPrefixAt(p.data, p.pos, ">>") {
+			p.pos += 2
+			return d, nil
+		}
+		key, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(Name)
+		if !ok {
+			return nil, fmt.Errorf("dictionary key %v is not a name", key)
+		}
+		p.skipSpace()
+		if hasPrefixAt(p.data, p.pos, ">>") {
+			// A key without a value; treat it as null.
+			continue
+		}
+		value, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if kw, ok := value.(keyword); ok {
+			return nil, fmt.Errorf("unexpected %q in dictionary", kw)
+		}
+		d[name] = value
+	}
+}
+
+func decodeName(raw []byte) Name {
+	if bytes.IndexByte(raw, '#') < 0 {
+		return Name(raw)
+	}
+	out := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if b, err := hex.DecodeString(string(raw[i+1 : i+3])); err == nil {
+				out = append(out, b[0])
+				i += 2
+				continue
+			}
+		}
+		out = append(out, raw[i])
+	}
+	return Name(out)
+}
+
+func (p *parser) hexString() (Object, error) {
+	p.pos++
+	end := bytes.IndexByte(p.data[p.pos:], '>')
+	if end < 0 {
+		return nil, fmt.Errorf("unterminated hex string")
+	}
+	digits := make([]byte, 0, end)
+	for _, c := range p.data[p.pos : p.pos+end] {
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	p.pos += end + 1
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	s, err := hex.DecodeString(string(digits))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string: %w", err)
+	}
+	return String(s), nil
+}
+
+func (p *parser) literalString() (Object, error) {
+	p.pos++
+	var out []byte
+	depth := 1
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return String(out), nil
+			}
+		case '\\':
+			if p.pos >= len(p.data) {
+				continue
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				// Line continuation.
+				if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					n := int(e - '0')
+					for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+						n = n*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					out = append(out, byte(n))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return nil, fmt.Errorf("unterminated string")
+}
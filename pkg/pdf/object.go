@@ -0,0 +1,239 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// Object is a PDF object: nil (null), bool, int, float64, String, Name,
+// Array, Dict, Ref or *Stream.
+type Object interface{}
+
+// Name is a PDF name, without the leading slash.
+type Name string
+
+// String is a PDF string. Its bytes are undecoded; use Text for strings that
+// hold human-readable text.
+type String []byte
+
+// Array is a PDF array.
+type Array []Object
+
+// Dict is a PDF dictionary.
+type Dict map[Name]Object
+
+// Ref is an indirect object reference.
+type Ref struct {
+	Num, Gen int
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%d %d R", r.Num, r.Gen)
+}
+
+// Stream is a stream object. Its data is still encoded; use
+// Reader.StreamData to decode it.
+type Stream struct {
+	Dict Dict
+	raw  []byte
+}
+
+// Name returns the name stored under key, or "" when it is missing or not a
+// name. References are not followed.
+func (d Dict) Name(key Name) Name {
+	n, _ := d[key].(Name)
+	return n
+}
+
+// Int returns the integer stored under key. References are not followed.
+func (d Dict) Int(key Name) (int, bool) {
+	n, ok := d[key].(int)
+	return n, ok
+}
+
+// Ref returns the reference stored under key.
+func (d Dict) Ref(key Name) (Ref, bool) {
+	r, ok := d[key].(Ref)
+	return r, ok
+}
+
+// Number converts an integer or real object to float64.
+func Number(obj Object) (float64, bool) {
+	switch v := obj.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// Text decodes a text string: UTF-16 when it starts with a byte order mark,
+// PDFDocEncoding otherwise.
+func (s String) Text() string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		units := make([]uint16, 0, len(s)/2)
+		for i := 2; i+1 < len(s); i += 2 {
+			units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	if len(s) >= 3 && s[0] == 0xEF && s[1] == 0xBB && s[2] == 0xBF {
+		return string(s[3:])
+	}
+	runes := make([]rune, len(s))
+	for i, b := range s {
+		runes[i] = pdfDocEncoding(b)
+	}
+	return string(runes)
+}
+
+// TextString encodes s as a text string: as is when it is printable ASCII,
+// UTF-16 with a byte order mark otherwise.
+func TextString(s string) String {
+	ascii := true
+	for _, r := range s {
+		if r > unicode.MaxASCII || (r < ' ' && r != '\t') {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return String(s)
+	}
+	encoded := utf16.Encode([]rune(s))
+	buf := make([]byte, 2, 2+2*len(encoded))
+	buf[0], buf[1] = 0xFE, 0xFF
+	for _, u := range encoded {
+		buf = append(buf, byte(u>>8), byte(u))
+	}
+	return String(buf)
+}
+
+// Format returns obj in PDF syntax. Dictionary keys are sorted so the output
+// is deterministic. Streams cannot be formatted inline and are an error.
+func Format(obj Object) (string, error) {
+	var b strings.Builder
+	if err := format(&b, obj); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func format(b *strings.Builder, obj Object) error {
+	switch v := obj.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case Name:
+		b.WriteByte('/')
+		for i := 0; i < len(v); i++ {
+			c := v[i]
+			if c <= ' ' || c >= 0x7F || c == '#' || isDelimiter(c) {
+				fmt.Fprintf(b, "#%02X", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	case String:
+		formatString(b, v)
+	case Ref:
+		b.WriteString(v.String())
+	case Array:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			if err := format(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	case Dict:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+		b.WriteString("<<")
+		for _, k := range keys {
+			b.WriteByte(' ')
+			_ = format(b, Name(k))
+			b.WriteByte(' ')
+			if err := format(b, v[Name(k)]); err != nil {
+				return err
+			}
+		}
+		b.WriteString(" >>")
+	default:
+		return fmt.Errorf("cannot format %T inline", obj)
+	}
+	return nil
+}
+
+// formatString writes a literal string, escaping delimiters and bytes that
+// are not printable ASCII.
+func formatString(b *strings.Builder, s String) {
+	b.WriteByte('(')
+	for _, c := range []byte(s) {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c >= 0x7F:
+			fmt.Fprintf(b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// pdfDocEncoding maps a PDFDocEncoding byte to a rune. It matches Latin-1
+// except for a block of typographic characters at 0x18-0x1F and 0x80-0x9F.
+func pdfDocEncoding(b byte) rune {
+	if r, ok := pdfDocSpecials[b]; ok {
+		return r
+	}
+	return rune(b)
+}
+
+var pdfDocSpecials = map[byte]rune{
+	0x18: '˘', 0x19: 'ˇ', 0x1A: 'ˆ', 0x1B: '˙', 0x1C: '˝', 0x1D: '˛', 0x1E: '˚', 0x1F: '˜',
+	0x80: '•', 0x81: '†', 0x82: '‡', 0x83: '…', 0x84: '—', 0x85: '–', 0x86: 'ƒ', 0x87: '⁄',
+	0x88: '‹', 0x89: '›', 0x8A: '−', 0x8B: '‰', 0x8C: '„', 0x8D: '“', 0x8E: '”', 0x8F: '‘',
+	0x90: '’', 0x91: '‚', 0x92: '™', 0x93: 'ﬁ', 0x94: 'ﬂ', 0x95: 'Ł', 0x96: 'Œ', 0x97: 'Š',
+	0x98: 'Ÿ', 0x99: 'Ž', 0x9A: 'ı', 0x9B: 'ł', 0x9C: 'œ', 0x9D: 'š', 0x9E: 'ž', 0xA0: '€',
+}
+
+// hasPrefixAt reports whether data[pos:] starts with prefix.
+func hasPrefixAt(data []byte, pos int, prefix string) bool {
+	return pos >= 0 && pos <= len(data) && bytes.HasPrefix(data[pos:], []byte(prefix))
+}
@@ -0,0 +1,182 @@
+package pdf
+
+import (
+	"fmt"
+)
+
+// Rect is a rectangle in PDF user space units (points).
+type Rect struct {
+	LLX, LLY, URX, URY float64
+}
+
+// Width returns the width of the rectangle.
+func (r Rect) Width() float64 {
+	if r.URX < r.LLX {
+		return r.LLX - r.URX
+	}
+	return r.URX - r.LLX
+}
+
+// Height returns the height of the rectangle.
+func (r Rect) Height() float64 {
+	if r.URY < r.LLY {
+		return r.LLY - r.URY
+	}
+	return r.URY - r.LLY
+}
+
+// letter is assumed for pages that omit their media box.
+var letter = Rect{0, 0, 612, 792}
+
+// Page is a page of the document with its inheritable attributes resolved.
+type Page struct {
+	// Number is the 1-based page number.
+	Number   int
+	Ref      Ref
+	Dict     Dict
+	MediaBox Rect
+	// CropBox is the visible region; it defaults to the media box.
+	CropBox Rect
+	// Rotate is the clockwise rotation in degrees: 0, 90, 180 or 270.
+	Rotate    int
+	Resources Dict
+
+	r *Reader
+}
+
+// Size returns the width and height of the page in points as displayed,
+// with rotation applied.
+func (p *Page) Size() (width, height float64) {
+	width, height = p.CropBox.Width(), p.CropBox.Height()
+	if p.Rotate == 90 || p.Rotate == 270 {
+		width, height = height, width
+	}
+	return width, height
+}
+
+// NumPages returns the number of pages in the page tree.
+func (r *Reader) NumPages() (int, error) {
+	pages, err := r.Pages()
+	return len(pages), err
+}
+
+// Page returns page n, counting from 1.
+func (r *Reader) Page(n int) (*Page, error) {
+	pages, err := r.Pages()
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > len(pages) {
+		return nil, fmt.Errorf("page %d out of range (document has %d pages)", n, len(pages))
+	}
+	return pages[n-1], nil
+}
+
+// Pages returns the pages in order by walking the page tree.
+func (r *Reader) Pages() ([]*Page, error) {
+	if r.pages != nil {
+		return r.pages, nil
+	}
+	catalog, err := r.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	root, ok := catalog["Pages"].(Ref)
+	if !ok {
+		return nil, fmt.Errorf("document catalog has no page tree")
+	}
+	pages := []*Page{}
+	if err := r.walkPages(root, inherited{mediaBox: letter}, make(map[Ref]bool), &pages); err != nil {
+		return nil, err
+	}
+	r.pages = pages
+	return pages, nil
+}
+
+// inherited holds the page attributes that page tree nodes pass down.
+type inherited struct {
+	resources Dict
+	mediaBox  Rect
+	cropBox   *Rect
+	rotate    int
+}
+
+func (r *Reader) walkPages(ref Ref, attrs inherited, seen map[Ref]bool, pages *[]*Page) error {
+	if seen[ref] {
+		return fmt.Errorf("page tree loops back to object %d", ref.Num)
+	}
+	seen[ref] = true
+	obj, err := r.Object(ref)
+	if err != nil {
+		return err
+	}
+	node, ok := obj.(Dict)
+	if !ok {
+		return fmt.Errorf("page tree node %d is not a dictionary", ref.Num)
+	}
+
+	if res := r.ResolveDict(node["Resources"]); res != nil {
+		attrs.resources = res
+	}
+	if box, ok := r.rect(node["MediaBox"]); ok {
+		attrs.mediaBox = box
+	}
+	if box, ok := r.rect(node["CropBox"]); ok {
+		attrs.cropBox = &box
+	}
+	if rot, err := r.Resolve(node["Rotate"]); err == nil {
+		if n, ok := rot.(int); ok {
+			attrs.rotate = ((n % 360) + 360) % 360
+		}
+	}
+
+	kids, _ := r.Resolve(node["Kids"])
+	if kidList, isTree := kids.(Array); node.Name("Type") == "Pages" || (isTree && node.Name("Type") != "Page") {
+		for _, kid := range kidList {
+			kidRef, ok := kid.(Ref)
+			if !ok {
+				continue
+			}
+			if err := r.walkPages(kidRef, attrs, seen, pages); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	page := &Page{
+		Number:    len(*pages) + 1,
+		Ref:       ref,
+		Dict:      node,
+		MediaBox:  attrs.mediaBox,
+		CropBox:   attrs.mediaBox,
+		Rotate:    attrs.rotate,
+		Resources: attrs.resources,
+		r:         r,
+	}
+	if attrs.cropBox != nil {
+		page.CropBox = *attrs.cropBox
+	}
+	*pages = append(*pages, page)
+	return nil
+}
+
+// rect resolves obj as a rectangle.
+func (r *Reader) rect(obj Object) (Rect, bool) {
+	v, err := r.Resolve(obj)
+	if err != nil {
+		return Rect{}, false
+	}
+	arr, ok := v.(Array)
+	if !ok || len(arr) != 4 {
+		return Rect{}, false
+	}
+	var n [4]float64
+	for i, item := range arr {
+		item, _ = r.Resolve(item)
+		if n[i], ok = Number(item); !ok {
+			return Rect{}, false
+		}
+	}
+	return Rect{n[0], n[1], n[2], n[3]}, true
+}
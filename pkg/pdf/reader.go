@@ -0,0 +1,559 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Reader gives access to the objects and pages of a PDF held in memory. It
+// reads cross-reference tables and streams, follows incremental updates and
+// loads objects from compressed object streams. Encrypted documents are not
+// supported.
+type Reader struct {
+	data       []byte
+	xref       map[int]xrefEntry
+	trailer    Dict
+	startxref  int
+	xrefStream bool
+
+	objects   map[Ref]Object
+	objStms   map[int]map[int]Object
+	resolving map[Ref]bool
+	pages     []*Page
+}
+
+// xrefEntry locates an object: at a byte offset, or at an index inside a
+// compressed object stream.
+type xrefEntry struct {
+	offset     int
+	gen        int
+	compressed bool
+	stream     int
+	index      int
+}
+
+// Open reads the PDF at path.
+func Open(path string) (*Reader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(data)
+}
+
+// NewReader parses the cross-reference data of a PDF. When it is missing or
+// damaged the objects are located by scanning the file instead.
+func NewReader(data []byte) (*Reader, error) {
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a PDF: missing %%PDF header")
+	}
+	r := &Reader{
+		data:      data,
+		xref:      make(map[int]xrefEntry),
+		objects:   make(map[Ref]Object),
+		objStms:   make(map[int]map[int]Object),
+		resolving: make(map[Ref]bool),
+	}
+	if err := r.readXref(); err != nil || r.trailer["Root"] == nil {
+		if err := r.rebuildXref(); err != nil {
+			return nil, err
+		}
+	}
+	if r.trailer["Encrypt"] != nil {
+		return nil, fmt.Errorf("encrypted PDFs are not supported")
+	}
+	return r, nil
+}
+
+// Trailer returns the trailer dictionary of the latest revision, with
+// entries missing from it filled in from earlier revisions.
+func (r *Reader) Trailer() Dict {
+	return r.trailer
+}
+
+// StartXref returns the offset of the latest cross-reference section, the
+// /Prev value for an incremental update. It is -1 when the cross-reference
+// data was damaged and had to be rebuilt.
+func (r *Reader) StartXref() int {
+	return r.startxref
+}
+
+// XRefStream reports whether the latest revision uses a cross-reference
+// stream rather than a table. Incremental updates should use the same form.
+func (r *Reader) XRefStream() bool {
+	return r.xrefStream
+}
+
+// Catalog returns the document catalog.
+func (r *Reader) Catalog() (Dict, error) {
+	obj, err := r.Resolve(r.trailer["Root"])
+	if err != nil {
+		return nil, err
+	}
+	d, ok := obj.(Dict)
+	if !ok {
+		return nil, fmt.Errorf("document catalog is missing")
+	}
+	return d, nil
+}
+
+// Resolve follows references until it reaches a direct object. A reference
+// to a missing object resolves to nil, as the PDF specification requires.
+func (r *Reader) Resolve(obj Object) (Object, error) {
+	for depth := 0; depth < 32; depth++ {
+		ref, ok := obj.(Ref)
+		if !ok {
+			return obj, nil
+		}
+		var err error
+		if obj, err = r.Object(ref); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("reference chain too long")
+}
+
+// ResolveDict resolves obj and returns it as a dictionary; the dictionary
+// of a stream is returned for streams. Anything else yields nil.
+func (r *Reader) ResolveDict(obj Object) Dict {
+	obj, err := r.Resolve(obj)
+	if err != nil {
+		return nil
+	}
+	switch v := obj.(type) {
+	case Dict:
+		return v
+	case *Stream:
+		return v.Dict
+	}
+	return nil
+}
+
+// Object returns the object ref refers to.
+func (r *Reader) Object(ref Ref) (Object, error) {
+	if obj, ok := r.objects[ref]; ok {
+		return obj, nil
+	}
+	entry, ok := r.xref[ref.Num]
+	if !ok || entry.gen != ref.Gen {
+		return nil, nil
+	}
+	if r.resolving[ref] {
+		return nil, fmt.Errorf("object %d %d refers to itself", ref.Num, ref.Gen)
+	}
+	r.resolving[ref] = true
+	defer delete(r.resolving, ref)
+
+	var obj Object
+	if entry.compressed {
+		objs, err := r.objectStream(entry.stream)
+		if err != nil {
+			return nil, fmt.Errorf("object %d: %w", ref.Num, err)
+		}
+		obj = objs[ref.Num]
+	} else {
+		got, parsed, err := r.parseIndirect(entry.offset)
+		if err != nil {
+			return nil, fmt.Errorf("object %d: %w", ref.Num, err)
+		}
+		if got != ref {
+			return nil, fmt.Errorf("object %d: cross-reference points at object %d", ref.Num, got.Num)
+		}
+		obj = parsed
+	}
+	r.objects[ref] = obj
+	return obj, nil
+}
+
+// StreamData returns the decoded contents of a stream.
+func (r *Reader) StreamData(s *Stream) ([]byte, error) {
+	dict := Dict{}
+	for _, key := range []Name{"Filter", "DecodeParms"} {
+		v, err := r.Resolve(s.Dict[key])
+		if err != nil {
+			return nil, err
+		}
+		if arr, ok := v.(Array); ok {
+			resolved := make(Array, len(arr))
+			for i, item := range arr {
+				resolved[i], _ = r.Resolve(item)
+			}
+			v = resolved
+		}
+		if v != nil {
+			dict[key] = v
+		}
+	}
+	return decodeStream(dict, s.raw)
+}
+
+// parseIndirect parses "num gen obj ... endobj" at offset.
+func (r *Reader) parseIndirect(offset int) (Ref, Object, error) {
+	if offset < 0 || offset >= len(r.data) {
+		return Ref{}, nil, fmt.Errorf("offset %d is outside the file", offset)
+	}
+	p := newParser(r.data, offset)
+	p.refs = false
+	num, err1 := p.next()
+	gen, err2 := p.next()
+	kw, err3 := p.next()
+	n, ok1 := num.(int)
+	g, ok2 := gen.(int)
+	if err1 != nil || err2 != nil || err3 != nil || !ok1 || !ok2 || kw != keyword("obj") {
+		return Ref{}, nil, fmt.Errorf("no object at offset %d", offset)
+	}
+	ref := Ref{Num: n, Gen: g}
+	p.refs = true
+
+	obj, err := p.next()
+	if err != nil {
+		return ref, nil, err
+	}
+	if _, isKeyword := obj.(keyword); isKeyword {
+		return ref, nil, fmt.Errorf("unexpected %q", obj)
+	}
+	dict, ok := obj.(Dict)
+	if !ok {
+		return ref, obj, nil
+	}
+	p.skipSpace()
+	if !hasPrefixAt(r.data, p.pos, "stream") {
+		return ref, dict, nil
+	}
+
+	start := p.pos + len("stream")
+	if hasPrefixAt(r.data, start, "\r\n") {
+		start += 2
+	} else if hasPrefixAt(r.data, start, "\n") || hasPrefixAt(r.data, start, "\r") {
+		start++
+	}
+	length := -1
+	if l, err := r.Resolve(dict["Length"]); err == nil {
+		if n, ok := l.(int); ok {
+			length = n
+		}
+	}
+	end := start + length
+	if length < 0 || end > len(r.data) || !bytes.HasPrefix(bytes.TrimLeft(r.data[end:], "\r\n \t"), []byte("endstream")) {
+		// Recover from a wrong /Length by looking for the end marker.
+		i := bytes.Index(r.data[start:], []byte("endstream"))
+		if i < 0 {
+			return ref, nil, fmt.Errorf("unterminated stream")
+		}
+		end = start + i
+		if end-2 >= start && hasPrefixAt(r.data, end-2, "\r\n") {
+			end -= 2
+		} else if end > start && (r.data[end-1] == '\n' || r.data[end-1] == '\r') {
+			end--
+		}
+	}
+	return ref, &Stream{Dict: dict, raw: r.data[start:end]}, nil
+}
+
+// objectStream returns the objects stored in the object stream num.
+func (r *Reader) objectStream(num int) (map[int]Object, error) {
+	if objs, ok := r.objStms[num]; ok {
+		return objs, nil
+	}
+	obj, err := r.Object(Ref{Num: num})
+	if err != nil {
+		return nil, err
+	}
+	s, ok := obj.(*Stream)
+	if !ok || s.Dict.Name("Type") != "ObjStm" {
+		return nil, fmt.Errorf("object %d is not an object stream", num)
+	}
+	data, err := r.StreamData(s)
+	if err != nil {
+		return nil, fmt.Errorf("object stream %d: %w", num, err)
+	}
+	n, _ := s.Dict.Int("N")
+	first, _ := s.Dict.Int("First")
+
+	header := newParser(data, 0)
+	header.refs = false
+	objs := make(map[int]Object, n)
+	for i := 0; i < n; i++ {
+		numObj, err1 := header.next()
+		offObj, err2 := header.next()
+		objNum, ok1 := numObj.(int)
+		off, ok2 := offObj.(int)
+		if err1 != nil || err2 != nil || !ok1 || !ok2 {
+			return nil, fmt.Errorf("object stream %d has a malformed header", num)
+		}
+		if first+off >= len(data) {
+			continue
+		}
+		v, err := newParser(data, first+off).next()
+		if err != nil {
+			continue
+		}
+		objs[objNum] = v
+	}
+	r.objStms[num] = objs
+	return objs, nil
+}
+
+// readXref reads the cross-reference sections from the last startxref
+// through the /Prev chain. Entries from newer sections take precedence.
+func (r *Reader) readXref() error {
+	idx := bytes.LastIndex(r.data, []byte("startxref"))
+	if idx < 0 {
+		return fmt.Errorf("startxref not found")
+	}
+	p := newParser(r.data, idx+len("startxref"))
+	offObj, err := p.next()
+	offset, ok := offObj.(int)
+	if err != nil || !ok {
+		return fmt.Errorf("invalid startxref")
+	}
+	r.startxref = offset
+	r.trailer = Dict{}
+
+	seen := make(map[int]bool)
+	for first := true; ; first = false {
+		if seen[offset] {
+			return fmt.Errorf("cross-reference sections form a loop")
+		}
+		seen[offset] = true
+
+		trailer, isStream, err := r.readXrefSection(offset)
+		if err != nil {
+			return err
+		}
+		if first {
+			r.xrefStream = isStream
+		}
+		// Hybrid files point at a cross-reference stream from the table.
+		if stm, ok := trailer.Int("XRefStm"); ok && !seen[stm] {
+			seen[stm] = true
+			if _, _, err := r.readXrefSection(stm); err != nil {
+				return err
+			}
+		}
+		for k, v := range trailer {
+			if _, ok := r.trailer[k]; !ok && k != "Prev" && k != "XRefStm" {
+				r.trailer[k] = v
+			}
+		}
+		prev, ok := trailer.Int("Prev")
+		if !ok {
+			return nil
+		}
+		offset = prev
+	}
+}
+
+// readXrefSection reads the table or stream at offset, adds its entries to
+// the cross-reference and returns its trailer dictionary.
+func (r *Reader) readXrefSection(offset int) (Dict, bool, error) {
+	if offset < 0 || offset >= len(r.data) {
+		return nil, false, fmt.Errorf("cross-reference offset %d is outside the file", offset)
+	}
+	p := newParser(r.data, offset)
+	p.skipSpace()
+	if hasPrefixAt(r.data, p.pos, "xref") {
+		p.pos += len("xref")
+		trailer, err := r.readXrefTable(p)
+		return trailer, false, err
+	}
+	trailer, err := r.readXrefStream(offset)
+	return trailer, true, err
+}
+
+func (r *Reader) readXrefTable(p *parser) (Dict, error) {
+	p.refs = false
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, fmt.Errorf("malformed cross-reference table: %w", err)
+		}
+		if tok == keyword("trailer") {
+			p.refs = true
+			obj, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+			trailer, ok := obj.(Dict)
+			if !ok {
+				return nil, fmt.Errorf("malformed trailer")
+			}
+			return trailer, nil
+		}
+		start, ok1 := tok.(int)
+		countObj, err := p.next()
+		count, ok2 := countObj.(int)
+		if err != nil || !ok1 || !ok2 {
+			return nil, fmt.Errorf("malformed cross-reference subsection")
+		}
+		for i := 0; i < count; i++ {
+			offObj, _ := p.next()
+			genObj, _ := p.next()
+			kind, _ := p.next()
+			off, ok1 := offObj.(int)
+			gen, ok2 := genObj.(int)
+			if !ok1 || !ok2 || (kind != keyword("n") && kind != keyword("f")) {
+				return nil, fmt.Errorf("malformed cross-reference entry for object %d", start+i)
+			}
+			num := start + i
+			if _, exists := r.xref[num]; exists {
+				continue
+			}
+			if kind == keyword("n") {
+				r.xref[num] = xrefEntry{offset: off, gen: gen}
+			} else {
+				// Record free entries so older sections cannot revive them.
+				r.xref[num] = xrefEntry{offset: -1, gen: -1}
+			}
+		}
+	}
+}
+
+func (r *Reader) readXrefStream(offset int) (Dict, error) {
+	_, obj, err := r.parseIndirect(offset)
+	if err != nil {
+		return nil, fmt.Errorf("cross-reference stream: %w", err)
+	}
+	s, ok := obj.(*Stream)
+	if !ok || s.Dict.Name("Type") != "XRef" {
+		return nil, fmt.Errorf("no cross-reference data at offset %d", offset)
+	}
+	data, err := r.StreamData(s)
+	if err != nil {
+		return nil, fmt.Errorf("cross-reference stream: %w", err)
+	}
+
+	w, _ := s.Dict["W"].(Array)
+	if len(w) != 3 {
+		return nil, fmt.Errorf("cross-reference stream has an invalid /W")
+	}
+	var widths [3]int
+	for i, v := range w {
+		widths[i], _ = v.(int)
+		if widths[i] < 0 || widths[i] > 8 {
+			return nil, fmt.Errorf("cross-reference stream has an invalid /W")
+		}
+	}
+	rowLen := widths[0] + widths[1] + widths[2]
+	if rowLen == 0 {
+		return nil, fmt.Errorf("cross-reference stream has an invalid /W")
+	}
+
+	size, _ := s.Dict.Int("Size")
+	index := Array{0, size}
+	if idx, ok := s.Dict["Index"].(Array); ok {
+		index = idx
+	}
+	pos := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		start, _ := index[i].(int)
+		count, _ := index[i+1].(int)
+		for j := 0; j < count && pos+rowLen <= len(data); j++ {
+			row := data[pos : pos+rowLen]
+			pos += rowLen
+			kind := 1
+			if widths[0] > 0 {
+				kind = beInt(row[:widths[0]])
+			}
+			f2 := beInt(row[widths[0] : widths[0]+widths[1]])
+			f3 := beInt(row[widths[0]+widths[1]:])
+
+			num := start + j
+			if _, exists := r.xref[num]; exists {
+				continue
+			}
+			switch kind {
+			case 0:
+				r.xref[num] = xrefEntry{offset: -1, gen: -1}
+			case 1:
+				r.xref[num] = xrefEntry{offset: f2, gen: f3}
+			case 2:
+				r.xref[num] = xrefEntry{compressed: true, stream: f2, index: f3}
+			}
+		}
+	}
+	return s.Dict, nil
+}
+
+func beInt(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<8 | int(c)
+	}
+	return n
+}
+
+var reObjectHeader = regexp.MustCompile(`(?:^|[\r\n\s])(\d+)\s+(\d+)\s+obj\b`)
+
+// rebuildXref locates objects by scanning the file for "num gen obj", for
+// files whose cross-reference data is missing or damaged. The trailer is
+// taken from the last trailer dictionary or cross-reference stream, or else
+// rebuilt around the first catalog found.
+func (r *Reader) rebuildXref() error {
+	r.xref = make(map[int]xrefEntry)
+	r.objects = make(map[Ref]Object)
+	r.objStms = make(map[int]map[int]Object)
+	r.xrefStream = false
+	r.startxref = -1
+
+	for _, m := range reObjectHeader.FindAllSubmatchIndex(r.data, -1) {
+		num, _ := strconv.Atoi(string(r.data[m[2]:m[3]]))
+		gen, _ := strconv.Atoi(string(r.data[m[4]:m[5]]))
+		// Later definitions are newer revisions.
+		r.xref[num] = xrefEntry{offset: m[2], gen: gen}
+	}
+	if len(r.xref) == 0 {
+		return fmt.Errorf("not a PDF: no objects found")
+	}
+
+	trailer := Dict{}
+	if idx := bytes.LastIndex(r.data, []byte("trailer")); idx >= 0 {
+		p := newParser(r.data, idx+len("trailer"))
+		if obj, err := p.next(); err == nil {
+			if d, ok := obj.(Dict); ok {
+				trailer = d
+			}
+		}
+	}
+
+	// Objects in object streams are only listed in cross-reference streams,
+	// so read every one that is still intact, oldest first.
+	nums := make([]int, 0, len(r.xref))
+	for num := range r.xref {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return r.xref[nums[i]].offset < r.xref[nums[j]].offset })
+	var catalog *Ref
+	for _, num := range nums {
+		entry := r.xref[num]
+		obj, err := r.Object(Ref{Num: num, Gen: entry.gen})
+		if err != nil {
+			continue
+		}
+		switch v := obj.(type) {
+		case *Stream:
+			if v.Dict.Name("Type") != "XRef" {
+				continue
+			}
+			if d, err := r.readXrefStream(entry.offset); err == nil && trailer["Root"] == nil {
+				trailer = d
+			}
+		case Dict:
+			if v.Name("Type") == "Catalog" && catalog == nil {
+				catalog = &Ref{Num: num, Gen: entry.gen}
+			}
+		}
+	}
+	if trailer["Root"] == nil && catalog != nil {
+		trailer["Root"] = *catalog
+	}
+	if trailer["Root"] == nil {
+		return fmt.Errorf("document catalog not found")
+	}
+	r.trailer = trailer
+	return nil
+}
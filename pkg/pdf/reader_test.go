@@ -0,0 +1,300 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testPDF assembles PDFs for tests from object bodies keyed by number.
+type testPDF struct {
+	objects map[int]string
+	root    int
+	// compress stores non-stream objects in an object stream and writes a
+	// PNG-predicted cross-reference stream instead of a table.
+	compress bool
+}
+
+func flate(t testing.TB, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// streamObject returns the body of a Flate-compressed stream object.
+func streamObject(t testing.TB, dict string, data string) string {
+	compressed := flate(t, []byte(data))
+	return fmt.Sprintf("<< %s /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream", dict, len(compressed), compressed)
+}
+
+func (tp testPDF) bytes(t testing.TB) []byte {
+	t.Helper()
+	nums := make([]int, 0, len(tp.objects))
+	for num := range tp.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	offsets := map[int]int{}
+	write := func(num int, body string) {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", num, body)
+	}
+
+	size := nums[len(nums)-1] + 1
+	if !tp.compress {
+		for _, num := range nums {
+			write(num, tp.objects[num])
+		}
+		start := buf.Len()
+		fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f\r\n", size)
+		for num := 1; num < size; num++ {
+			if off, ok := offsets[num]; ok {
+				fmt.Fprintf(&buf, "%010d 00000 n\r\n", off)
+			} else {
+				buf.WriteString("0000000000 65535 f\r\n")
+			}
+		}
+		fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, tp.root, start)
+		return buf.Bytes()
+	}
+
+	// Object stream holding every non-stream object.
+	objStm := size
+	xrefNum := size + 1
+	var header, body strings.Builder
+	index := map[int]int{}
+	for _, num := range nums {
+		if strings.Contains(tp.objects[num], "stream\n") {
+			write(num, tp.objects[num])
+			continue
+		}
+		index[num] = len(index)
+		fmt.Fprintf(&header, "%d %d ", num, body.Len())
+		body.WriteString(tp.objects[num])
+		body.WriteString("\n")
+	}
+	write(objStm, streamObject(t, fmt.Sprintf("/Type /ObjStm /N %d /First %d", len(index), header.Len()), header.String()+body.String()))
+
+	// Rows of [type, offset (4 bytes), index], PNG "Up" predicted.
+	var rows bytes.Buffer
+	prev := make([]byte, 6)
+	for num := 0; num <= xrefNum; num++ {
+		row := make([]byte, 6)
+		switch {
+		case num == xrefNum:
+			row = []byte{1, byte(buf.Len() >> 24), byte(buf.Len() >> 16), byte(buf.Len() >> 8), byte(buf.Len()), 0}
+		case index[num] > 0 || (num != 0 && offsets[num] == 0 && tp.objects[num] != ""):
+			row = []byte{2, 0, 0, byte(objStm >> 8), byte(objStm), byte(index[num])}
+		case offsets[num] > 0:
+			off := offsets[num]
+			row = []byte{1, byte(off >> 24), byte(off >> 16), byte(off >> 8), byte(off), 0}
+		}
+		rows.WriteByte(2)
+		for i := range row {
+			rows.WriteByte(row[i] - prev[i])
+		}
+		prev = row
+	}
+	start := buf.Len()
+	write(xrefNum, streamObject(t, fmt.Sprintf("/Type /XRef /Size %d /W [1 4 1] /Root %d 0 R /DecodeParms << /Predictor 12 /Columns 6 >>", xrefNum+1, tp.root), rows.String()))
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", start)
+	return buf.Bytes()
+}
+
+// twoPageDocument returns a document with inherited page attributes, a
+// rotated page and text in both a simple and a composite font.
+func twoPageDocument(t testing.TB) map[int]string {
+	cmap := `/CIDInit /ProcSet findresource begin 12 dict begin begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar <0003> <0020> <0010> <00E9> endbfchar
+1 beginbfrange <0024> <0026> <0041> endbfrange
+endcmap CMapName currentdict /CMap defineresource pop end end`
+	return map[int]string{
+		1: "<< /Type /Catalog /Pages 2 0 R >>",
+		2: "<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>",
+		3: "<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		4: "<< /Type /Page /Parent 2 0 R /Contents [8 0 R] /MediaBox [0 0 595 842] /Rotate 90 >>",
+		5: "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding << /BaseEncoding /WinAnsiEncoding /Differences [150 /endash] >> >>",
+		6: "<< /Type /Font /Subtype /Type0 /BaseFont /Test /Encoding /Identity-H /ToUnicode 9 0 R /DescendantFonts [10 0 R] >>",
+		7: streamObject(t, "", "BT /F1 12 Tf 72 720 Td (Professional Experience) Tj 0 -14 Td [(Jan 2020 ) 150 (\\226 Present)] TJ ET\nBT 72 650 Td /F1 10 Tf (Line ) Tj (continues) Tj ET"),
+		8: streamObject(t, "", "q 1 0 0 1 0 0 cm BT /F2 11 Tf 72 720 Td <0024002500260003002400100026> Tj ET Q"),
+		9: streamObject(t, "", cmap),
+		10: "<< /Type /Font /Subtype /CIDFontType2 /BaseFont /Test /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> " +
+			"/DW 600 /W [3 [250] 16 [500]] >>",
+	}
+}
+
+func TestReader(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("objectStreams=%v", compress), func(t *testing.T) {
+			data := testPDF{objects: twoPageDocument(t), root: 1, compress: compress}.bytes(t)
+			r, err := NewReader(data)
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			if r.XRefStream() != compress {
+				t.Errorf("XRefStream() = %v, want %v", r.XRefStream(), compress)
+			}
+
+			n, err := r.NumPages()
+			if err != nil || n != 2 {
+				t.Fatalf("NumPages() = %d, %v; want 2", n, err)
+			}
+
+			first, _ := r.Page(1)
+			if w, h := first.Size(); w != 612 || h != 792 {
+				t.Errorf("page 1 size = %vx%v, want the inherited 612x792", w, h)
+			}
+			second, _ := r.Page(2)
+			if w, h := second.Size(); w != 842 || h != 595 {
+				t.Errorf("page 2 size = %vx%v, want the rotated 842x595", w, h)
+			}
+
+			text, err := first.Text()
+			if err != nil {
+				t.Fatalf("Text() error = %v", err)
+			}
+			want := "Professional Experience\nJan 2020 – Present\nLine continues"
+			if text != want {
+				t.Errorf("page 1 text = %q, want %q", text, want)
+			}
+
+			lines, err := first.Lines()
+			if err != nil {
+				t.Fatalf("Lines() error = %v", err)
+			}
+			if len(lines) != 3 || lines[1] != (TextLine{Text: "Jan 2020 – Present", X: 72, Y: 706, Size: 12}) || lines[2].Size != 10 {
+				t.Errorf("page 1 lines = %+v", lines)
+			}
+
+			text, err = second.Text()
+			if err != nil {
+				t.Fatalf("Text() error = %v", err)
+			}
+			if text != "ABC Aéc" && text != "ABC AéC" {
+				t.Errorf("page 2 text = %q, want text decoded through ToUnicode", text)
+			}
+		})
+	}
+}
+
+func TestReaderIncrementalUpdate(t *testing.T) {
+	objects := twoPageDocument(t)
+	data := testPDF{objects: objects, root: 1}.bytes(t)
+
+	// Drop the second page in an update.
+	base, err := NewReader(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevOffset := base.StartXref()
+	var buf bytes.Buffer
+	buf.Write(data)
+	offset := buf.Len()
+	buf.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> >>\nendobj\n")
+	start := buf.Len()
+	fmt.Fprintf(&buf, "xref\n2 1\n%010d 00000 n\r\ntrailer\n<< /Size 11 /Root 1 0 R /Prev %d >>\nstartxref\n%d\n%%%%EOF\n", offset, prevOffset, start)
+
+	r, err := NewReader(buf.Bytes())
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if n, _ := r.NumPages(); n != 1 {
+		t.Errorf("NumPages() = %d, want 1 after the update", n)
+	}
+	if r.StartXref() != start {
+		t.Errorf("StartXref() = %d, want %d", r.StartXref(), start)
+	}
+}
+
+func TestReaderRebuildsDamagedXref(t *testing.T) {
+	data := testPDF{objects: twoPageDocument(t), root: 1}.bytes(t)
+	// Point startxref at garbage.
+	i := bytes.LastIndex(data, []byte("startxref"))
+	damaged := append(append([]byte(nil), data[:i]...), []byte("startxref\n7\n%%EOF\n")...)
+
+	r, err := NewReader(damaged)
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if r.StartXref() != -1 {
+		t.Errorf("StartXref() = %d, want -1 for a rebuilt cross-reference", r.StartXref())
+	}
+	if n, _ := r.NumPages(); n != 2 {
+		t.Errorf("NumPages() = %d, want 2", n)
+	}
+}
+
+func TestReaderRejectsNonPDF(t *testing.T) {
+	if _, err := NewReader([]byte("hello")); err == nil {
+		t.Error("NewReader() accepted data without a PDF header")
+	}
+}
+
+func TestMarkerInContentIsNotAPage(t *testing.T) {
+	objects := twoPageDocument(t)
+	objects[7] = streamObject(t, "", "BT /F1 12 Tf 72 720 Td (/Type /Page) Tj ET")
+	data := testPDF{objects: objects, root: 1}.bytes(t)
+	r, err := NewReader(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := r.NumPages(); n != 2 {
+		t.Errorf("NumPages() = %d, want 2", n)
+	}
+}
+
+func TestFormatAndTextString(t *testing.T) {
+	got, err := Format(Dict{"Title": TextString("Zoë"), "Kids": Array{Ref{3, 0}, 1, 2.5, true, nil}, "Type": Name("Pages"), "Odd": String("a(b)\\")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<< /Kids [3 0 R 1 2.5 true null] /Odd (a\(b\)\\) /Title (\376\377\000Z\000o\000\353) /Type /Pages >>`
+	if got != want {
+		t.Errorf("Format() = %s, want %s", got, want)
+	}
+
+	obj, err := newParser([]byte(got), 0).next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title := obj.(Dict)["Title"].(String).Text(); title != "Zoë" {
+		t.Errorf("round-tripped title = %q", title)
+	}
+}
+
+// FuzzNewReader checks that malformed files are reported as errors rather
+// than panicking: ats-check reads arbitrary PDFs.
+func FuzzNewReader(f *testing.F) {
+	compressed := testPDF{objects: twoPageDocument(f), root: 1, compress: true}.bytes(f)
+	f.Add(bytes.Replace(compressed, []byte("/W [1 4 1]"), []byte("/W [-1 4 1]"), 1))
+	f.Add([]byte("%PDF-00000000 0 0 obj << /0000000/000000000000/00000000000>> stream\r\nendstream"))
+	f.Add(testPDF{objects: twoPageDocument(f), root: 1}.bytes(f))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r, err := NewReader(data)
+		if err != nil {
+			return
+		}
+		pages, err := r.Pages()
+		if err != nil {
+			return
+		}
+		for _, p := range pages {
+			_, _ = p.Text()
+		}
+	})
+}
@@ -0,0 +1,366 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// TextLine is a line of text on a page.
+type TextLine struct {
+	Text string
+	// X and Y locate the baseline of the first glyph in default user space,
+	// where Y grows upward from the bottom of the media box.
+	X, Y float64
+	// Size is the font size of the first glyph in user space units.
+	Size float64
+}
+
+// Text returns the text on the page in content stream order. Line breaks
+// are inserted where text moves to a new line and spaces where it skips
+// ahead on the same line. Text drawn with fonts that lack a Unicode mapping
+// is not recovered.
+func (p *Page) Text() (string, error) {
+	lines, err := p.Lines()
+	if err != nil {
+		return "", err
+	}
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.Text
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// Lines returns the lines of text on the page in content stream order, as
+// split by Text. Blank lines are left out.
+func (p *Page) Lines() ([]TextLine, error) {
+	content, err := p.content()
+	if err != nil {
+		return nil, err
+	}
+	x := &textExtractor{r: p.r, fonts: map[Ref]*font{}}
+	x.run(content, p.Resources, identity, 0)
+	return x.result(), nil
+}
+
+// content returns the page's content streams decoded and joined.
+func (p *Page) content() ([]byte, error) {
+	obj, err := p.r.Resolve(p.Dict["Contents"])
+	if err != nil {
+		return nil, err
+	}
+	var streams []Object
+	switch v := obj.(type) {
+	case nil:
+		return nil, nil
+	case *Stream:
+		streams = []Object{v}
+	case Array:
+		streams = v
+	default:
+		return nil, fmt.Errorf("page %d has invalid contents", p.Number)
+	}
+
+	var buf bytes.Buffer
+	for _, item := range streams {
+		s, ok := mustResolve(p.r, item).(*Stream)
+		if !ok {
+			continue
+		}
+		data, err := p.r.StreamData(s)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", p.Number, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// matrix is an affine transformation [a b c d e f].
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m × n: the transformation m followed by n.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func translate(tx, ty float64) matrix {
+	return matrix{1, 0, 0, 1, tx, ty}
+}
+
+// graphicsState is the part of the graphics state that affects text
+// positions.
+type graphicsState struct {
+	ctm        matrix
+	font       *font
+	fontSize   float64
+	charSpace  float64
+	wordSpace  float64
+	scale      float64
+	leading    float64
+	textRise   float64
+	textMatrix matrix
+	lineMatrix matrix
+}
+
+type textExtractor struct {
+	r     *Reader
+	fonts map[Ref]*font
+	lines []TextLine
+	text  strings.Builder
+	// Device-space position where the last glyph ended and its font size.
+	lastX, lastY, lastSize float64
+	hasLast                bool
+}
+
+// result returns the extracted lines with surrounding spaces trimmed.
+func (x *textExtractor) result() []TextLine {
+	x.endLine()
+	lines := x.lines[:0]
+	for _, line := range x.lines {
+		if line.Text = strings.TrimSpace(line.Text); line.Text != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// endLine stores the text of the current line.
+func (x *textExtractor) endLine() {
+	if len(x.lines) > 0 {
+		x.lines[len(x.lines)-1].Text = x.text.String()
+	}
+	x.text.Reset()
+}
+
+// maxFormDepth limits nesting of form XObjects.
+const maxFormDepth = 8
+
+func (x *textExtractor) run(content []byte, resources Dict, ctm matrix, depth int) {
+	gs := graphicsState{ctm: ctm, scale: 1, font: &font{defaultWidth: 500, widths: map[int]float64{}}}
+	var stack []graphicsState
+	var operands []Object
+
+	p := newParser(content, 0)
+	p.refs = false
+	for {
+		tok, err := p.next()
+		if err != nil {
+			if p.pos >= len(content) {
+				return
+			}
+			operands = operands[:0]
+			continue
+		}
+		op, isOperator := tok.(keyword)
+		if !isOperator {
+			operands = append(operands, tok)
+			continue
+		}
+
+		num := func(i int) float64 {
+			if i < len(operands) {
+				n, _ := Number(operands[i])
+				return n
+			}
+			return 0
+		}
+		switch op {
+		case "q":
+			stack = append(stack, gs)
+		case "Q":
+			if len(stack) > 0 {
+				gs = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			if len(operands) == 6 {
+				gs.ctm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}.mul(gs.ctm)
+			}
+		case "BT":
+			gs.textMatrix, gs.lineMatrix = identity, identity
+		case "Tf":
+			if len(operands) == 2 {
+				gs.font = x.font(resources, operands[0])
+				gs.fontSize = num(1)
+			}
+		case "Tc":
+			gs.charSpace = num(0)
+		case "Tw":
+			gs.wordSpace = num(0)
+		case "Tz":
+			gs.scale = num(0) / 100
+		case "TL":
+			gs.leading = num(0)
+		case "Ts":
+			gs.textRise = num(0)
+		case "Td":
+			gs.lineMatrix = translate(num(0), num(1)).mul(gs.lineMatrix)
+			gs.textMatrix = gs.lineMatrix
+		case "TD":
+			gs.leading = -num(1)
+			gs.lineMatrix = translate(num(0), num(1)).mul(gs.lineMatrix)
+			gs.textMatrix = gs.lineMatrix
+		case "Tm":
+			if len(operands) == 6 {
+				gs.lineMatrix = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+				gs.textMatrix = gs.lineMatrix
+			}
+		case "T*":
+			gs.lineMatrix = translate(0, -gs.leading).mul(gs.lineMatrix)
+			gs.textMatrix = gs.lineMatrix
+		case "Tj":
+			if len(operands) > 0 {
+				x.show(&gs, operands[0])
+			}
+		case "'", "\"":
+			if op == "\"" && len(operands) == 3 {
+				gs.wordSpace, gs.charSpace = num(0), num(1)
+			}
+			gs.lineMatrix = translate(0, -gs.leading).mul(gs.lineMatrix)
+			gs.textMatrix = gs.lineMatrix
+			if len(operands) > 0 {
+				x.show(&gs, operands[len(operands)-1])
+			}
+		case "TJ":
+			if len(operands) == 0 {
+				break
+			}
+			arr, _ := operands[0].(Array)
+			for _, item := range arr {
+				if n, ok := Number(item); ok {
+					gs.textMatrix = translate(-n/1000*gs.fontSize*gs.scale, 0).mul(gs.textMatrix)
+					continue
+				}
+				x.show(&gs, item)
+			}
+		case "Do":
+			if len(operands) == 1 && depth < maxFormDepth {
+				x.form(resources, operands[0], gs.ctm, depth)
+			}
+		case "BI":
+			// Inline image data is binary; skip to the end marker.
+			if i := bytes.Index(content[p.pos:], []byte("ID")); i >= 0 {
+				start := p.pos + i + 2
+				if end := indexEI(content[start:]); end >= 0 {
+					p.pos = start + end + 2
+				} else {
+					p.pos = len(content)
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// indexEI returns the index of an "EI" operator delimited by whitespace.
+func indexEI(data []byte) int {
+	for i := 1; i+1 < len(data); i++ {
+		if data[i] == 'E' && data[i+1] == 'I' && isSpace(data[i-1]) && (i+2 == len(data) || isSpace(data[i+2])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// form runs the content of a form XObject.
+func (x *textExtractor) form(resources Dict, name Object, ctm matrix, depth int) {
+	n, ok := name.(Name)
+	if !ok {
+		return
+	}
+	xobjects := x.r.ResolveDict(resources["XObject"])
+	s, ok := mustResolve(x.r, xobjects[n]).(*Stream)
+	if !ok || s.Dict.Name("Subtype") != "Form" {
+		return
+	}
+	data, err := x.r.StreamData(s)
+	if err != nil {
+		return
+	}
+	if m, ok := mustResolve(x.r, s.Dict["Matrix"]).(Array); ok && len(m) == 6 {
+		var fm matrix
+		for i := range fm {
+			fm[i], _ = Number(m[i])
+		}
+		ctm = fm.mul(ctm)
+	}
+	formResources := x.r.ResolveDict(s.Dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	x.run(data, formResources, ctm, depth+1)
+}
+
+func (x *textExtractor) font(resources Dict, name Object) *font {
+	n, _ := name.(Name)
+	fonts := x.r.ResolveDict(resources["Font"])
+	entry := fonts[n]
+	if ref, ok := entry.(Ref); ok {
+		if f, ok := x.fonts[ref]; ok {
+			return f
+		}
+		f := x.r.loadFont(ref)
+		x.fonts[ref] = f
+		return f
+	}
+	return x.r.loadFont(entry)
+}
+
+// show appends the text of a shown string, separated from the previous text
+// by a line break or a space depending on where it is drawn.
+func (x *textExtractor) show(gs *graphicsState, obj Object) {
+	s, ok := obj.(String)
+	if !ok {
+		return
+	}
+	for _, g := range gs.font.glyphs(s) {
+		trm := translate(0, gs.textRise).mul(gs.textMatrix).mul(gs.ctm)
+		px, py := trm[4], trm[5]
+		size := math.Abs(gs.fontSize) * math.Hypot(trm[2], trm[3])
+		if size == 0 {
+			size = 1
+		}
+
+		if g.text != "" {
+			ref := math.Max(size, x.lastSize)
+			switch {
+			case !x.hasLast || math.Abs(py-x.lastY) > 0.5*ref:
+				x.endLine()
+				x.lines = append(x.lines, TextLine{X: px, Y: py, Size: size})
+			case px-x.lastX > 0.15*ref || x.lastX-px > ref:
+				x.space()
+			}
+		}
+		x.text.WriteString(g.text)
+
+		advance := (g.width/1000*gs.fontSize + gs.charSpace) * gs.scale
+		if g.space {
+			advance += gs.wordSpace * gs.scale
+		}
+		gs.textMatrix = translate(advance, 0).mul(gs.textMatrix)
+		if g.text != "" {
+			end := translate(0, gs.textRise).mul(gs.textMatrix).mul(gs.ctm)
+			x.lastX, x.lastY, x.lastSize, x.hasLast = end[4], end[5], size, true
+		}
+	}
+}
+
+func (x *textExtractor) space() {
+	s := x.text.String()
+	if s != "" && !strings.HasSuffix(s, " ") {
+		x.text.WriteByte(' ')
+	}
+}
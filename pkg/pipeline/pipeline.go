@@ -79,6 +79,11 @@ func (p *PDFPipeline) CompileLaTeXToPDFBytes(tmpl *generators.Template, r *resum
 		return nil, err
 	}
 
+	// Images are referenced by name from the .tex file, so they sit beside it
+	if err := generators.WriteImageAssets(r, tmpDir); err != nil {
+		return nil, fmt.Errorf("failed to copy images: %w", err)
+	}
+
 	texPath := filepath.Join(tmpDir, "resume.tex")
 	if err := os.WriteFile(texPath, []byte(content), 0644); err != nil {
 		return nil, fmt.Errorf("failed to write .tex file: %w", err)
@@ -109,7 +114,13 @@ func (p *PDFPipeline) compileHTMLTemplateToPDF(tmpl *generators.Template, r *res
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile PDF: %w", err)
 	}
-	return pdfBytes, nil
+	meta := compilers.PDFMetadata{DocumentInfo: r.DocumentInfo(), Sections: generators.SectionTitles(html)}
+	withMeta, err := compilers.ApplyPDFMetadata(pdfBytes, meta)
+	if err != nil {
+		p.logger.Warnf("Failed to write PDF metadata: %v", err)
+		return pdfBytes, nil
+	}
+	return withMeta, nil
 }
 
 func (p *PDFPipeline) compileHTMLFallbackToPDF(r *resume.Resume) ([]byte, error) {
@@ -121,20 +132,11 @@ func (p *PDFPipeline) compileHTMLFallbackToPDF(r *resume.Resume) ([]byte, error)
 }
 
 func (p *PDFPipeline) copyTemplateFiles(tmpl *generators.Template, destDir string) error {
-	var sourceDir string
-
-	if tmpl.Embedded && tmpl.EmbeddedDir != "" {
-		extractedDir, err := generators.ExtractEmbeddedTemplateDir(tmpl.EmbeddedDir)
-		if err != nil {
-			return fmt.Errorf("failed to extract template files: %w", err)
-		}
-		defer func() { _ = os.RemoveAll(extractedDir) }()
-		sourceDir = extractedDir
-	} else if tmpl.Path != "" {
-		sourceDir = filepath.Dir(tmpl.Path)
-	} else {
-		return nil
+	sourceDir, err := generators.ExtractTemplateFiles(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to extract template files: %w", err)
 	}
+	defer func() { _ = os.RemoveAll(sourceDir) }()
 
 	entries, err := os.ReadDir(sourceDir)
 	if err != nil {
@@ -3,6 +3,7 @@ package resume
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -45,12 +46,21 @@ func (a *ResumeAdapter) Validate() error {
 }
 
 // LoadResumeFromBytes parses resume data from raw bytes with the given format.
-// Format must be one of: "yaml", "yml", "json", "toml".
+// Format must be one of: "yaml", "yml", "json", "jsonc", "toml", "md",
+// "markdown", or empty to detect it from the content.
 func LoadResumeFromBytes(data []byte, format string) (InputData, error) {
 	var resumeData Resume
 	var serializationFmt string
 
-	switch strings.ToLower(format) {
+	if strings.TrimSpace(format) == "" {
+		detected, err := DetectFormat(data)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
 	case "yaml", "yml":
 		if err := UnmarshalYAMLWithContext(data, &resumeData); err != nil {
 			return nil, fmt.Errorf("failed to parse YAML: %w", err)
@@ -63,6 +73,12 @@ func LoadResumeFromBytes(data []byte, format string) (InputData, error) {
 		}
 		serializationFmt = "json"
 
+	case "jsonc":
+		if err := json.Unmarshal(stripJSONComments(data), &resumeData); err != nil {
+			return nil, fmt.Errorf("failed to parse JSONC: %w", err)
+		}
+		serializationFmt = "json"
+
 	case "toml":
 		if _, err := toml.Decode(string(data), &resumeData); err != nil {
 			return nil, fmt.Errorf("failed to parse TOML: %w", err)
@@ -78,7 +94,7 @@ func LoadResumeFromBytes(data []byte, format string) (InputData, error) {
 		serializationFmt = "md"
 
 	default:
-		return nil, fmt.Errorf("unsupported format: %s (supported: yaml, yml, json, toml, md, markdown)", format)
+		return nil, fmt.Errorf("unsupported format: %s (supported: yaml, yml, json, jsonc, toml, md, markdown)", format)
 	}
 
 	// Basic validation
@@ -92,13 +108,43 @@ func LoadResumeFromBytes(data []byte, format string) (InputData, error) {
 	}, nil
 }
 
-// LoadResumeFromFile loads a resume from YAML, JSON, or TOML file.
+// LoadResumeFromReader loads a resume from r, typically standard input. An
+// empty format is detected from the content.
+func LoadResumeFromReader(r io.Reader, format string) (InputData, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	return LoadResumeFromBytes(data, format)
+}
+
+// LoadResumeFromFile loads a resume from a YAML, JSON, TOML or Markdown file.
+// The format follows the file extension and is detected from the content
+// when the extension is missing or unrecognized.
 func LoadResumeFromFile(filePath string) (InputData, error) {
+	return LoadResumeFromFileAs(filePath, "")
+}
+
+// LoadResumeFromFileAs loads a resume from filePath in the given format,
+// falling back to LoadResumeFromFile's extension and content rules when the
+// format is empty.
+func LoadResumeFromFileAs(filePath, format string) (InputData, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	format := strings.TrimPrefix(filepath.Ext(filePath), ".")
-	return LoadResumeFromBytes(data, format)
+	if strings.TrimSpace(format) == "" {
+		format = FormatFromPath(filePath)
+	}
+	inputData, err := LoadResumeFromBytes(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Image paths are relative to the input file, not the working directory.
+	if absPath, absErr := filepath.Abs(filePath); absErr == nil {
+		inputData.ToResume().ResolveImages(filepath.Dir(absPath))
+	}
+	return inputData, nil
 }
@@ -0,0 +1,147 @@
+package resume
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// CoverLetter is a letter generated alongside the resume, sharing its
+// contact header and layout. It is either declared inline under the
+// resume's cover_letter key or kept in its own file that points at the
+// resume through Resume.
+type CoverLetter struct {
+	// Resume is the path of the resume this letter accompanies, relative to
+	// the letter file. It is ignored for inline letters.
+	Resume     string     `json:"resume,omitempty" yaml:"resume,omitempty" toml:"resume,omitempty"`
+	Recipient  *Recipient `json:"recipient,omitempty" yaml:"recipient,omitempty" toml:"recipient,omitempty"`
+	Company    string     `json:"company,omitempty" yaml:"company,omitempty" toml:"company,omitempty"`
+	Date       *time.Time `json:"date,omitempty" yaml:"date,omitempty" toml:"date,omitempty"`
+	Salutation string     `json:"salutation,omitempty" yaml:"salutation,omitempty" toml:"salutation,omitempty"`
+	// Body holds the letter's paragraphs in order.
+	Body    []string `json:"body" yaml:"body" toml:"body"`
+	Closing string   `json:"closing,omitempty" yaml:"closing,omitempty" toml:"closing,omitempty"`
+	// Signature defaults to the resume's contact name.
+	Signature string `json:"signature,omitempty" yaml:"signature,omitempty" toml:"signature,omitempty"`
+
+	resolvedResume string
+}
+
+// Recipient is the person a cover letter is addressed to.
+type Recipient struct {
+	Name     string    `json:"name,omitempty" yaml:"name,omitempty" toml:"name,omitempty"`
+	Title    string    `json:"title,omitempty" yaml:"title,omitempty" toml:"title,omitempty"`
+	Location *Location `json:"location,omitempty" yaml:"location,omitempty" toml:"location,omitempty"`
+}
+
+// SalutationText returns the salutation, defaulting to the recipient's name
+// or "Dear Hiring Manager,".
+func (c *CoverLetter) SalutationText() string {
+	if s := strings.TrimSpace(c.Salutation); s != "" {
+		return s
+	}
+	if c.Recipient != nil && strings.TrimSpace(c.Recipient.Name) != "" {
+		return "Dear " + strings.TrimSpace(c.Recipient.Name) + ","
+	}
+	return "Dear Hiring Manager,"
+}
+
+// ClosingText returns the closing, defaulting to "Sincerely,".
+func (c *CoverLetter) ClosingText() string {
+	if s := strings.TrimSpace(c.Closing); s != "" {
+		return s
+	}
+	return "Sincerely,"
+}
+
+// SignatureFor returns the signature, defaulting to the contact name.
+func (c *CoverLetter) SignatureFor(contact Contact) string {
+	if s := strings.TrimSpace(c.Signature); s != "" {
+		return s
+	}
+	return contact.Name
+}
+
+// DateOr returns the letter date, or now when none is set.
+func (c *CoverLetter) DateOr(now time.Time) time.Time {
+	if c.Date != nil && !c.Date.IsZero() {
+		return *c.Date
+	}
+	return now
+}
+
+// ResumePath returns the referenced resume path resolved against the
+// letter file's directory, or "" when the letter names no resume.
+func (c *CoverLetter) ResumePath() string {
+	if c.resolvedResume != "" {
+		return c.resolvedResume
+	}
+	return strings.TrimSpace(c.Resume)
+}
+
+// LoadCoverLetterFromFile loads a standalone cover letter from a YAML, JSON
+// or TOML file.
+func LoadCoverLetterFromFile(filePath string) (*CoverLetter, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var letter CoverLetter
+	switch format := strings.ToLower(strings.TrimPrefix(filepath.Ext(filePath), ".")); format {
+	case "yaml", "yml":
+		if err := UnmarshalYAMLWithContext(data, &letter); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+	case "json":
+		if err := json.Unmarshal(data, &letter); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	case "toml":
+		if _, err := toml.Decode(string(data), &letter); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported cover letter format: %s (supported: yaml, yml, json, toml)", format)
+	}
+
+	if path := strings.TrimSpace(letter.Resume); path != "" && !filepath.IsAbs(path) {
+		if absPath, absErr := filepath.Abs(filePath); absErr == nil {
+			letter.resolvedResume = filepath.Join(filepath.Dir(absPath), path)
+		}
+	}
+	return &letter, nil
+}
+
+// ValidateCoverLetter checks a cover letter. prefix is prepended to field
+// paths, e.g. "cover_letter" for inline letters.
+func ValidateCoverLetter(c *CoverLetter, prefix string) []ValidationError {
+	field := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+
+	var errors []ValidationError
+	hasBody := false
+	for _, p := range c.Body {
+		if strings.TrimSpace(p) != "" {
+			hasBody = true
+			break
+		}
+	}
+	if !hasBody {
+		errors = append(errors, ValidationError{
+			Field:   field("body"),
+			Message: "Cover letter needs at least one body paragraph",
+			Type:    "required",
+		})
+	}
+	return errors
+}
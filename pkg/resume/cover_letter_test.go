@@ -0,0 +1,98 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCoverLetterFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "letter.yml")
+	content := `resume: resume.yml
+recipient:
+  name: Alex Smith
+company: Acme
+date: 2025-03-04
+body:
+  - First paragraph.
+  - Second paragraph.
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write letter: %v", err)
+	}
+
+	letter, err := LoadCoverLetterFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadCoverLetterFromFile() error = %v", err)
+	}
+	if got, want := letter.ResumePath(), filepath.Join(dir, "resume.yml"); got != want {
+		t.Errorf("ResumePath() = %q, want %q", got, want)
+	}
+	if letter.Resume != "resume.yml" {
+		t.Errorf("Resume = %q, the original path should be kept", letter.Resume)
+	}
+	if len(letter.Body) != 2 || letter.Company != "Acme" {
+		t.Errorf("unexpected letter: %+v", letter)
+	}
+	if got := letter.DateOr(time.Time{}); got.Format("2006-01-02") != "2025-03-04" {
+		t.Errorf("DateOr() = %v", got)
+	}
+	if errs := ValidateCoverLetter(letter, ""); len(errs) != 0 {
+		t.Errorf("ValidateCoverLetter() = %+v, want none", errs)
+	}
+
+	if _, err := LoadCoverLetterFromFile(filepath.Join(dir, "letter.md")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestCoverLetterDefaults(t *testing.T) {
+	contact := Contact{Name: "Jane Doe"}
+
+	letter := &CoverLetter{}
+	if got := letter.SalutationText(); got != "Dear Hiring Manager," {
+		t.Errorf("SalutationText() = %q", got)
+	}
+	if got := letter.ClosingText(); got != "Sincerely," {
+		t.Errorf("ClosingText() = %q", got)
+	}
+	if got := letter.SignatureFor(contact); got != "Jane Doe" {
+		t.Errorf("SignatureFor() = %q", got)
+	}
+
+	letter = &CoverLetter{Recipient: &Recipient{Name: "Alex Smith"}, Closing: "Best regards,", Signature: "J. Doe"}
+	if got := letter.SalutationText(); got != "Dear Alex Smith," {
+		t.Errorf("SalutationText() = %q", got)
+	}
+	if got := letter.ClosingText(); got != "Best regards," {
+		t.Errorf("ClosingText() = %q", got)
+	}
+	if got := letter.SignatureFor(contact); got != "J. Doe" {
+		t.Errorf("SignatureFor() = %q", got)
+	}
+}
+
+func TestInlineCoverLetterValidation(t *testing.T) {
+	data := []byte(`contact:
+  name: Jane Doe
+  email: jane@example.com
+cover_letter:
+  company: Acme
+  body: [""]
+`)
+	input, err := LoadResumeFromBytes(data, "yaml")
+	if err != nil {
+		t.Fatalf("LoadResumeFromBytes() error = %v", err)
+	}
+	r := input.ToResume()
+	if r.CoverLetter == nil || r.CoverLetter.Company != "Acme" {
+		t.Fatalf("inline cover letter not parsed: %+v", r.CoverLetter)
+	}
+
+	errs := Validate(r)
+	if len(errs) != 1 || errs[0].Field != "cover_letter.body" {
+		t.Errorf("Validate() = %+v, want a cover_letter.body error", errs)
+	}
+}
@@ -0,0 +1,533 @@
+package resume
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Change kinds reported by DiffResumes.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "changed"
+	ChangeReworded = "reworded"
+)
+
+// rewordThreshold is the minimum word overlap for two bullets to count as
+// one reworded bullet rather than a removal and an addition.
+const rewordThreshold = 0.5
+
+// Change is a single semantic difference between two versions of a resume.
+type Change struct {
+	Kind    string `json:"kind"`
+	Section string `json:"section"`
+	// Entity names the position, project, institution or other item the
+	// change belongs to; it is empty for section-level fields like summary.
+	Entity string `json:"entity,omitempty"`
+	// Field is the changed attribute of the entity, such as "dates" or
+	// "highlight"; it is empty when the entity itself was added or removed.
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// String renders the change as a single review line.
+func (c Change) String() string {
+	var b strings.Builder
+	b.WriteString(c.Section)
+	if c.Entity != "" {
+		b.WriteString(": ")
+		b.WriteString(c.Entity)
+	}
+	if c.Field != "" {
+		b.WriteString(": ")
+		b.WriteString(c.Field)
+	}
+	b.WriteString(" ")
+	b.WriteString(c.Kind)
+
+	switch {
+	case c.Old != "" && c.New != "":
+		fmt.Fprintf(&b, ": %q → %q", c.Old, c.New)
+	case c.New != "" && c.Field != "":
+		fmt.Fprintf(&b, ": %q", c.New)
+	case c.Old != "" && c.Field != "":
+		fmt.Fprintf(&b, ": %q", c.Old)
+	}
+	return b.String()
+}
+
+// DiffResumes reports the semantic changes from old to new. Entities are
+// matched by identity rather than list position: positions by company and
+// title, projects by name, institutions by name and degree, and so on, so
+// reordering a list is not reported as a change.
+func DiffResumes(old, new *Resume) []Change {
+	var changes []Change
+	changes = append(changes, diffContact(old.Contact, new.Contact)...)
+	changes = append(changes, diffText("summary", "", "summary", old.Summary, new.Summary)...)
+	changes = append(changes, diffSkills(old.Skills, new.Skills)...)
+	changes = append(changes, diffExperience(old.Experience.Positions, new.Experience.Positions)...)
+	changes = append(changes, diffProjects(projectsOf(old), projectsOf(new))...)
+	changes = append(changes, diffEducation(old.Education.Institutions, new.Education.Institutions)...)
+	changes = append(changes, diffNamed("certifications", certificationNames(old), certificationNames(new))...)
+	changes = append(changes, diffNamed("languages", languageNames(old), languageNames(new))...)
+	changes = append(changes, diffNamed("references", refereeNames(old), refereeNames(new))...)
+	if !reflect.DeepEqual(old.Layout, new.Layout) {
+		changes = append(changes, Change{Kind: ChangeModified, Section: "layout"})
+	}
+	return changes
+}
+
+func diffContact(old, new Contact) []Change {
+	var changes []Change
+	field := func(name, a, b string) {
+		if a != b {
+			changes = append(changes, modified("contact", "", name, a, b))
+		}
+	}
+	field("name", old.Name, new.Name)
+	field("email", old.Email, new.Email)
+	field("phone", old.Phone, new.Phone)
+	field("credentials", old.Credentials, new.Credentials)
+	field("location", locationText(old.Location), locationText(new.Location))
+
+	oldLinks := make([]string, len(old.Links))
+	for i, l := range old.Links {
+		oldLinks[i] = l.URI
+	}
+	newLinks := make([]string, len(new.Links))
+	for i, l := range new.Links {
+		newLinks[i] = l.URI
+	}
+	return append(changes, diffItems("contact", "", "link", oldLinks, newLinks)...)
+}
+
+func diffSkills(old, new Skills) []Change {
+	var changes []Change
+	key := func(c SkillCategory) string { return normalizeKey(c.Category) }
+	pairs, removed, added := matchEntities(old.Categories, new.Categories, key)
+	for _, i := range removed {
+		changes = append(changes, Change{Kind: ChangeRemoved, Section: "skills", Entity: old.Categories[i].Category})
+	}
+	for _, p := range pairs {
+		a, b := old.Categories[p[0]], new.Categories[p[1]]
+		changes = append(changes, diffItems("skills", b.Category, "skill", SkillNames(a.Items), SkillNames(b.Items))...)
+
+		itemKey := func(s SkillItem) string { return normalizeKey(s.Name) }
+		items, _, _ := matchEntities(a.Items, b.Items, itemKey)
+		for _, q := range items {
+			if l1, l2 := a.Items[q[0]].Level, b.Items[q[1]].Level; l1 != l2 {
+				changes = append(changes, modified("skills", b.Category, b.Items[q[1]].Name+" level", l1, l2))
+			}
+		}
+	}
+	for _, i := range added {
+		changes = append(changes, Change{Kind: ChangeAdded, Section: "skills", Entity: new.Categories[i].Category})
+	}
+	return changes
+}
+
+func diffExperience(old, new []Experience) []Change {
+	var changes []Change
+	pairs, removed, added := matchEntities(old, new, positionKey, func(e Experience) string { return normalizeKey(e.Company) })
+	for _, i := range removed {
+		changes = append(changes, Change{Kind: ChangeRemoved, Section: "experience", Entity: positionName(old[i])})
+	}
+	for _, p := range pairs {
+		a, b := old[p[0]], new[p[1]]
+		entity := positionName(b)
+		if a.Title != b.Title {
+			changes = append(changes, modified("experience", positionName(a), "title", a.Title, b.Title))
+		}
+		if r1, r2 := DateRangeText(&a.Dates), DateRangeText(&b.Dates); r1 != r2 {
+			changes = append(changes, modified("experience", entity, "dates", r1, r2))
+		}
+		if l1, l2 := locationText(a.Location), locationText(b.Location); l1 != l2 {
+			changes = append(changes, modified("experience", entity, "location", l1, l2))
+		}
+		if a.EmploymentType != b.EmploymentType {
+			changes = append(changes, modified("experience", entity, "employment type", a.EmploymentType, b.EmploymentType))
+		}
+		changes = append(changes, diffBullets("experience", entity, "highlight", a.Highlights, b.Highlights)...)
+		changes = append(changes, diffBullets("experience", entity, "duty", a.Duties, b.Duties)...)
+		changes = append(changes, diffItems("experience", entity, "technology", a.Technologies, b.Technologies)...)
+	}
+	for _, i := range added {
+		changes = append(changes, Change{Kind: ChangeAdded, Section: "experience", Entity: positionName(new[i])})
+	}
+	return changes
+}
+
+func diffProjects(old, new []Project) []Change {
+	var changes []Change
+	pairs, removed, added := matchEntities(old, new,
+		func(p Project) string { return normalizeKey(p.Name) },
+		func(p Project) string { return normalizeKey(p.Link.URI) })
+	for _, i := range removed {
+		changes = append(changes, Change{Kind: ChangeRemoved, Section: "projects", Entity: old[i].Name})
+	}
+	for _, p := range pairs {
+		a, b := old[p[0]], new[p[1]]
+		if a.Name != b.Name {
+			changes = append(changes, modified("projects", a.Name, "name", a.Name, b.Name))
+		}
+		if a.Link.URI != b.Link.URI {
+			changes = append(changes, modified("projects", b.Name, "link", a.Link.URI, b.Link.URI))
+		}
+		if r1, r2 := DateRangeText(a.Dates), DateRangeText(b.Dates); r1 != r2 {
+			changes = append(changes, modified("projects", b.Name, "dates", r1, r2))
+		}
+		changes = append(changes, diffBullets("projects", b.Name, "highlight", a.Highlights, b.Highlights)...)
+		changes = append(changes, diffItems("projects", b.Name, "technology", a.Technologies, b.Technologies)...)
+	}
+	for _, i := range added {
+		changes = append(changes, Change{Kind: ChangeAdded, Section: "projects", Entity: new[i].Name})
+	}
+	return changes
+}
+
+func diffEducation(old, new []Education) []Change {
+	var changes []Change
+	pairs, removed, added := matchEntities(old, new,
+		func(e Education) string { return normalizeKey(e.Institution + "|" + e.Degree.Name) },
+		func(e Education) string { return normalizeKey(e.Institution) })
+	for _, i := range removed {
+		changes = append(changes, Change{Kind: ChangeRemoved, Section: "education", Entity: institutionName(old[i])})
+	}
+	for _, p := range pairs {
+		a, b := old[p[0]], new[p[1]]
+		entity := institutionName(b)
+		if a.Degree.Name != b.Degree.Name {
+			changes = append(changes, modified("education", institutionName(a), "degree", a.Degree.Name, b.Degree.Name))
+		}
+		if r1, r2 := DateRangeText(&a.Dates), DateRangeText(&b.Dates); r1 != r2 {
+			changes = append(changes, modified("education", entity, "dates", r1, r2))
+		}
+		if g1, g2 := gpaText(a.GPA), gpaText(b.GPA); g1 != g2 {
+			changes = append(changes, modified("education", entity, "GPA", g1, g2))
+		}
+		changes = append(changes, diffBullets("education", entity, "description", a.Degree.Descriptions, b.Degree.Descriptions)...)
+	}
+	for _, i := range added {
+		changes = append(changes, Change{Kind: ChangeAdded, Section: "education", Entity: institutionName(new[i])})
+	}
+	return changes
+}
+
+// diffNamed reports names added to or removed from a section, ignoring order
+// and case.
+func diffNamed(section string, old, new []string) []Change {
+	var changes []Change
+	key := func(s string) string { return normalizeKey(s) }
+	_, removed, added := matchEntities(old, new, key)
+	for _, i := range removed {
+		changes = append(changes, Change{Kind: ChangeRemoved, Section: section, Entity: old[i]})
+	}
+	for _, i := range added {
+		changes = append(changes, Change{Kind: ChangeAdded, Section: section, Entity: new[i]})
+	}
+	return changes
+}
+
+// diffItems reports short list items, such as technologies, added to or
+// removed from an entity.
+func diffItems(section, entity, field string, old, new []string) []Change {
+	var changes []Change
+	for _, c := range diffNamed(section, old, new) {
+		item := c.Entity
+		c.Entity, c.Field = entity, field
+		if c.Kind == ChangeAdded {
+			c.New = item
+		} else {
+			c.Old = item
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// diffBullets reports bullets added, removed and reworded between two lists.
+func diffBullets(section, entity, field string, old, new []string) []Change {
+	var changes []Change
+	for _, op := range alignBullets(old, new) {
+		switch {
+		case op.old < 0:
+			changes = append(changes, Change{Kind: ChangeAdded, Section: section, Entity: entity, Field: field, New: new[op.new]})
+		case op.new < 0:
+			changes = append(changes, Change{Kind: ChangeRemoved, Section: section, Entity: entity, Field: field, Old: old[op.old]})
+		case old[op.old] != new[op.new]:
+			changes = append(changes, Change{Kind: ChangeReworded, Section: section, Entity: entity, Field: field, Old: old[op.old], New: new[op.new]})
+		}
+	}
+	return changes
+}
+
+// diffText reports a changed free-text field.
+func diffText(section, entity, field, old, new string) []Change {
+	old, new = strings.TrimSpace(old), strings.TrimSpace(new)
+	switch {
+	case old == new:
+		return nil
+	case old == "":
+		return []Change{{Kind: ChangeAdded, Section: section, Entity: entity, Field: field, New: new}}
+	case new == "":
+		return []Change{{Kind: ChangeRemoved, Section: section, Entity: entity, Field: field, Old: old}}
+	default:
+		return []Change{{Kind: ChangeReworded, Section: section, Entity: entity, Field: field, Old: old, New: new}}
+	}
+}
+
+func modified(section, entity, field, old, new string) Change {
+	kind := ChangeModified
+	switch {
+	case old == "":
+		kind = ChangeAdded
+	case new == "":
+		kind = ChangeRemoved
+	}
+	return Change{Kind: kind, Section: section, Entity: entity, Field: field, Old: old, New: new}
+}
+
+// matchEntities pairs items of old and new whose keys agree. Keys are tried
+// in order, so a precise key (company and title) is preferred over a looser
+// fallback (company alone). Empty keys never match. It returns the matched
+// index pairs in new's order and the unmatched indices of each list.
+func matchEntities[T any](old, new []T, keys ...func(T) string) (pairs [][2]int, removed, added []int) {
+	oldMatched := make([]bool, len(old))
+	newMatch := make([]int, len(new))
+	for j := range newMatch {
+		newMatch[j] = -1
+	}
+
+	for _, key := range keys {
+		for j := range new {
+			if newMatch[j] >= 0 {
+				continue
+			}
+			k := key(new[j])
+			if k == "" {
+				continue
+			}
+			for i := range old {
+				if !oldMatched[i] && key(old[i]) == k {
+					oldMatched[i], newMatch[j] = true, i
+					break
+				}
+			}
+		}
+	}
+
+	for i, matched := range oldMatched {
+		if !matched {
+			removed = append(removed, i)
+		}
+	}
+	for j, i := range newMatch {
+		if i >= 0 {
+			pairs = append(pairs, [2]int{i, j})
+		} else {
+			added = append(added, j)
+		}
+	}
+	return pairs, removed, added
+}
+
+// bulletOp aligns one old bullet with one new bullet; an index of -1 marks a
+// removal or an addition.
+type bulletOp struct {
+	old, new int
+}
+
+// alignBullets matches identical bullets first, then pairs the remaining
+// ones by word overlap so small rewrites read as rewording. Operations come
+// in new's order, with removed bullets placed before the bullet that follows
+// them in old.
+func alignBullets(old, new []string) []bulletOp {
+	oldMatch := make([]int, len(old))
+	newMatch := make([]int, len(new))
+	for i := range oldMatch {
+		oldMatch[i] = -1
+	}
+	for j := range newMatch {
+		newMatch[j] = -1
+	}
+
+	for j, b := range new {
+		for i, a := range old {
+			if oldMatch[i] < 0 && strings.TrimSpace(a) == strings.TrimSpace(b) {
+				oldMatch[i], newMatch[j] = j, i
+				break
+			}
+		}
+	}
+	for j, b := range new {
+		if newMatch[j] >= 0 {
+			continue
+		}
+		best, bestScore := -1, rewordThreshold
+		for i, a := range old {
+			if oldMatch[i] >= 0 {
+				continue
+			}
+			if score := wordOverlap(a, b); score >= bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 {
+			oldMatch[best], newMatch[j] = j, best
+		}
+	}
+
+	var ops []bulletOp
+	next := 0 // first old bullet not yet emitted as a removal
+	for j := range new {
+		if i := newMatch[j]; i >= 0 {
+			for ; next < i; next++ {
+				if oldMatch[next] < 0 {
+					ops = append(ops, bulletOp{old: next, new: -1})
+				}
+			}
+			if next == i {
+				next++
+			}
+		}
+		ops = append(ops, bulletOp{old: newMatch[j], new: j})
+	}
+	for ; next < len(old); next++ {
+		if oldMatch[next] < 0 {
+			ops = append(ops, bulletOp{old: next, new: -1})
+		}
+	}
+	return ops
+}
+
+// wordOverlap is the Jaccard similarity of the lower-cased words of a and b.
+func wordOverlap(a, b string) float64 {
+	wordsA := wordSet(a)
+	wordsB := wordSet(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(strings.ToLower(s)) {
+		if w = strings.Trim(w, ".,;:!?()\"'"); w != "" {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+func normalizeKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func positionKey(e Experience) string {
+	return normalizeKey(e.Company + "|" + e.Title)
+}
+
+func positionName(e Experience) string {
+	switch {
+	case e.Title != "" && e.Company != "":
+		return e.Title + " at " + e.Company
+	case e.Title != "":
+		return e.Title
+	default:
+		return e.Company
+	}
+}
+
+func institutionName(e Education) string {
+	if e.Degree.Name == "" {
+		return e.Institution
+	}
+	return e.Degree.Name + ", " + e.Institution
+}
+
+// DateRangeText renders a date range as "Jan 2020 – Present" for reports.
+func DateRangeText(d *DateRange) string {
+	if d == nil || d.Start.IsZero() {
+		return ""
+	}
+	end := "Present"
+	if d.End != nil && !d.End.IsZero() {
+		end = d.End.Format("Jan 2006")
+	}
+	return d.Start.Format("Jan 2006") + " – " + end
+}
+
+func locationText(l *Location) string {
+	if l == nil {
+		return ""
+	}
+	var parts []string
+	for _, p := range []string{l.City, l.State, l.Province, l.Country} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if l.Remote {
+		parts = append(parts, "Remote")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func gpaText(g *GPA) string {
+	if g == nil || g.GPA == "" {
+		return ""
+	}
+	if g.MaxGPA == "" {
+		return g.GPA
+	}
+	return g.GPA + "/" + g.MaxGPA
+}
+
+func projectsOf(r *Resume) []Project {
+	if r.Projects == nil {
+		return nil
+	}
+	return r.Projects.Projects
+}
+
+func certificationNames(r *Resume) []string {
+	if r.Certifications == nil {
+		return nil
+	}
+	names := make([]string, len(r.Certifications.Items))
+	for i, c := range r.Certifications.Items {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func languageNames(r *Resume) []string {
+	if r.Languages == nil {
+		return nil
+	}
+	names := make([]string, len(r.Languages.Languages))
+	for i, l := range r.Languages.Languages {
+		names[i] = l.Name
+	}
+	return names
+}
+
+func refereeNames(r *Resume) []string {
+	if r.References == nil {
+		return nil
+	}
+	names := make([]string, len(r.References.Referees))
+	for i, ref := range r.References.Referees {
+		names[i] = ref.Name
+	}
+	return names
+}
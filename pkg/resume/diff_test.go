@@ -0,0 +1,103 @@
+package resume
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffResumes(t *testing.T) {
+	old := loadTestResume(t)
+	new := loadTestResume(t)
+
+	// Reordering positions is not a change.
+	positions := new.Experience.Positions
+	positions[0], positions[1] = positions[1], positions[0]
+
+	// positions[1] is now the original first position.
+	positions[1].Highlights[0] = strings.TrimSuffix(positions[1].Highlights[0], ".") + " across teams."
+	positions[1].Highlights = append(positions[1].Highlights, "Mentored two junior engineers.")
+	end := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	positions[1].Dates.End = &end
+	new.Experience.Positions = append(new.Experience.Positions, Experience{Company: "Initech", Title: "Staff Engineer"})
+	new.Skills.Categories[0].Items = append(new.Skills.Categories[0].Items, SkillItem{Name: "Go"})
+
+	changes := DiffResumes(old, new)
+	got := make(map[string]bool, len(changes))
+	for _, c := range changes {
+		got[c.Kind+"|"+c.Section+"|"+c.Entity+"|"+c.Field] = true
+	}
+
+	entity := positionName(old.Experience.Positions[0])
+	want := []string{
+		ChangeReworded + "|experience|" + entity + "|highlight",
+		ChangeAdded + "|experience|" + entity + "|highlight",
+		ChangeModified + "|experience|" + entity + "|dates",
+		ChangeAdded + "|experience|Staff Engineer at Initech|",
+		ChangeAdded + "|skills|" + old.Skills.Categories[0].Category + "|skill",
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("missing change %s", w)
+		}
+	}
+	if len(changes) != len(want) {
+		for _, c := range changes {
+			t.Log(c)
+		}
+		t.Errorf("got %d changes, want %d", len(changes), len(want))
+	}
+
+	if changes := DiffResumes(old, loadTestResume(t)); len(changes) != 0 {
+		t.Errorf("identical resumes reported %v", changes)
+	}
+}
+
+func TestAlignBullets(t *testing.T) {
+	old := []string{"Kept bullet", "Dropped entirely", "Built the billing service in Go"}
+	new := []string{"Kept bullet", "Built the billing service in Go and Rust", "Brand new"}
+
+	ops := alignBullets(old, new)
+	want := []bulletOp{{0, 0}, {1, -1}, {2, 1}, {-1, 2}}
+	if len(ops) != len(want) {
+		t.Fatalf("alignBullets() = %v, want %v", ops, want)
+	}
+	for i := range want {
+		if ops[i] != want[i] {
+			t.Errorf("ops[%d] = %v, want %v", i, ops[i], want[i])
+		}
+	}
+}
+
+func TestMarkWords(t *testing.T) {
+	got := markWords("Led a team of five engineers", "Led a team of eight engineers")
+	want := "Led a team of " + RedlineDeleteStart + "five" + RedlineDeleteEnd + " " + RedlineInsertStart + "eight" + RedlineInsertEnd + " engineers"
+	if got != want {
+		t.Errorf("markWords() = %q, want %q", got, want)
+	}
+	if got := markWords("", "New"); got != RedlineInsertStart+"New"+RedlineInsertEnd {
+		t.Errorf("markWords() for added text = %q", got)
+	}
+	if got := markWords("Same", "Same"); got != "Same" {
+		t.Errorf("markWords() for equal text = %q", got)
+	}
+}
+
+func TestRedline(t *testing.T) {
+	old := loadTestResume(t)
+	new := loadTestResume(t)
+	removed := new.Experience.Positions[0]
+	new.Experience.Positions = new.Experience.Positions[1:]
+
+	merged := Redline(old, new)
+	if len(merged.Experience.Positions) != len(old.Experience.Positions) {
+		t.Fatalf("removed positions should stay in the redline, got %d", len(merged.Experience.Positions))
+	}
+	last := merged.Experience.Positions[len(merged.Experience.Positions)-1]
+	if last.Company != RedlineDeleteStart+removed.Company+RedlineDeleteEnd {
+		t.Errorf("removed position company = %q", last.Company)
+	}
+	if strings.Contains(new.Experience.Positions[0].Company, RedlineDeleteStart) || strings.Contains(old.Experience.Positions[0].Company, RedlineDeleteStart) {
+		t.Error("Redline must not modify its arguments")
+	}
+}
@@ -0,0 +1,40 @@
+package resume
+
+import "sort"
+
+// SplitPositions sorts positions newest first by start date and partitions
+// their indexes into detailed entries and collapsed "earlier" entries
+// according to the layout's CollapseBefore and MaxDetailedPositions
+// settings. A nil layout, or one with neither setting, returns every
+// position as detailed. Renderers and Tighten both split through it, so
+// they agree on which positions show their highlights.
+func SplitPositions(positions []Experience, layout *Layout) (detailed, earlier []int) {
+	order := make([]int, len(positions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return positions[order[a]].Dates.Start.After(positions[order[b]].Dates.Start)
+	})
+	if layout == nil || (layout.CollapseBefore <= 0 && layout.MaxDetailedPositions <= 0) {
+		return order, nil
+	}
+
+	for _, idx := range order {
+		end := positions[idx].Dates.End
+		collapse := false
+		if layout.CollapseBefore > 0 && end != nil && !end.IsZero() && end.Year() < layout.CollapseBefore {
+			collapse = true
+		}
+		if layout.MaxDetailedPositions > 0 && len(detailed) >= layout.MaxDetailedPositions {
+			collapse = true
+		}
+
+		if collapse {
+			earlier = append(earlier, idx)
+		} else {
+			detailed = append(detailed, idx)
+		}
+	}
+	return detailed, earlier
+}
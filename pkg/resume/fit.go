@@ -0,0 +1,82 @@
+package resume
+
+import (
+	"fmt"
+	"strings"
+)
+
+// densitySteps lists layout densities from the most to the least spacious.
+var densitySteps = []string{"detailed", "standard", "compact"}
+
+// Tighten applies the next adjustment that shortens the rendered resume and
+// describes it. Adjustments are tried in order: a denser layout one step at a
+// time, the classic typography (the narrowest face in every template), two
+// skill columns, and finally dropping the last highlight of the oldest
+// detailed position that still has more than one. It returns false when
+// nothing is left to adjust.
+//
+// Tighten replaces Layout and Experience.Positions rather than modifying
+// them, so a shallow copy of the resume taken beforehand is left intact.
+func (r *Resume) Tighten() (string, bool) {
+	layout := Layout{}
+	if r.Layout != nil {
+		layout = *r.Layout
+	}
+
+	density := layout.Density
+	if density == "" {
+		density = "standard"
+	}
+	for i, step := range densitySteps[:len(densitySteps)-1] {
+		if density == step {
+			layout.Density = densitySteps[i+1]
+			r.Layout = &layout
+			return fmt.Sprintf("layout.density: %s → %s", density, layout.Density), true
+		}
+	}
+
+	if layout.Typography != "" && layout.Typography != "classic" {
+		previous := layout.Typography
+		layout.Typography = "classic"
+		r.Layout = &layout
+		return fmt.Sprintf("layout.typography: %s → classic", previous), true
+	}
+
+	if layout.SkillColumns < 2 && len(r.Skills.Categories) > 1 {
+		previous := max(layout.SkillColumns, 1)
+		layout.SkillColumns = 2
+		r.Layout = &layout
+		return fmt.Sprintf("layout.skill_columns: %d → 2", previous), true
+	}
+
+	detailed, _ := SplitPositions(r.Experience.Positions, r.Layout)
+	for i := len(detailed) - 1; i >= 0; i-- {
+		idx := detailed[i]
+		p := r.Experience.Positions[idx]
+		if len(p.Highlights) < 2 {
+			continue
+		}
+		dropped := p.Highlights[len(p.Highlights)-1]
+		p.Highlights = p.Highlights[:len(p.Highlights)-1]
+
+		positions := make([]Experience, len(r.Experience.Positions))
+		copy(positions, r.Experience.Positions)
+		positions[idx] = p
+		r.Experience.Positions = positions
+		return fmt.Sprintf("dropped the last highlight of %s: %q", p.Label(), dropped), true
+	}
+	return "", false
+}
+
+// Label names a position as "Title at Company", or by whichever of the two
+// is set.
+func (e Experience) Label() string {
+	title, company := strings.TrimSpace(e.Title), strings.TrimSpace(e.Company)
+	switch {
+	case title == "":
+		return company
+	case company == "":
+		return title
+	}
+	return title + " at " + company
+}
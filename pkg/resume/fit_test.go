@@ -0,0 +1,83 @@
+package resume
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTighten(t *testing.T) {
+	oldEnd := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)
+	midEnd := time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC)
+	original := &Resume{
+		Skills: Skills{Categories: []SkillCategory{
+			{Category: "Languages", Items: SkillItemsFromNames("Go")},
+			{Category: "Tools", Items: SkillItemsFromNames("Docker")},
+		}},
+		Experience: ExperienceList{Positions: []Experience{
+			{Title: "Staff Engineer", Company: "Acme", Highlights: []string{"Led", "Built"}, Dates: DateRange{Start: time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC)}},
+			{Title: "Intern", Company: "Initech", Highlights: []string{"Filed", "Fixed"}, Dates: DateRange{Start: time.Date(2014, time.June, 1, 0, 0, 0, 0, time.UTC), End: &oldEnd}},
+			{Title: "Engineer", Company: "Globex", Highlights: []string{"Shipped", "Tuned", "Scaled"}, Dates: DateRange{Start: time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC), End: &midEnd}},
+		}},
+		Layout: &Layout{Density: "detailed", Typography: "elegant", CollapseBefore: 2016},
+	}
+
+	r := *original
+	var changes []string
+	for {
+		change, ok := r.Tighten()
+		if !ok {
+			break
+		}
+		changes = append(changes, change)
+	}
+
+	want := []string{
+		"layout.density: detailed → standard",
+		"layout.density: standard → compact",
+		"layout.typography: elegant → classic",
+		"layout.skill_columns: 1 → 2",
+		`dropped the last highlight of Engineer at Globex: "Scaled"`,
+		`dropped the last highlight of Engineer at Globex: "Tuned"`,
+		`dropped the last highlight of Staff Engineer at Acme: "Built"`,
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Tighten() changes =\n%q\nwant\n%q", changes, want)
+	}
+
+	// The collapsed internship keeps its highlights, and the original is
+	// untouched.
+	if got := r.Experience.Positions[1].Highlights; len(got) != 2 {
+		t.Errorf("collapsed position highlights = %q, want both kept", got)
+	}
+	if original.Layout.Density != "detailed" || len(original.Experience.Positions[2].Highlights) != 3 {
+		t.Error("Tighten() modified the resume it was copied from")
+	}
+}
+
+func TestTightenNothingLeft(t *testing.T) {
+	r := &Resume{Layout: &Layout{Density: "compact"}}
+	if change, ok := r.Tighten(); ok {
+		t.Errorf("Tighten() = %q, want nothing left to adjust", change)
+	}
+}
+
+func TestTightenTrimsRenderedPosition(t *testing.T) {
+	// The current job started first; renderers order by start date, so the
+	// 2018 job is the one detailed.
+	end := time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC)
+	r := &Resume{
+		Layout: &Layout{Density: "compact", MaxDetailedPositions: 1},
+		Experience: ExperienceList{Positions: []Experience{
+			{Title: "Principal", Company: "Acme", Highlights: []string{"Led", "Built"}, Dates: DateRange{Start: time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+			{Title: "Engineer", Company: "Globex", Highlights: []string{"Shipped", "Tuned"}, Dates: DateRange{Start: time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC), End: &end}},
+		}},
+	}
+	if detailed, earlier := SplitPositions(r.Experience.Positions, r.Layout); !reflect.DeepEqual(detailed, []int{1}) || !reflect.DeepEqual(earlier, []int{0}) {
+		t.Fatalf("SplitPositions() = %v, %v; want [1], [0]", detailed, earlier)
+	}
+	change, ok := r.Tighten()
+	if !ok || change != `dropped the last highlight of Engineer at Globex: "Tuned"` {
+		t.Errorf("Tighten() = %q, %v", change, ok)
+	}
+}
@@ -0,0 +1,146 @@
+package resume
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Image crop modes.
+const (
+	ImageCropNone   = "none"
+	ImageCropSquare = "square"
+	ImageCropCircle = "circle"
+)
+
+// Image references a local image file such as a profile photo, company logo
+// or project thumbnail. Relative paths are resolved against the directory of
+// the input file.
+type Image struct {
+	Path string `json:"path" yaml:"path" toml:"path"`
+	Alt  string `json:"alt" yaml:"alt" toml:"alt"`
+	// Width and Height are in millimetres. When only one is set the other
+	// follows the image's aspect ratio; when neither is set the template
+	// picks a default.
+	Width  float64 `json:"width,omitempty" yaml:"width,omitempty" toml:"width,omitempty"`
+	Height float64 `json:"height,omitempty" yaml:"height,omitempty" toml:"height,omitempty"`
+	// Crop is one of "none" (default), "square" or "circle".
+	Crop string `json:"crop,omitempty" yaml:"crop,omitempty" toml:"crop,omitempty"`
+
+	resolved string
+}
+
+// ResolvedPath returns the absolute path set by ResolveImages, falling back
+// to Path when the image has not been resolved.
+func (img *Image) ResolvedPath() string {
+	if img == nil {
+		return ""
+	}
+	if img.resolved != "" {
+		return img.resolved
+	}
+	return img.Path
+}
+
+// CropMode returns the normalized crop mode.
+func (img *Image) CropMode() string {
+	if img == nil {
+		return ImageCropNone
+	}
+	switch strings.ToLower(strings.TrimSpace(img.Crop)) {
+	case ImageCropSquare:
+		return ImageCropSquare
+	case ImageCropCircle:
+		return ImageCropCircle
+	default:
+		return ImageCropNone
+	}
+}
+
+// Images returns every image referenced by the resume paired with its field
+// path, in document order.
+func (r *Resume) Images() []FieldImage {
+	var images []FieldImage
+	if r.Contact.Photo != nil {
+		images = append(images, FieldImage{Field: "contact.photo", Image: r.Contact.Photo})
+	}
+	for i := range r.Experience.Positions {
+		if logo := r.Experience.Positions[i].Logo; logo != nil {
+			images = append(images, FieldImage{Field: fmt.Sprintf("experience.positions[%d].logo", i), Image: logo})
+		}
+	}
+	if r.Projects != nil {
+		for i := range r.Projects.Projects {
+			if thumb := r.Projects.Projects[i].Thumbnail; thumb != nil {
+				images = append(images, FieldImage{Field: fmt.Sprintf("projects.projects[%d].thumbnail", i), Image: thumb})
+			}
+		}
+	}
+	return images
+}
+
+// FieldImage pairs an image with the field path it was declared at.
+type FieldImage struct {
+	Field string
+	Image *Image
+}
+
+// ResolveImages resolves relative image paths against baseDir. The original
+// Path is kept so the resume serializes unchanged.
+func (r *Resume) ResolveImages(baseDir string) {
+	for _, fi := range r.Images() {
+		path := strings.TrimSpace(fi.Image.Path)
+		if path == "" || filepath.IsAbs(path) {
+			continue
+		}
+		fi.Image.resolved = filepath.Join(baseDir, path)
+	}
+}
+
+func validateImages(r *Resume) []ValidationError {
+	var errors []ValidationError
+	for _, fi := range r.Images() {
+		img := fi.Image
+		if strings.TrimSpace(img.Path) == "" {
+			errors = append(errors, ValidationError{
+				Field:   fi.Field + ".path",
+				Message: "Image path is required",
+				Type:    "required",
+			})
+		} else {
+			switch strings.ToLower(filepath.Ext(img.Path)) {
+			case ".png", ".jpg", ".jpeg":
+			default:
+				errors = append(errors, ValidationError{
+					Field:   fi.Field + ".path",
+					Message: "Image must be a PNG or JPEG file",
+					Type:    "invalid",
+					Value:   img.Path,
+				})
+			}
+		}
+		if strings.TrimSpace(img.Alt) == "" {
+			errors = append(errors, ValidationError{
+				Field:   fi.Field + ".alt",
+				Message: "Image alt text is required",
+				Type:    "required",
+			})
+		}
+		if img.Crop != "" && img.CropMode() == ImageCropNone && !strings.EqualFold(img.Crop, ImageCropNone) {
+			errors = append(errors, ValidationError{
+				Field:   fi.Field + ".crop",
+				Message: "Unknown crop mode; expected none, square or circle",
+				Type:    "invalid",
+				Value:   img.Crop,
+			})
+		}
+		if img.Width < 0 || img.Height < 0 {
+			errors = append(errors, ValidationError{
+				Field:   fi.Field,
+				Message: "Image width and height must not be negative",
+				Type:    "invalid",
+			})
+		}
+	}
+	return errors
+}
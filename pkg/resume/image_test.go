@@ -0,0 +1,69 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateImages(t *testing.T) {
+	r := &Resume{
+		Contact: Contact{
+			Name:  "Jane",
+			Email: "jane@example.com",
+			Photo: &Image{Path: "photo.png", Alt: "Jane"},
+		},
+		Experience: ExperienceList{Positions: []Experience{
+			{Company: "Acme", Logo: &Image{Path: "logo.svg"}},
+		}},
+		Projects: &ProjectList{Projects: []Project{
+			{Name: "Tool", Thumbnail: &Image{Path: "thumb.jpg", Alt: "Tool", Crop: "hexagon"}},
+		}},
+	}
+
+	got := map[string]bool{}
+	for _, e := range Validate(r) {
+		got[e.Field] = true
+	}
+
+	want := []string{
+		"experience.positions[0].logo.path",
+		"experience.positions[0].logo.alt",
+		"projects.projects[0].thumbnail.crop",
+	}
+	if len(got) != len(want) {
+		t.Errorf("Validate() fields = %v, want %v", got, want)
+	}
+	for _, field := range want {
+		if !got[field] {
+			t.Errorf("expected a validation error for %s", field)
+		}
+	}
+}
+
+func TestLoadResumeFromFileResolvesImages(t *testing.T) {
+	dir := t.TempDir()
+	content := `contact:
+  name: Jane
+  email: jane@example.com
+  photo:
+    path: images/photo.png
+    alt: Jane
+`
+	path := filepath.Join(dir, "resume.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+
+	data, err := LoadResumeFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadResumeFromFile() error = %v", err)
+	}
+	photo := data.ToResume().Contact.Photo
+	if photo.Path != "images/photo.png" {
+		t.Errorf("Path = %q, want the original relative path", photo.Path)
+	}
+	if want := filepath.Join(dir, "images", "photo.png"); photo.ResolvedPath() != want {
+		t.Errorf("ResolvedPath() = %q, want %q", photo.ResolvedPath(), want)
+	}
+}
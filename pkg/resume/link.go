@@ -0,0 +1,177 @@
+package resume
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Recognized contact link types. A link's type is either set explicitly via
+// the "type" field or inferred from its URI.
+const (
+	LinkTypeGitHub   = "github"
+	LinkTypeLinkedIn = "linkedin"
+	LinkTypeWebsite  = "website"
+	LinkTypeORCID    = "orcid"
+	LinkTypeScholar  = "scholar"
+	LinkTypeMastodon = "mastodon"
+)
+
+// LinkTypes lists every recognized link type.
+var LinkTypes = []string{
+	LinkTypeGitHub,
+	LinkTypeLinkedIn,
+	LinkTypeWebsite,
+	LinkTypeORCID,
+	LinkTypeScholar,
+	LinkTypeMastodon,
+}
+
+var linkTypeNames = map[string]string{
+	LinkTypeGitHub:   "GitHub",
+	LinkTypeLinkedIn: "LinkedIn",
+	LinkTypeWebsite:  "Website",
+	LinkTypeORCID:    "ORCID",
+	LinkTypeScholar:  "Google Scholar",
+	LinkTypeMastodon: "Mastodon",
+}
+
+// IsLinkType reports whether t is a recognized link type.
+func IsLinkType(t string) bool {
+	_, ok := linkTypeNames[strings.ToLower(strings.TrimSpace(t))]
+	return ok
+}
+
+// LinkTypeName returns the human-readable name of a link type, e.g. "GitHub".
+func LinkTypeName(t string) string {
+	if name, ok := linkTypeNames[strings.ToLower(strings.TrimSpace(t))]; ok {
+		return name
+	}
+	return linkTypeNames[LinkTypeWebsite]
+}
+
+// LinkTypeFromName maps a human-readable name such as "GitHub" back to its
+// link type.
+func LinkTypeFromName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for t, n := range linkTypeNames {
+		if strings.EqualFold(n, name) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// atPathHosts are sites other than Mastodon that also put profiles at /@user.
+var atPathHosts = []string{
+	"medium.com",
+	"youtube.com",
+	"tiktok.com",
+	"substack.com",
+	"threads.net",
+	"pinterest.com",
+}
+
+// isAtPathHost reports whether host, or the domain it belongs to, is one of
+// atPathHosts.
+func isAtPathHost(host string) bool {
+	for _, h := range atPathHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// InferLinkType guesses a link type from its URI, falling back to website.
+func InferLinkType(uri string) string {
+	u := parseLinkURI(uri)
+	if u == nil {
+		return LinkTypeWebsite
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	switch {
+	case host == "github.com":
+		return LinkTypeGitHub
+	case host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com"):
+		return LinkTypeLinkedIn
+	case host == "orcid.org":
+		return LinkTypeORCID
+	case host == "scholar.google.com" || strings.HasPrefix(host, "scholar.google."):
+		return LinkTypeScholar
+	case strings.HasPrefix(u.Path, "/@") && !strings.Contains(strings.Trim(u.Path, "/"), "/") && !isAtPathHost(host):
+		return LinkTypeMastodon
+	default:
+		return LinkTypeWebsite
+	}
+}
+
+// ResolvedType returns the explicit type when set, otherwise the inferred one.
+func (l Link) ResolvedType() string {
+	if t := strings.ToLower(strings.TrimSpace(l.Type)); t != "" && IsLinkType(t) {
+		return t
+	}
+	return InferLinkType(l.URI)
+}
+
+// DisplayLabel returns the label to show for a link: the explicit label when
+// set, otherwise a canonical short form such as "github.com/user".
+func (l Link) DisplayLabel() string {
+	if label := strings.TrimSpace(l.Label); label != "" {
+		return label
+	}
+	return CanonicalLinkLabel(l.ResolvedType(), l.URI)
+}
+
+// CanonicalLinkLabel derives a short label for a URI of the given type.
+func CanonicalLinkLabel(linkType, uri string) string {
+	u := parseLinkURI(uri)
+	if u == nil {
+		return strings.TrimSpace(uri)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.Trim(u.Path, "/")
+	segments := strings.Split(path, "/")
+
+	switch linkType {
+	case LinkTypeGitHub:
+		if path != "" {
+			return host + "/" + segments[0]
+		}
+	case LinkTypeLinkedIn:
+		if len(segments) >= 2 {
+			return host + "/" + segments[0] + "/" + segments[1]
+		}
+	case LinkTypeORCID:
+		if path != "" {
+			return host + "/" + segments[len(segments)-1]
+		}
+	case LinkTypeScholar:
+		return LinkTypeName(LinkTypeScholar)
+	case LinkTypeMastodon:
+		if user := strings.TrimPrefix(segments[0], "@"); user != "" {
+			return "@" + user + "@" + host
+		}
+	}
+
+	if path == "" {
+		return host
+	}
+	return host + "/" + path
+}
+
+func parseLinkURI(uri string) *url.URL {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return nil
+	}
+	if !strings.Contains(uri, "://") {
+		uri = "https://" + uri
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	return u
+}
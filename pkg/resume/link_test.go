@@ -0,0 +1,85 @@
+package resume
+
+import "testing"
+
+func TestInferLinkType(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"https://github.com/janedoe", LinkTypeGitHub},
+		{"github.com/janedoe", LinkTypeGitHub},
+		{"https://www.linkedin.com/in/janedoe/", LinkTypeLinkedIn},
+		{"https://ca.linkedin.com/in/janedoe", LinkTypeLinkedIn},
+		{"https://orcid.org/0000-0002-1825-0097", LinkTypeORCID},
+		{"https://scholar.google.com/citations?user=abc123", LinkTypeScholar},
+		{"https://scholar.google.co.uk/citations?user=abc123", LinkTypeScholar},
+		{"https://mastodon.social/@janedoe", LinkTypeMastodon},
+		{"https://janedoe.dev", LinkTypeWebsite},
+		{"https://janedoe.dev/@janedoe/posts", LinkTypeWebsite},
+		{"https://medium.com/@janedoe", LinkTypeWebsite},
+		{"https://www.youtube.com/@janedoe", LinkTypeWebsite},
+		{"https://m.youtube.com/@janedoe", LinkTypeWebsite},
+		{"", LinkTypeWebsite},
+	}
+
+	for _, tt := range tests {
+		if got := InferLinkType(tt.uri); got != tt.want {
+			t.Errorf("InferLinkType(%q) = %q, want %q", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestLinkDisplayLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		link Link
+		want string
+	}{
+		{"github profile", Link{URI: "https://github.com/janedoe"}, "github.com/janedoe"},
+		{"github repo trimmed to user", Link{URI: "https://github.com/janedoe/tool"}, "github.com/janedoe"},
+		{"linkedin", Link{URI: "https://www.linkedin.com/in/janedoe/"}, "linkedin.com/in/janedoe"},
+		{"orcid", Link{URI: "https://orcid.org/0000-0002-1825-0097"}, "orcid.org/0000-0002-1825-0097"},
+		{"scholar", Link{URI: "https://scholar.google.com/citations?user=abc123"}, "Google Scholar"},
+		{"mastodon", Link{URI: "https://fosstodon.org/@janedoe"}, "@janedoe@fosstodon.org"},
+		{"website", Link{URI: "https://www.janedoe.dev/blog/"}, "janedoe.dev/blog"},
+		{"explicit label wins", Link{URI: "https://github.com/janedoe", Label: "Code"}, "Code"},
+		{"explicit type", Link{URI: "https://git.example.com/janedoe", Type: "github"}, "git.example.com/janedoe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.DisplayLabel(); got != tt.want {
+				t.Errorf("DisplayLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkResolvedType(t *testing.T) {
+	if got := (Link{URI: "https://janedoe.dev", Type: "Mastodon"}).ResolvedType(); got != LinkTypeMastodon {
+		t.Errorf("explicit type: got %q, want %q", got, LinkTypeMastodon)
+	}
+	if got := (Link{URI: "https://github.com/janedoe", Type: "bogus"}).ResolvedType(); got != LinkTypeGitHub {
+		t.Errorf("unknown type should fall back to inference: got %q", got)
+	}
+}
+
+func TestValidateLinkType(t *testing.T) {
+	r := &Resume{Contact: Contact{
+		Name:  "Jane",
+		Email: "jane@example.com",
+		Links: []Link{
+			{URI: "https://github.com/janedoe", Type: "github"},
+			{URI: "https://example.com", Type: "myspace"},
+		},
+	}}
+
+	errs := Validate(r)
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, want 1: %v", len(errs), errs)
+	}
+	if errs[0].Field != "contact.links[1].type" {
+		t.Errorf("Field = %q, want contact.links[1].type", errs[0].Field)
+	}
+}
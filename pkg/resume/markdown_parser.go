@@ -263,10 +263,22 @@ func parseContactLine(line string, r *Resume) {
 		}
 
 		// Check for regular link → contact link
-		if m := reLink.FindStringSubmatch(part); m != nil {
-			label := strings.TrimSpace(m[1])
-			uri := strings.TrimSpace(m[2])
-			r.Contact.Links = append(r.Contact.Links, Link{URI: uri, Label: label})
+		if loc := reLink.FindStringSubmatchIndex(part); loc != nil {
+			link := Link{
+				URI:   strings.TrimSpace(part[loc[4]:loc[5]]),
+				Label: strings.TrimSpace(part[loc[2]:loc[3]]),
+			}
+			// A "GitHub: " style prefix records the link type, kept only when
+			// the URI would not imply it anyway.
+			prefix := strings.TrimSuffix(strings.TrimSpace(part[:loc[0]]), ":")
+			if t, ok := LinkTypeFromName(prefix); ok && t != InferLinkType(link.URI) {
+				link.Type = t
+			}
+			// Canonical labels are derived at render time, so only keep custom ones.
+			if link.Label == CanonicalLinkLabel(link.ResolvedType(), link.URI) {
+				link.Label = ""
+			}
+			r.Contact.Links = append(r.Contact.Links, link)
 			continue
 		}
 
@@ -323,7 +335,7 @@ func parseSkillLine(content string, r *Resume) {
 	}
 	r.Skills.Categories = append(r.Skills.Categories, SkillCategory{
 		Category: category,
-		Items:    cleaned,
+		Items:    SkillItemsFromNames(cleaned...),
 	})
 }
 
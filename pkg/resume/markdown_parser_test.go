@@ -107,9 +107,9 @@ func TestParseMarkdownFullResume(t *testing.T) {
 		t.Fatalf("expected 2 skill categories, got %d", len(r.Skills.Categories))
 	}
 	assertEqual(t, "skills[0].category", "Programming Languages", r.Skills.Categories[0].Category)
-	assertSliceEqual(t, "skills[0].items", []string{"Python", "Java", "C++"}, r.Skills.Categories[0].Items)
+	assertSliceEqual(t, "skills[0].items", []string{"Python", "Java", "C++"}, SkillNames(r.Skills.Categories[0].Items))
 	assertEqual(t, "skills[1].category", "Tools & Frameworks", r.Skills.Categories[1].Category)
-	assertSliceEqual(t, "skills[1].items", []string{"AWS", "Docker", "React"}, r.Skills.Categories[1].Items)
+	assertSliceEqual(t, "skills[1].items", []string{"AWS", "Docker", "React"}, SkillNames(r.Skills.Categories[1].Items))
 
 	// Experience
 	assertEqual(t, "experience.title", "Professional Experience", r.Experience.Title)
@@ -398,3 +398,24 @@ func assertDateMonth(t *testing.T, field string, year int, month time.Month, d t
 		t.Errorf("%s: expected %d-%s, got %v", field, year, month, d)
 	}
 }
+
+func TestParseMarkdownContactLinkPrefix(t *testing.T) {
+	md := "# Jane Doe\n\n[jane@email.com](mailto:jane@email.com) | GitHub: [github.com/janedoe](https://github.com/janedoe) | [Blog](https://janedoe.dev) | ORCID: [ORCID](https://id.example.org/0000-0001)\n"
+
+	r, err := parseMarkdown([]byte(md))
+	if err != nil {
+		t.Fatalf("parseMarkdown() error = %v", err)
+	}
+	if len(r.Contact.Links) != 3 {
+		t.Fatalf("got %d links, want 3", len(r.Contact.Links))
+	}
+	if got := r.Contact.Links[0]; got.Type != "" || got.ResolvedType() != LinkTypeGitHub || got.Label != "" {
+		t.Errorf("links[0] = %+v, want implied github type with canonical label dropped", got)
+	}
+	if got := r.Contact.Links[1]; got.Type != "" || got.Label != "Blog" {
+		t.Errorf("links[1] = %+v, want custom label kept", got)
+	}
+	if got := r.Contact.Links[2]; got.Type != LinkTypeORCID {
+		t.Errorf("links[2] = %+v, want explicit orcid type kept", got)
+	}
+}
@@ -0,0 +1,77 @@
+package resume
+
+import (
+	"strings"
+)
+
+// DocumentInfo is the document metadata written into rendered PDFs: the
+// Info dictionary and XMP for HTML output, hyperref's pdfinfo for LaTeX.
+// Applicant tracking and document management systems index these fields.
+type DocumentInfo struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords []string
+}
+
+// DocumentInfo returns the document metadata for the resume. The title is
+// the candidate's name, the subject is the target role and the keywords are
+// the skill names in the order they are listed.
+func (r *Resume) DocumentInfo() DocumentInfo {
+	name := strings.TrimSpace(r.Contact.Name)
+	info := DocumentInfo{
+		Title:   "Resume",
+		Author:  name,
+		Subject: r.TargetRole(),
+	}
+	if name != "" {
+		info.Title = name + " - Resume"
+	}
+
+	seen := make(map[string]bool)
+	for _, category := range r.Skills.Categories {
+		for _, item := range category.Items {
+			key := skillKey(item.Name)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			info.Keywords = append(info.Keywords, strings.TrimSpace(item.Name))
+		}
+	}
+	return info
+}
+
+// TargetRole returns the title of the most recent position: the current one
+// with the latest start date, or else the one that ended last. It is empty
+// when the resume lists no positions.
+func (r *Resume) TargetRole() string {
+	var best *Experience
+	for i := range r.Experience.Positions {
+		p := &r.Experience.Positions[i]
+		if strings.TrimSpace(p.Title) == "" {
+			continue
+		}
+		if best == nil || moreRecent(p.Dates, best.Dates) {
+			best = p
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return strings.TrimSpace(best.Title)
+}
+
+// moreRecent reports whether a is more recent than b. Ongoing ranges are more
+// recent than finished ones; ties are broken by start date.
+func moreRecent(a, b DateRange) bool {
+	switch {
+	case a.End == nil && b.End != nil:
+		return true
+	case a.End != nil && b.End == nil:
+		return false
+	case a.End != nil && !a.End.Equal(*b.End):
+		return a.End.After(*b.End)
+	}
+	return a.Start.After(b.Start)
+}
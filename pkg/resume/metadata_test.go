@@ -0,0 +1,45 @@
+package resume
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTargetRole(t *testing.T) {
+	end := time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+	r := &Resume{Experience: ExperienceList{Positions: []Experience{
+		{Title: "Engineer", Dates: DateRange{Start: time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC), End: &end}},
+		{Title: "Staff Engineer", Dates: DateRange{Start: time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)}},
+		{Title: "Advisor", Dates: DateRange{Start: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+	}}}
+	if got := r.TargetRole(); got != "Staff Engineer" {
+		t.Errorf("TargetRole() = %q, want the latest ongoing position", got)
+	}
+
+	r.Experience.Positions = r.Experience.Positions[:1]
+	if got := r.TargetRole(); got != "Engineer" {
+		t.Errorf("TargetRole() = %q, want Engineer", got)
+	}
+	if got := (&Resume{}).TargetRole(); got != "" {
+		t.Errorf("TargetRole() without positions = %q", got)
+	}
+}
+
+func TestDocumentInfo(t *testing.T) {
+	r := &Resume{
+		Contact: Contact{Name: "Jane Doe"},
+		Skills: Skills{Categories: []SkillCategory{
+			{Category: "Languages", Items: SkillItemsFromNames("Go", "Python")},
+			{Category: "Tools", Items: SkillItemsFromNames("go", "Docker")},
+		}},
+		Experience: ExperienceList{Positions: []Experience{{Title: "Backend Engineer"}}},
+	}
+
+	info := r.DocumentInfo()
+	if info.Title != "Jane Doe - Resume" || info.Author != "Jane Doe" || info.Subject != "Backend Engineer" {
+		t.Errorf("DocumentInfo() = %+v", info)
+	}
+	if got := len(info.Keywords); got != 3 || info.Keywords[2] != "Docker" {
+		t.Errorf("Keywords = %v, want skills without duplicates", info.Keywords)
+	}
+}
@@ -0,0 +1,318 @@
+package resume
+
+import "strings"
+
+// Redline markers delimit inserted and deleted text in a resume built by
+// Redline. They are Unicode private-use characters, which do not occur in
+// resume data and pass through template escaping untouched, so renderers can
+// swap them for markup after rendering.
+const (
+	RedlineDeleteStart = "\uE000"
+	RedlineDeleteEnd   = "\uE001"
+	RedlineInsertStart = "\uE002"
+	RedlineInsertEnd   = "\uE003"
+)
+
+// Redline merges old into new for review: text added in new is wrapped in
+// insert markers, text removed from old is kept in place wrapped in delete
+// markers, and reworded text is marked word by word. Entities are matched the
+// same way as DiffResumes. Neither argument is modified.
+func Redline(old, new *Resume) *Resume {
+	merged := *new
+	merged.Summary = markWords(old.Summary, new.Summary)
+	merged.Skills.Categories = redlineSkills(old.Skills.Categories, new.Skills.Categories)
+	merged.Experience.Positions = redlinePositions(old.Experience.Positions, new.Experience.Positions)
+	merged.Education.Institutions = redlineEducation(old.Education.Institutions, new.Education.Institutions)
+
+	if old.Projects != nil || new.Projects != nil {
+		projects := ProjectList{}
+		if new.Projects != nil {
+			projects = *new.Projects
+		}
+		projects.Projects = redlineProjects(projectsOf(old), projectsOf(new))
+		merged.Projects = &projects
+	}
+	if old.Certifications != nil || new.Certifications != nil {
+		certs := Certifications{}
+		if new.Certifications != nil {
+			certs = *new.Certifications
+		}
+		var oldItems []Certification
+		if old.Certifications != nil {
+			oldItems = old.Certifications.Items
+		}
+		certs.Items = redlineNamed(oldItems, certs.Items, func(c *Certification) *string { return &c.Name })
+		merged.Certifications = &certs
+	}
+	if old.Languages != nil || new.Languages != nil {
+		langs := LanguageList{}
+		if new.Languages != nil {
+			langs = *new.Languages
+		}
+		var oldItems []Language
+		if old.Languages != nil {
+			oldItems = old.Languages.Languages
+		}
+		langs.Languages = redlineNamed(oldItems, langs.Languages, func(l *Language) *string { return &l.Name })
+		merged.Languages = &langs
+	}
+	if old.References != nil || new.References != nil {
+		refs := ReferenceList{}
+		if new.References != nil {
+			refs = *new.References
+		}
+		var oldItems []Referee
+		if old.References != nil {
+			oldItems = old.References.Referees
+		}
+		refs.Referees = redlineNamed(oldItems, refs.Referees, func(r *Referee) *string { return &r.Name })
+		merged.References = &refs
+	}
+	return &merged
+}
+
+func redlineSkills(old, new []SkillCategory) []SkillCategory {
+	key := func(c SkillCategory) string { return normalizeKey(c.Category) }
+	pairs, removed, added := matchEntities(old, new, key)
+
+	merged := make([]SkillCategory, len(new))
+	copy(merged, new)
+	for _, p := range pairs {
+		merged[p[1]].Items = redlineNamed(old[p[0]].Items, new[p[1]].Items, func(s *SkillItem) *string { return &s.Name })
+	}
+	for _, j := range added {
+		merged[j].Category = inserted(merged[j].Category)
+		merged[j].Items = markItems(merged[j].Items, inserted, func(s *SkillItem) *string { return &s.Name })
+	}
+	for _, i := range removed {
+		c := old[i]
+		c.Category = deleted(c.Category)
+		c.Items = markItems(c.Items, deleted, func(s *SkillItem) *string { return &s.Name })
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+func redlinePositions(old, new []Experience) []Experience {
+	pairs, removed, added := matchEntities(old, new, positionKey, func(e Experience) string { return normalizeKey(e.Company) })
+
+	merged := make([]Experience, len(new))
+	copy(merged, new)
+	for _, p := range pairs {
+		a, b := old[p[0]], &merged[p[1]]
+		b.Title = markWords(a.Title, b.Title)
+		b.Company = markWords(a.Company, b.Company)
+		b.Highlights = mergeBullets(a.Highlights, b.Highlights)
+		b.Duties = mergeBullets(a.Duties, b.Duties)
+		b.Technologies = mergeStrings(a.Technologies, b.Technologies)
+	}
+	for _, j := range added {
+		markPosition(&merged[j], inserted)
+	}
+	for _, i := range removed {
+		e := old[i]
+		markPosition(&e, deleted)
+		merged = append(merged, e)
+	}
+	return merged
+}
+
+func markPosition(e *Experience, mark func(string) string) {
+	e.Title = mark(e.Title)
+	e.Company = mark(e.Company)
+	e.Highlights = markAll(e.Highlights, mark)
+	e.Duties = markAll(e.Duties, mark)
+	e.Technologies = markAll(e.Technologies, mark)
+}
+
+func redlineProjects(old, new []Project) []Project {
+	pairs, removed, added := matchEntities(old, new,
+		func(p Project) string { return normalizeKey(p.Name) },
+		func(p Project) string { return normalizeKey(p.Link.URI) })
+
+	merged := make([]Project, len(new))
+	copy(merged, new)
+	for _, p := range pairs {
+		a, b := old[p[0]], &merged[p[1]]
+		b.Name = markWords(a.Name, b.Name)
+		b.Highlights = mergeBullets(a.Highlights, b.Highlights)
+		b.Technologies = mergeStrings(a.Technologies, b.Technologies)
+	}
+	markProject := func(p *Project, mark func(string) string) {
+		p.Name = mark(p.Name)
+		p.Highlights = markAll(p.Highlights, mark)
+		p.Technologies = markAll(p.Technologies, mark)
+	}
+	for _, j := range added {
+		markProject(&merged[j], inserted)
+	}
+	for _, i := range removed {
+		p := old[i]
+		markProject(&p, deleted)
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+func redlineEducation(old, new []Education) []Education {
+	pairs, removed, added := matchEntities(old, new,
+		func(e Education) string { return normalizeKey(e.Institution + "|" + e.Degree.Name) },
+		func(e Education) string { return normalizeKey(e.Institution) })
+
+	merged := make([]Education, len(new))
+	copy(merged, new)
+	for _, p := range pairs {
+		a, b := old[p[0]], &merged[p[1]]
+		b.Institution = markWords(a.Institution, b.Institution)
+		b.Degree.Name = markWords(a.Degree.Name, b.Degree.Name)
+		b.Degree.Descriptions = mergeBullets(a.Degree.Descriptions, b.Degree.Descriptions)
+	}
+	markInstitution := func(e *Education, mark func(string) string) {
+		e.Institution = mark(e.Institution)
+		e.Degree.Name = mark(e.Degree.Name)
+		e.Degree.Descriptions = markAll(e.Degree.Descriptions, mark)
+	}
+	for _, j := range added {
+		markInstitution(&merged[j], inserted)
+	}
+	for _, i := range removed {
+		e := old[i]
+		markInstitution(&e, deleted)
+		merged = append(merged, e)
+	}
+	return merged
+}
+
+// redlineNamed merges lists of named items: new items not in old are marked
+// inserted and old items not in new are appended marked deleted.
+func redlineNamed[T any](old, new []T, name func(*T) *string) []T {
+	key := func(item T) string { return normalizeKey(*name(&item)) }
+	_, removed, added := matchEntities(old, new, key)
+
+	merged := make([]T, len(new))
+	copy(merged, new)
+	for _, j := range added {
+		n := name(&merged[j])
+		*n = inserted(*n)
+	}
+	for _, i := range removed {
+		item := old[i]
+		n := name(&item)
+		*n = deleted(*n)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+func markItems[T any](items []T, mark func(string) string, name func(*T) *string) []T {
+	marked := make([]T, len(items))
+	copy(marked, items)
+	for i := range marked {
+		n := name(&marked[i])
+		*n = mark(*n)
+	}
+	return marked
+}
+
+// mergeBullets interleaves removed bullets with the new ones and marks
+// reworded bullets word by word.
+func mergeBullets(old, new []string) []string {
+	var merged []string
+	for _, op := range alignBullets(old, new) {
+		switch {
+		case op.old < 0:
+			merged = append(merged, inserted(new[op.new]))
+		case op.new < 0:
+			merged = append(merged, deleted(old[op.old]))
+		default:
+			merged = append(merged, markWords(old[op.old], new[op.new]))
+		}
+	}
+	return merged
+}
+
+func mergeStrings(old, new []string) []string {
+	return redlineNamed(old, new, func(s *string) *string { return s })
+}
+
+func markAll(items []string, mark func(string) string) []string {
+	if items == nil {
+		return nil
+	}
+	marked := make([]string, len(items))
+	for i, s := range items {
+		marked[i] = mark(s)
+	}
+	return marked
+}
+
+func inserted(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return s
+	}
+	return RedlineInsertStart + s + RedlineInsertEnd
+}
+
+func deleted(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return s
+	}
+	return RedlineDeleteStart + s + RedlineDeleteEnd
+}
+
+// markWords returns new with the words deleted from old and inserted into
+// new marked, using a longest common subsequence of words.
+func markWords(old, new string) string {
+	switch {
+	case old == new:
+		return new
+	case strings.TrimSpace(old) == "":
+		return inserted(new)
+	case strings.TrimSpace(new) == "":
+		return deleted(old)
+	}
+
+	a, b := strings.Fields(old), strings.Fields(new)
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out, dels, ins []string
+	flush := func() {
+		if len(dels) > 0 {
+			out = append(out, deleted(strings.Join(dels, " ")))
+		}
+		if len(ins) > 0 {
+			out = append(out, inserted(strings.Join(ins, " ")))
+		}
+		dels, ins = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			out = append(out, b[j])
+			i, j = i+1, j+1
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			ins = append(ins, b[j])
+			j++
+		default:
+			dels = append(dels, a[i])
+			i++
+		}
+	}
+	flush()
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,110 @@
+package resume
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reference display modes for Layout.ShowReferences.
+const (
+	// ReferencesHidden omits the references section. It is the default so
+	// referee details never leave the input file by accident.
+	ReferencesHidden = "hidden"
+	// ReferencesFull prints every referee field.
+	ReferencesFull = "full"
+	// ReferencesRedacted prints only referee names and companies.
+	ReferencesRedacted = "redacted"
+)
+
+// ReferenceModes lists every recognized reference display mode.
+var ReferenceModes = []string{ReferencesHidden, ReferencesFull, ReferencesRedacted}
+
+// ReferenceList is the references section.
+type ReferenceList struct {
+	Title    string    `json:"title,omitempty" yaml:"title,omitempty" toml:"title,omitempty"`
+	Referees []Referee `json:"referees" yaml:"referees" toml:"referees"`
+}
+
+// Referee is a person who can vouch for the candidate.
+type Referee struct {
+	Name         string `json:"name" yaml:"name" toml:"name"`
+	Title        string `json:"title,omitempty" yaml:"title,omitempty" toml:"title,omitempty"`
+	Company      string `json:"company,omitempty" yaml:"company,omitempty" toml:"company,omitempty"`
+	Relationship string `json:"relationship,omitempty" yaml:"relationship,omitempty" toml:"relationship,omitempty"`
+	Email        string `json:"email,omitempty" yaml:"email,omitempty" toml:"email,omitempty"`
+	Phone        string `json:"phone,omitempty" yaml:"phone,omitempty" toml:"phone,omitempty"`
+}
+
+// ReferencesMode returns the normalized reference display mode. A nil layout
+// or an unknown value hides references.
+func (l *Layout) ReferencesMode() string {
+	if l == nil {
+		return ReferencesHidden
+	}
+	switch mode := strings.ToLower(strings.TrimSpace(l.ShowReferences)); mode {
+	case ReferencesFull, ReferencesRedacted:
+		return mode
+	default:
+		return ReferencesHidden
+	}
+}
+
+// ShowReferences reports whether the references section should render.
+func (r *Resume) ShowReferences() bool {
+	return r.Layout.ReferencesMode() != ReferencesHidden && len(r.VisibleReferees()) > 0
+}
+
+// VisibleReferees returns the referees to render, stripped down to names and
+// companies in redacted mode. Templates should only ever read referees
+// through this method so redaction cannot be bypassed.
+func (r *Resume) VisibleReferees() []Referee {
+	mode := r.Layout.ReferencesMode()
+	if mode == ReferencesHidden || r.References == nil {
+		return nil
+	}
+
+	referees := make([]Referee, 0, len(r.References.Referees))
+	for _, ref := range r.References.Referees {
+		if strings.TrimSpace(ref.Name) == "" {
+			continue
+		}
+		if mode == ReferencesRedacted {
+			ref = Referee{Name: ref.Name, Company: ref.Company}
+		}
+		referees = append(referees, ref)
+	}
+	return referees
+}
+
+func validateReferences(r *Resume) []ValidationError {
+	var errors []ValidationError
+	if r.Layout != nil && r.Layout.ShowReferences != "" {
+		valid := false
+		for _, mode := range ReferenceModes {
+			if strings.EqualFold(strings.TrimSpace(r.Layout.ShowReferences), mode) {
+				valid = true
+			}
+		}
+		if !valid {
+			errors = append(errors, ValidationError{
+				Field:   "layout.show_references",
+				Message: fmt.Sprintf("Unknown references mode; expected one of %s", strings.Join(ReferenceModes, ", ")),
+				Type:    "invalid",
+				Value:   r.Layout.ShowReferences,
+			})
+		}
+	}
+	if r.References == nil {
+		return errors
+	}
+	for i, ref := range r.References.Referees {
+		if strings.TrimSpace(ref.Name) == "" {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("references.referees[%d].name", i),
+				Message: "Referee name is required",
+				Type:    "required",
+			})
+		}
+	}
+	return errors
+}
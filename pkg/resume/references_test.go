@@ -0,0 +1,68 @@
+package resume
+
+import "testing"
+
+func testReferees() *ReferenceList {
+	return &ReferenceList{Referees: []Referee{
+		{Name: "John Smith", Title: "Engineering Manager", Company: "Acme", Email: "john@example.com", Phone: "555-0100"},
+		{Name: "  "},
+	}}
+}
+
+func TestReferencesMode(t *testing.T) {
+	tests := []struct {
+		layout *Layout
+		want   string
+	}{
+		{nil, ReferencesHidden},
+		{&Layout{}, ReferencesHidden},
+		{&Layout{ShowReferences: "Full"}, ReferencesFull},
+		{&Layout{ShowReferences: " redacted "}, ReferencesRedacted},
+		{&Layout{ShowReferences: "everything"}, ReferencesHidden},
+	}
+	for _, tt := range tests {
+		if got := tt.layout.ReferencesMode(); got != tt.want {
+			t.Errorf("ReferencesMode(%+v) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestVisibleReferees(t *testing.T) {
+	r := &Resume{References: testReferees()}
+	if r.ShowReferences() || r.VisibleReferees() != nil {
+		t.Error("references should be hidden without a layout mode")
+	}
+
+	r.Layout = &Layout{ShowReferences: ReferencesFull}
+	got := r.VisibleReferees()
+	if len(got) != 1 || got[0].Email != "john@example.com" || got[0].Phone != "555-0100" {
+		t.Errorf("full mode VisibleReferees() = %+v", got)
+	}
+
+	r.Layout.ShowReferences = ReferencesRedacted
+	got = r.VisibleReferees()
+	want := Referee{Name: "John Smith", Company: "Acme"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("redacted mode VisibleReferees() = %+v, want [%+v]", got, want)
+	}
+	if r.References.Referees[0].Email == "" {
+		t.Error("redaction must not modify the source referees")
+	}
+	if !r.ShowReferences() {
+		t.Error("ShowReferences() = false in redacted mode")
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	r := &Resume{
+		References: testReferees(),
+		Layout:     &Layout{ShowReferences: "public"},
+	}
+	errs := validateReferences(r)
+	if len(errs) != 2 {
+		t.Fatalf("validateReferences() = %+v, want 2 errors", errs)
+	}
+	if errs[0].Field != "layout.show_references" || errs[1].Field != "references.referees[1].name" {
+		t.Errorf("unexpected fields: %q, %q", errs[0].Field, errs[1].Field)
+	}
+}
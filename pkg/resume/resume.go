@@ -1,6 +1,8 @@
 package resume
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,7 +17,13 @@ type Resume struct {
 	Projects       *ProjectList    `json:"projects,omitempty" yaml:"projects,omitempty" toml:"projects,omitempty"`
 	Education      EducationList   `json:"education" yaml:"education" toml:"education"`
 	Languages      *LanguageList   `json:"languages,omitempty" yaml:"languages,omitempty" toml:"languages,omitempty"`
+	References     *ReferenceList  `json:"references,omitempty" yaml:"references,omitempty" toml:"references,omitempty"`
 	Layout         *Layout         `json:"layout,omitempty" yaml:"layout,omitempty" toml:"layout,omitempty"`
+	CoverLetter    *CoverLetter    `json:"cover_letter,omitempty" yaml:"cover_letter,omitempty" toml:"cover_letter,omitempty"`
+
+	// Params holds the parameters of the template being rendered, resolved
+	// by the generator; it is never read from input.
+	Params map[string]any `json:"-" yaml:"-" toml:"-"`
 }
 
 type Layout struct {
@@ -24,7 +32,29 @@ type Layout struct {
 	Header       string   `json:"header,omitempty" yaml:"header,omitempty" toml:"header,omitempty"`
 	Sections     []string `json:"sections,omitempty" yaml:"sections,omitempty" toml:"sections,omitempty"`
 	SkillColumns int      `json:"skill_columns,omitempty" yaml:"skill_columns,omitempty" toml:"skill_columns,omitempty"`
-	References   bool     `json:"references,omitempty" yaml:"references,omitempty" toml:"references,omitempty"`
+	// References prints "References available upon request" when the
+	// references section itself is not shown.
+	References bool `json:"references,omitempty" yaml:"references,omitempty" toml:"references,omitempty"`
+	// ShowReferences renders the references section as "full" or
+	// "redacted" (names and companies only); it is hidden when empty.
+	ShowReferences string `json:"show_references,omitempty" yaml:"show_references,omitempty" toml:"show_references,omitempty"`
+
+	// CollapseBefore renders positions that ended before this year as compact
+	// one-line entries under "Earlier Experience".
+	CollapseBefore int `json:"collapse_before,omitempty" yaml:"collapse_before,omitempty" toml:"collapse_before,omitempty"`
+	// MaxDetailedPositions caps how many of the most recent positions keep their
+	// highlights; the remainder are collapsed.
+	MaxDetailedPositions int `json:"max_detailed_positions,omitempty" yaml:"max_detailed_positions,omitempty" toml:"max_detailed_positions,omitempty"`
+
+	// SkillLevels renders skill levels as "bars", "dots" or "text"; levels
+	// are hidden when empty.
+	SkillLevels string `json:"skill_levels,omitempty" yaml:"skill_levels,omitempty" toml:"skill_levels,omitempty"`
+	// SkillYears appends explicit or derived years of experience to skills.
+	SkillYears bool `json:"skill_years,omitempty" yaml:"skill_years,omitempty" toml:"skill_years,omitempty"`
+
+	// Params sets template parameters by name, such as accent: "#0a66c2";
+	// templates that do not declare a param ignore it.
+	Params map[string]any `json:"params,omitempty" yaml:"params,omitempty" toml:"params,omitempty"`
 }
 
 type LanguageList struct {
@@ -60,6 +90,8 @@ type Location struct {
 type Link struct {
 	URI   string `json:"uri" yaml:"uri" toml:"uri"`
 	Label string `json:"label,omitempty" yaml:"label,omitempty" toml:"label,omitempty"`
+	// Type is one of LinkTypes; when empty it is inferred from URI.
+	Type string `json:"type,omitempty" yaml:"type,omitempty" toml:"type,omitempty"`
 }
 
 type Contact struct {
@@ -69,6 +101,7 @@ type Contact struct {
 	Credentials string    `json:"credentials,omitempty" yaml:"credentials,omitempty" toml:"credentials,omitempty"`
 	Location    *Location `json:"location,omitempty" yaml:"location,omitempty" toml:"location,omitempty"`
 	Links       []Link    `json:"links,omitempty" yaml:"links,omitempty" toml:"links,omitempty"`
+	Photo       *Image    `json:"photo,omitempty" yaml:"photo,omitempty" toml:"photo,omitempty"`
 }
 
 type Skills struct {
@@ -77,8 +110,8 @@ type Skills struct {
 }
 
 type SkillCategory struct {
-	Category string   `json:"category" yaml:"category" toml:"category"`
-	Items    []string `json:"items" yaml:"items" toml:"items"`
+	Category string      `json:"category" yaml:"category" toml:"category"`
+	Items    []SkillItem `json:"items" yaml:"items" toml:"items"`
 }
 
 type ExperienceList struct {
@@ -96,6 +129,7 @@ type Experience struct {
 	Dates          DateRange `json:"dates" yaml:"dates" toml:"dates"`
 	Location       *Location `json:"location,omitempty" yaml:"location,omitempty" toml:"location,omitempty"`
 	Technologies   []string  `json:"technologies,omitempty" yaml:"technologies,omitempty" toml:"technologies,omitempty"`
+	Logo           *Image    `json:"logo,omitempty" yaml:"logo,omitempty" toml:"logo,omitempty"`
 }
 type ExperienceGroup struct {
 	Name      string `json:"name" yaml:"name" toml:"name"`
@@ -117,6 +151,7 @@ type Project struct {
 	Highlights   []string   `json:"highlights,omitempty" yaml:"highlights,omitempty" toml:"highlights,omitempty"`
 	Dates        *DateRange `json:"dates,omitempty" yaml:"dates,omitempty" toml:"dates,omitempty"`
 	Technologies []string   `json:"technologies,omitempty" yaml:"technologies,omitempty" toml:"technologies,omitempty"`
+	Thumbnail    *Image     `json:"thumbnail,omitempty" yaml:"thumbnail,omitempty" toml:"thumbnail,omitempty"`
 }
 
 type EducationList struct {
@@ -229,6 +264,24 @@ func Validate(resume *Resume) []ValidationError {
 		})
 	}
 
+	for i, link := range resume.Contact.Links {
+		if link.Type != "" && !IsLinkType(link.Type) {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("contact.links[%d].type", i),
+				Message: fmt.Sprintf("Unknown link type; expected one of %s", strings.Join(LinkTypes, ", ")),
+				Type:    "invalid",
+				Value:   link.Type,
+			})
+		}
+	}
+
+	errors = append(errors, validateSkills(resume)...)
+	errors = append(errors, validateImages(resume)...)
+	errors = append(errors, validateReferences(resume)...)
+	if resume.CoverLetter != nil {
+		errors = append(errors, ValidateCoverLetter(resume.CoverLetter, "cover_letter")...)
+	}
+
 	return errors
 }
 
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
@@ -12,13 +14,17 @@ import (
 
 // SerializeResume marshals a Resume to bytes in the given format.
 // Returns the serialized bytes, the canonical format name, and any error.
+//
+// YAML and JSON output leave out null values and empty collections, and YAML
+// writes midnight UTC timestamps as plain dates. Call Canonicalize first to
+// also drop empty optional sections and normalize dates.
 func SerializeResume(r *Resume, format string) ([]byte, string, error) {
 	switch strings.ToLower(format) {
 	case "yaml", "yml":
-		data, err := yaml.Marshal(r)
+		data, err := marshalCanonicalYAML(r)
 		return data, "yaml", err
-	case "json":
-		data, err := json.MarshalIndent(r, "", "  ")
+	case "json", "jsonc":
+		data, err := marshalCanonicalJSON(r)
 		return data, "json", err
 	case "toml":
 		var buf bytes.Buffer
@@ -26,9 +32,248 @@ func SerializeResume(r *Resume, format string) ([]byte, string, error) {
 		return buf.Bytes(), "toml", err
 	case "md", "markdown":
 		// Markdown input cannot be losslessly serialized; fall back to YAML
-		data, err := yaml.Marshal(r)
+		data, err := marshalCanonicalYAML(r)
 		return data, "yaml", err
 	default:
 		return nil, "", fmt.Errorf("unsupported format: %s", format)
 	}
 }
+
+// Canonicalize normalizes r in place so equivalent resumes serialize
+// identically: dates lose their time of day and zone, and optional sections
+// (pointer fields) that hold no data are removed.
+func Canonicalize(r *Resume) {
+	canonicalizeValue(reflect.ValueOf(r).Elem())
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func canonicalizeValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		canonicalizeValue(v.Elem())
+		if v.Elem().Kind() == reflect.Struct && v.Elem().IsZero() && v.CanSet() {
+			v.Set(reflect.Zero(v.Type()))
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			if v.CanSet() {
+				v.Set(reflect.ValueOf(canonicalDate(v.Interface().(time.Time))))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				canonicalizeValue(field)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			canonicalizeValue(v.Index(i))
+		}
+	}
+}
+
+// canonicalDate keeps the calendar date of t at midnight UTC.
+func canonicalDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// FieldDifferences lists the dotted paths at which a and b hold different
+// values, such as "layout" or "experience.positions[0].dates.end". Lists of
+// different lengths are reported once at the list's path.
+func FieldDifferences(a, b *Resume) ([]string, error) {
+	nodeA, err := canonicalNode(a)
+	if err != nil {
+		return nil, err
+	}
+	nodeB, err := canonicalNode(b)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []string
+	compareNodes("", nodeA, nodeB, &diffs)
+	return diffs, nil
+}
+
+// canonicalNode encodes r as a YAML node tree without null values or empty
+// collections.
+func canonicalNode(r *Resume) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(r); err != nil {
+		return nil, err
+	}
+	pruneNode(&node)
+	return &node, nil
+}
+
+// pruneNode removes mapping entries whose values are null or empty and
+// reports whether n itself is empty. Sequence items are kept so indices stay
+// meaningful.
+func pruneNode(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.MappingNode:
+		content := n.Content[:0]
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if pruneNode(n.Content[i+1]) {
+				continue
+			}
+			content = append(content, n.Content[i], n.Content[i+1])
+		}
+		n.Content = content
+		return len(content) == 0
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			pruneNode(item)
+		}
+		return len(n.Content) == 0
+	case yaml.ScalarNode:
+		return n.Tag == "!!null"
+	}
+	return false
+}
+
+// shortenDates rewrites midnight UTC timestamps as plain YAML dates.
+func shortenDates(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!timestamp" {
+		if t, err := time.Parse(time.RFC3339Nano, n.Value); err == nil && t.Equal(canonicalDate(t)) {
+			n.Value = t.Format("2006-01-02")
+		}
+		return
+	}
+	for _, child := range n.Content {
+		shortenDates(child)
+	}
+}
+
+func marshalCanonicalYAML(r *Resume) ([]byte, error) {
+	node, err := canonicalNode(r)
+	if err != nil {
+		return nil, err
+	}
+	shortenDates(node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func marshalCanonicalJSON(r *Resume) ([]byte, error) {
+	node, err := canonicalNode(r)
+	if err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if err := writeJSONNode(&compact, node); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// writeJSONNode writes n as compact JSON, keeping mapping keys in field order.
+func writeJSONNode(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(n.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONNode(buf, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		switch n.Tag {
+		case "!!null":
+			buf.WriteString("null")
+		case "!!bool", "!!int", "!!float":
+			buf.WriteString(n.Value)
+		default:
+			value, _ := json.Marshal(n.Value)
+			buf.Write(value)
+		}
+	default:
+		return fmt.Errorf("cannot write YAML node kind %d as JSON", n.Kind)
+	}
+	return nil
+}
+
+func compareNodes(path string, a, b *yaml.Node, diffs *[]string) {
+	if a.Kind != b.Kind {
+		*diffs = append(*diffs, path)
+		return
+	}
+	switch a.Kind {
+	case yaml.MappingNode:
+		valuesB := make(map[string]*yaml.Node, len(b.Content)/2)
+		for i := 0; i+1 < len(b.Content); i += 2 {
+			valuesB[b.Content[i].Value] = b.Content[i+1]
+		}
+		seen := make(map[string]bool, len(a.Content)/2)
+		for i := 0; i+1 < len(a.Content); i += 2 {
+			key := a.Content[i].Value
+			seen[key] = true
+			if other, ok := valuesB[key]; ok {
+				compareNodes(joinPath(path, key), a.Content[i+1], other, diffs)
+			} else {
+				*diffs = append(*diffs, joinPath(path, key))
+			}
+		}
+		for i := 0; i+1 < len(b.Content); i += 2 {
+			if key := b.Content[i].Value; !seen[key] {
+				*diffs = append(*diffs, joinPath(path, key))
+			}
+		}
+	case yaml.SequenceNode:
+		if len(a.Content) != len(b.Content) {
+			*diffs = append(*diffs, path)
+			return
+		}
+		for i := range a.Content {
+			compareNodes(fmt.Sprintf("%s[%d]", path, i), a.Content[i], b.Content[i], diffs)
+		}
+	default:
+		if a.Value != b.Value {
+			*diffs = append(*diffs, path)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,114 @@
+package resume
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadTestResume(t *testing.T) *Resume {
+	t.Helper()
+	data, err := LoadResumeFromFile(filepath.Join("..", "generators", "testdata", "input", "software_engineer.yml"))
+	if err != nil {
+		t.Fatalf("failed to load test resume: %v", err)
+	}
+	return data.ToResume()
+}
+
+func TestSerializeResumeRoundTrip(t *testing.T) {
+	for _, format := range []string{"yaml", "json", "toml"} {
+		t.Run(format, func(t *testing.T) {
+			original := loadTestResume(t)
+			Canonicalize(original)
+
+			data, canonical, err := SerializeResume(original, format)
+			if err != nil {
+				t.Fatalf("SerializeResume() error = %v", err)
+			}
+			if canonical != format {
+				t.Errorf("format = %q, want %q", canonical, format)
+			}
+
+			parsed, err := LoadResumeFromBytes(data, format)
+			if err != nil {
+				t.Fatalf("failed to parse serialized %s: %v\n%s", format, err, data)
+			}
+			roundTripped := parsed.ToResume()
+			Canonicalize(roundTripped)
+
+			diffs, err := FieldDifferences(original, roundTripped)
+			if err != nil {
+				t.Fatalf("FieldDifferences() error = %v", err)
+			}
+			if len(diffs) > 0 {
+				t.Errorf("%s round trip changed %v", format, diffs)
+			}
+		})
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	start := time.Date(2021, time.March, 4, 15, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	r := &Resume{
+		Contact: Contact{Name: "Jane Doe", Location: &Location{}},
+		Experience: ExperienceList{Positions: []Experience{{
+			Company:  "Acme",
+			Dates:    DateRange{Start: start},
+			Location: &Location{City: "Toronto"},
+		}}},
+		Projects: &ProjectList{},
+		Layout:   &Layout{},
+	}
+	Canonicalize(r)
+
+	if r.Contact.Location != nil || r.Projects != nil || r.Layout != nil {
+		t.Errorf("empty optional sections should be removed: %+v", r)
+	}
+	if r.Experience.Positions[0].Location == nil {
+		t.Error("non-empty location should be kept")
+	}
+	if got := r.Experience.Positions[0].Dates.Start; !got.Equal(time.Date(2021, time.March, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Start = %v, want 2021-03-04 UTC", got)
+	}
+
+	data, _, err := SerializeResume(r, "yaml")
+	if err != nil {
+		t.Fatalf("SerializeResume() error = %v", err)
+	}
+	yamlText := string(data)
+	if !strings.Contains(yamlText, "start: 2021-03-04\n") {
+		t.Errorf("dates should be written as plain dates:\n%s", yamlText)
+	}
+	for _, empty := range []string{"null", "[]", "{}", "layout"} {
+		if strings.Contains(yamlText, empty) {
+			t.Errorf("output should not contain %q:\n%s", empty, yamlText)
+		}
+	}
+}
+
+func TestFieldDifferences(t *testing.T) {
+	a := loadTestResume(t)
+	b := loadTestResume(t)
+	b.Layout = nil
+	b.Experience.Positions[0].Title = "Changed"
+	b.Skills.Categories = b.Skills.Categories[:1]
+
+	diffs, err := FieldDifferences(a, b)
+	if err != nil {
+		t.Fatalf("FieldDifferences() error = %v", err)
+	}
+	want := map[string]bool{
+		"skills.categories":             true,
+		"experience.positions[0].title": true,
+		"layout":                        true,
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("FieldDifferences() = %v, want %d paths", diffs, len(want))
+	}
+	for _, d := range diffs {
+		if !want[d] {
+			t.Errorf("unexpected difference %q", d)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package resume
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Skill proficiency levels, from least to most experienced.
+const (
+	SkillLevelBeginner     = "beginner"
+	SkillLevelIntermediate = "intermediate"
+	SkillLevelAdvanced     = "advanced"
+	SkillLevelExpert       = "expert"
+)
+
+// SkillLevels lists every recognized level in ascending order.
+var SkillLevels = []string{
+	SkillLevelBeginner,
+	SkillLevelIntermediate,
+	SkillLevelAdvanced,
+	SkillLevelExpert,
+}
+
+// SkillItem is a single skill within a category. In input files it may be
+// written as a plain string ("Go") or as an object with a level and years.
+type SkillItem struct {
+	Name  string `json:"name" yaml:"name" toml:"name"`
+	Level string `json:"level,omitempty" yaml:"level,omitempty" toml:"level,omitempty"`
+	// Years overrides the tenure derived from experience and projects.
+	Years float64 `json:"years,omitempty" yaml:"years,omitempty" toml:"years,omitempty"`
+}
+
+// skillItemFields mirrors SkillItem without its custom (un)marshalers.
+type skillItemFields SkillItem
+
+// SkillItemsFromNames builds plain skill items from names.
+func SkillItemsFromNames(names ...string) []SkillItem {
+	items := make([]SkillItem, len(names))
+	for i, name := range names {
+		items[i] = SkillItem{Name: name}
+	}
+	return items
+}
+
+// SkillNames returns the names of the given items.
+func SkillNames(items []SkillItem) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names
+}
+
+// String returns the skill name.
+func (s SkillItem) String() string {
+	return s.Name
+}
+
+// LevelRank returns the 1-based position of the level in SkillLevels, or 0
+// when the level is unset or unknown.
+func (s SkillItem) LevelRank() int {
+	level := strings.ToLower(strings.TrimSpace(s.Level))
+	for i, l := range SkillLevels {
+		if l == level {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// isPlain reports whether the item carries nothing beyond its name, so it
+// can be written back out as a bare string.
+func (s SkillItem) isPlain() bool {
+	return s.Level == "" && s.Years == 0
+}
+
+// UnmarshalYAML accepts either a scalar name or a mapping.
+func (s *SkillItem) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = SkillItem{Name: value.Value}
+		return nil
+	}
+	var fields skillItemFields
+	if err := value.Decode(&fields); err != nil {
+		return err
+	}
+	*s = SkillItem(fields)
+	return nil
+}
+
+// MarshalYAML writes plain items as bare strings.
+func (s SkillItem) MarshalYAML() (interface{}, error) {
+	if s.isPlain() {
+		return s.Name, nil
+	}
+	return skillItemFields(s), nil
+}
+
+// UnmarshalJSON accepts either a string name or an object.
+func (s *SkillItem) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		var name string
+		if err := json.Unmarshal(trimmed, &name); err != nil {
+			return err
+		}
+		*s = SkillItem{Name: name}
+		return nil
+	}
+	var fields skillItemFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*s = SkillItem(fields)
+	return nil
+}
+
+// MarshalJSON writes plain items as bare strings.
+func (s SkillItem) MarshalJSON() ([]byte, error) {
+	if s.isPlain() {
+		return json.Marshal(s.Name)
+	}
+	return json.Marshal(skillItemFields(s))
+}
+
+// UnmarshalTOML accepts either a string name or an inline table.
+func (s *SkillItem) UnmarshalTOML(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		*s = SkillItem{Name: v}
+	case map[string]interface{}:
+		*s = SkillItem{}
+		if name, ok := v["name"].(string); ok {
+			s.Name = name
+		}
+		if level, ok := v["level"].(string); ok {
+			s.Level = level
+		}
+		switch years := v["years"].(type) {
+		case int64:
+			s.Years = float64(years)
+		case float64:
+			s.Years = years
+		}
+	default:
+		return fmt.Errorf("skill item must be a string or table, got %T", value)
+	}
+	return nil
+}
+
+// MarshalTOML writes plain items as bare strings and detailed items as
+// inline tables.
+func (s SkillItem) MarshalTOML() ([]byte, error) {
+	if s.isPlain() {
+		return []byte(tomlString(s.Name)), nil
+	}
+	parts := []string{"name = " + tomlString(s.Name)}
+	if s.Level != "" {
+		parts = append(parts, "level = "+tomlString(s.Level))
+	}
+	if s.Years != 0 {
+		parts = append(parts, "years = "+strconv.FormatFloat(s.Years, 'f', -1, 64))
+	}
+	return []byte("{ " + strings.Join(parts, ", ") + " }"), nil
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSpace(buf.String())
+}
+
+// SkillTenure is the experience behind a technology, derived from the
+// positions and projects that list it.
+type SkillTenure struct {
+	Name    string
+	Years   float64
+	Sources []string
+}
+
+type tenureInterval struct {
+	start, end time.Time
+}
+
+// ComputeSkillTenure walks Experience.Technologies and Project.Technologies
+// against their date ranges and returns the total time spent with each
+// technology, longest first. Overlapping ranges are merged so concurrent
+// roles are not double counted, and open-ended ranges run until now.
+func ComputeSkillTenure(r *Resume, now time.Time) []SkillTenure {
+	type entry struct {
+		name      string
+		intervals []tenureInterval
+		sources   []string
+	}
+	byKey := map[string]*entry{}
+	var order []string
+
+	add := func(tech, source string, dates DateRange) {
+		key := skillKey(tech)
+		if key == "" || dates.Start.IsZero() {
+			return
+		}
+		end := now
+		if dates.End != nil {
+			end = *dates.End
+		}
+		if !end.After(dates.Start) {
+			return
+		}
+		e, ok := byKey[key]
+		if !ok {
+			e = &entry{name: strings.TrimSpace(tech)}
+			byKey[key] = e
+			order = append(order, key)
+		}
+		e.intervals = append(e.intervals, tenureInterval{start: dates.Start, end: end})
+		e.sources = append(e.sources, source)
+	}
+
+	for _, pos := range r.Experience.Positions {
+		source := pos.Title
+		if pos.Company != "" {
+			source += ", " + pos.Company
+		}
+		for _, tech := range pos.Technologies {
+			add(tech, source, pos.Dates)
+		}
+	}
+	if r.Projects != nil {
+		for _, proj := range r.Projects.Projects {
+			if proj.Dates == nil {
+				continue
+			}
+			for _, tech := range proj.Technologies {
+				add(tech, proj.Name, *proj.Dates)
+			}
+		}
+	}
+
+	tenures := make([]SkillTenure, 0, len(order))
+	for _, key := range order {
+		e := byKey[key]
+		tenures = append(tenures, SkillTenure{
+			Name:    e.name,
+			Years:   mergedYears(e.intervals),
+			Sources: e.sources,
+		})
+	}
+	sort.SliceStable(tenures, func(i, j int) bool {
+		return tenures[i].Years > tenures[j].Years
+	})
+	return tenures
+}
+
+// TenureIndex holds derived skill tenures by skill name, so a resume's
+// skills are looked up without walking its history again for each one.
+type TenureIndex map[string]float64
+
+// IndexSkillTenure indexes tenures, as returned by ComputeSkillTenure, by
+// skill name.
+func IndexSkillTenure(tenures []SkillTenure) TenureIndex {
+	index := make(TenureIndex, len(tenures))
+	for _, t := range tenures {
+		index[skillKey(t.Name)] = t.Years
+	}
+	return index
+}
+
+// Lookup returns the derived years of the named skill, ignoring case and
+// surrounding space, and whether any position or dated project lists it.
+func (t TenureIndex) Lookup(name string) (float64, bool) {
+	years, ok := t[skillKey(name)]
+	return years, ok
+}
+
+// Years returns the years to show for a skill: the explicit value when
+// set, otherwise the derived tenure. derived reports which.
+func (t TenureIndex) Years(item SkillItem) (years float64, derived bool) {
+	if item.Years > 0 {
+		return item.Years, false
+	}
+	years, _ = t.Lookup(item.Name)
+	return years, true
+}
+
+// SkillYears returns the years to show for a skill: the explicit value when
+// set, otherwise the tenure derived from the resume. derived reports which.
+// To look up several skills, index the tenures once with IndexSkillTenure.
+func (r *Resume) SkillYears(item SkillItem, now time.Time) (years float64, derived bool) {
+	return IndexSkillTenure(ComputeSkillTenure(r, now)).Years(item)
+}
+
+// mergedYears sums the union of the intervals in years.
+func mergedYears(intervals []tenureInterval) float64 {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	var total time.Duration
+	var cur tenureInterval
+	for i, iv := range intervals {
+		if i == 0 {
+			cur = iv
+			continue
+		}
+		if !iv.start.After(cur.end) {
+			if iv.end.After(cur.end) {
+				cur.end = iv.end
+			}
+			continue
+		}
+		total += cur.end.Sub(cur.start)
+		cur = iv
+	}
+	if len(intervals) > 0 {
+		total += cur.end.Sub(cur.start)
+	}
+
+	years := total.Hours() / 24 / 365.25
+	return math.Round(years*10) / 10
+}
+
+func skillKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func validateSkills(r *Resume) []ValidationError {
+	var errors []ValidationError
+	for i, cat := range r.Skills.Categories {
+		for j, item := range cat.Items {
+			field := fmt.Sprintf("skills.categories[%d].items[%d]", i, j)
+			if item.Level != "" && item.LevelRank() == 0 {
+				errors = append(errors, ValidationError{
+					Field:   field + ".level",
+					Message: fmt.Sprintf("Unknown skill level; expected one of %s", strings.Join(SkillLevels, ", ")),
+					Type:    "invalid",
+					Value:   item.Level,
+				})
+			}
+			if item.Years < 0 {
+				errors = append(errors, ValidationError{
+					Field:   field + ".years",
+					Message: "Years must not be negative",
+					Type:    "invalid",
+					Value:   item.Years,
+				})
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,150 @@
+package resume
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSkillItemUnmarshal(t *testing.T) {
+	want := []SkillItem{
+		{Name: "Go"},
+		{Name: "Rust", Level: "advanced", Years: 3},
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		var got []SkillItem
+		src := "- Go\n- name: Rust\n  level: advanced\n  years: 3\n"
+		if err := yaml.Unmarshal([]byte(src), &got); err != nil {
+			t.Fatalf("yaml.Unmarshal() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var got []SkillItem
+		src := `["Go", {"name": "Rust", "level": "advanced", "years": 3}]`
+		if err := json.Unmarshal([]byte(src), &got); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		var got struct {
+			Items []SkillItem `toml:"items"`
+		}
+		src := `items = ["Go", { name = "Rust", level = "advanced", years = 3 }]`
+		if _, err := toml.Decode(src, &got); err != nil {
+			t.Fatalf("toml.Decode() error = %v", err)
+		}
+		if !reflect.DeepEqual(got.Items, want) {
+			t.Errorf("got %+v, want %+v", got.Items, want)
+		}
+	})
+}
+
+func TestSkillItemMarshal(t *testing.T) {
+	items := []SkillItem{
+		{Name: "Go"},
+		{Name: "Rust", Level: "expert", Years: 2.5},
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(data), `["Go",{"name":"Rust","level":"expert","years":2.5}]`; got != want {
+		t.Errorf("json = %s, want %s", got, want)
+	}
+
+	out, err := yaml.Marshal(items)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if !strings.HasPrefix(string(out), "- Go\n") {
+		t.Errorf("plain items should marshal as bare strings, got:\n%s", out)
+	}
+	var back []SkillItem
+	if err := yaml.Unmarshal(out, &back); err != nil {
+		t.Fatalf("yaml round trip error = %v", err)
+	}
+	if !reflect.DeepEqual(back, items) {
+		t.Errorf("yaml round trip = %+v, want %+v", back, items)
+	}
+}
+
+func TestComputeSkillTenure(t *testing.T) {
+	date := func(y int, m time.Month) time.Time { return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC) }
+	end := func(y int, m time.Month) *time.Time { d := date(y, m); return &d }
+	now := date(2024, time.January)
+
+	r := &Resume{
+		Experience: ExperienceList{Positions: []Experience{
+			{Title: "Engineer", Company: "Acme", Technologies: []string{"Go", "Docker"},
+				Dates: DateRange{Start: date(2018, time.January), End: end(2020, time.January)}},
+			// Overlaps the first role for a year; Go must not be double counted.
+			{Title: "Consultant", Technologies: []string{"go"},
+				Dates: DateRange{Start: date(2019, time.January), End: end(2021, time.January)}},
+			{Title: "Lead", Company: "Initech", Technologies: []string{"Rust"},
+				Dates: DateRange{Start: date(2023, time.January)}},
+		}},
+		Projects: &ProjectList{Projects: []Project{
+			{Name: "Tool", Technologies: []string{"Rust"},
+				Dates: &DateRange{Start: date(2021, time.January), End: end(2022, time.January)}},
+			{Name: "Undated", Technologies: []string{"Python"}},
+		}},
+	}
+
+	got := ComputeSkillTenure(r, now)
+	want := []SkillTenure{
+		{Name: "Go", Years: 3, Sources: []string{"Engineer, Acme", "Consultant"}},
+		{Name: "Docker", Years: 2, Sources: []string{"Engineer, Acme"}},
+		{Name: "Rust", Years: 2, Sources: []string{"Lead, Initech", "Tool"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeSkillTenure() = %+v, want %+v", got, want)
+	}
+
+	if years, derived := r.SkillYears(SkillItem{Name: "GO"}, now); years != 3 || !derived {
+		t.Errorf("SkillYears(derived) = %v, %v; want 3, true", years, derived)
+	}
+	if years, derived := r.SkillYears(SkillItem{Name: "Go", Years: 10}, now); years != 10 || derived {
+		t.Errorf("SkillYears(explicit) = %v, %v; want 10, false", years, derived)
+	}
+
+	index := IndexSkillTenure(got)
+	if years, ok := index.Lookup(" rust "); years != 2 || !ok {
+		t.Errorf("Lookup(rust) = %v, %v; want 2, true", years, ok)
+	}
+	if _, ok := index.Lookup("Python"); ok {
+		t.Error("Lookup(Python) found a skill only listed on an undated project")
+	}
+}
+
+func TestValidateSkills(t *testing.T) {
+	r := &Resume{Skills: Skills{Categories: []SkillCategory{{
+		Category: "Languages",
+		Items:    []SkillItem{{Name: "Go", Level: "guru"}, {Name: "Rust", Years: -1}, {Name: "C", Level: "Expert"}},
+	}}}}
+
+	errs := validateSkills(r)
+	if len(errs) != 2 {
+		t.Fatalf("validateSkills() returned %d errors, want 2: %+v", len(errs), errs)
+	}
+	if errs[0].Field != "skills.categories[0].items[0].level" {
+		t.Errorf("first error field = %q", errs[0].Field)
+	}
+	if errs[1].Field != "skills.categories[0].items[1].years" {
+		t.Errorf("second error field = %q", errs[1].Field)
+	}
+}
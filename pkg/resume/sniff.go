@@ -0,0 +1,128 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	reTOMLTable  = regexp.MustCompile(`^\[\[?[A-Za-z0-9_.-]+\]\]?\s*(#.*)?$`)
+	reTOMLAssign = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*\s*=`)
+	reYAMLKey    = regexp.MustCompile(`^[a-z_][a-z0-9_]*:(\s|$)`)
+)
+
+// FormatFromPath returns the input format implied by a file extension, or ""
+// when the extension is missing or not one the loader recognizes.
+func FormatFromPath(path string) string {
+	return NormalizeFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// NormalizeFormat maps a format name or alias (yml, markdown) to its canonical
+// name: yaml, json, jsonc, toml or md. Unknown names return "".
+func NormalizeFormat(name string) string {
+	switch format := strings.ToLower(strings.TrimSpace(name)); format {
+	case "yaml", "yml":
+		return "yaml"
+	case "json", "jsonc", "toml", "md":
+		return format
+	case "markdown":
+		return "md"
+	default:
+		return ""
+	}
+}
+
+// DetectFormat guesses the serialization format of resume data from its
+// content. JSON is recognized by its leading brace, TOML by table headers or
+// top-level assignments, YAML by top-level keys, and Markdown by headings.
+// YAML and TOML win over Markdown because both use "#" for comments.
+func DetectFormat(data []byte) (string, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) == 0 {
+		return "", fmt.Errorf("cannot detect format of empty input")
+	}
+
+	switch {
+	case trimmed[0] == '{':
+		if !bytes.Equal(stripJSONComments(trimmed), trimmed) {
+			return "jsonc", nil
+		}
+		return "json", nil
+	case bytes.HasPrefix(trimmed, []byte("//")), bytes.HasPrefix(trimmed, []byte("/*")):
+		return "jsonc", nil
+	}
+
+	sawHeading := false
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		switch {
+		case line == "---":
+			// A YAML document marker or a Markdown rule; decided by what follows.
+		case reTOMLTable.MatchString(line), reTOMLAssign.MatchString(line):
+			return "toml", nil
+		case reYAMLKey.MatchString(line):
+			return "yaml", nil
+		case strings.HasPrefix(line, "#"):
+			sawHeading = sawHeading || reH1.MatchString(line) || reH2.MatchString(line)
+		}
+	}
+	if sawHeading {
+		return "md", nil
+	}
+	return "", fmt.Errorf("cannot detect format; pass it explicitly (yaml, json, jsonc, toml, md)")
+}
+
+// stripJSONComments removes // and /* */ comments and trailing commas from
+// JSONC so it can be decoded as plain JSON. String literals are left intact.
+func stripJSONComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+		case c == ']' || c == '}':
+			// Drop a trailing comma before the closing bracket.
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package resume
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"json", `{"contact": {"name": "Jane"}}`, "json", false},
+		{"jsonc comment", "{\n  // name\n  \"contact\": {\"name\": \"Jane\"}\n}", "jsonc", false},
+		{"jsonc trailing comma", `{"contact": {"name": "Jane",},}`, "jsonc", false},
+		{"yaml", "contact:\n  name: Jane\n", "yaml", false},
+		{"yaml with leading comment", "# yaml-language-server: $schema=resume.schema.json\n---\ncontact:\n  name: Jane\n", "yaml", false},
+		{"toml table", "# resume\n[contact]\nname = \"Jane\"\n", "toml", false},
+		{"toml assignment", "title = \"Resume\"\n", "toml", false},
+		{"markdown", "# Jane Doe\n\njane@example.com\n\n---\n\n## Experience\n", "md", false},
+		{"empty", "  \n", "", true},
+		{"unknown", "<resume></resume>", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFormat([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripJSONComments(t *testing.T) {
+	input := `{
+  // line comment
+  "url": "https://example.com/*not a comment*/", /* block */
+  "items": ["a", "b",],
+  "quote": "say \"hi\" // still text",
+}`
+	var got struct {
+		URL   string   `json:"url"`
+		Items []string `json:"items"`
+		Quote string   `json:"quote"`
+	}
+	if err := json.Unmarshal(stripJSONComments([]byte(input)), &got); err != nil {
+		t.Fatalf("stripped JSONC does not decode: %v", err)
+	}
+	if got.URL != "https://example.com/*not a comment*/" || got.Quote != `say "hi" // still text` || len(got.Items) != 2 {
+		t.Errorf("string contents were altered: %+v", got)
+	}
+}
+
+func TestLoadResumeWithoutExtension(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"resume":       "contact:\n  name: Jane Doe\n",
+		"resume.jsonc": "{\n  // comment\n  \"contact\": {\"name\": \"Jane Doe\"},\n}",
+		"resume.txt":   "[contact]\nname = \"Jane Doe\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		data, err := LoadResumeFromFile(path)
+		if err != nil {
+			t.Fatalf("LoadResumeFromFile(%s) error = %v", name, err)
+		}
+		if data.ToResume().Contact.Name != "Jane Doe" {
+			t.Errorf("%s: Contact.Name = %q", name, data.ToResume().Contact.Name)
+		}
+	}
+
+	if _, err := LoadResumeFromFileAs(filepath.Join(dir, "resume.txt"), "yaml"); err == nil {
+		t.Error("an explicit format should override detection")
+	}
+}
+
+func TestLoadResumeFromReader(t *testing.T) {
+	data, err := LoadResumeFromReader(strings.NewReader(`{"contact": {"name": "Jane Doe"}}`), "")
+	if err != nil {
+		t.Fatalf("LoadResumeFromReader() error = %v", err)
+	}
+	if data.GetFormat() != "json" {
+		t.Errorf("GetFormat() = %q, want json", data.GetFormat())
+	}
+
+	if _, err := LoadResumeFromReader(strings.NewReader("contact:\n  name: Jane\n"), "toml"); err == nil {
+		t.Error("expected an error when the explicit format does not match")
+	}
+}
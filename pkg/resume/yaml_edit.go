@@ -0,0 +1,267 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TextEdit replaces the text at a path of a YAML resume, such as
+// "experience.positions[2].highlights[1]". Original is the text the edit
+// was proposed against.
+type TextEdit struct {
+	Path        string `json:"path"`
+	Original    string `json:"original"`
+	Replacement string `json:"replacement"`
+}
+
+// YAMLScalars maps the path of every scalar value in a YAML document to
+// its text.
+func YAMLScalars(data []byte) (map[string]string, error) {
+	nodes, err := yamlScalarNodes(data)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(nodes))
+	for path, node := range nodes {
+		out[path] = node.Value
+	}
+	return out, nil
+}
+
+func yamlScalarNodes(data []byte) (map[string]*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, formatYAMLError(data, err)
+	}
+	nodes := map[string]*yaml.Node{}
+	var walk func(node *yaml.Node, path []yamlPathPart)
+	walk = func(node *yaml.Node, path []yamlPathPart) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i+1], appendYAMLKey(path, node.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, appendYAMLIndex(path, i))
+			}
+		case yaml.ScalarNode:
+			nodes[formatYAMLPath(path)] = node
+		}
+	}
+	walk(&root, nil)
+	return nodes, nil
+}
+
+// ApplyYAMLEdits rewrites the values at the paths of the edits in place,
+// leaving comments, key order, quoting style and every other line of the
+// document as they were. An edit fails when its path is not a scalar or
+// the value no longer matches Original.
+func ApplyYAMLEdits(data []byte, edits []TextEdit) ([]byte, error) {
+	nodes, err := yamlScalarNodes(data)
+	if err != nil {
+		return nil, err
+	}
+	lines := lineOffsets(data)
+
+	type span struct {
+		start, end int
+		text       string
+		path       string
+	}
+	spans := make([]span, 0, len(edits))
+	for _, edit := range edits {
+		node, ok := nodes[edit.Path]
+		if !ok {
+			return nil, fmt.Errorf("%s: no text value at this path", edit.Path)
+		}
+		if strings.TrimSpace(node.Value) != strings.TrimSpace(edit.Original) {
+			return nil, fmt.Errorf("%s: value has changed since the edit was proposed", edit.Path)
+		}
+		start, end, text, err := scalarSpan(data, lines, node, edit.Replacement)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", edit.Path, err)
+		}
+		spans = append(spans, span{start, end, text, edit.Path})
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start > spans[j].start })
+	out := append([]byte(nil), data...)
+	for i, s := range spans {
+		if i > 0 && s.end > spans[i-1].start {
+			return nil, fmt.Errorf("%s: overlaps the edit of %s", s.path, spans[i-1].path)
+		}
+		out = append(out[:s.start], append([]byte(s.text), out[s.end:]...)...)
+	}
+	return out, nil
+}
+
+// lineOffsets returns the byte offset of the start of each line, so that
+// line n (1-based) starts at offsets[n-1].
+func lineOffsets(data []byte) []int {
+	offsets := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// lineAt returns line n (1-based) without its line break.
+func lineAt(data []byte, lines []int, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	end := len(data)
+	if n < len(lines) {
+		end = lines[n] - 1
+	}
+	return strings.TrimSuffix(string(data[lines[n-1]:end]), "\r")
+}
+
+// scalarSpan locates the source text of a scalar and renders its
+// replacement in the same style.
+func scalarSpan(data []byte, lines []int, node *yaml.Node, replacement string) (int, int, string, error) {
+	line := lineAt(data, lines, node.Line)
+	runes := []rune(line)
+	if node.Column < 1 || node.Column > len(runes) {
+		return 0, 0, "", fmt.Errorf("cannot locate the value in the source")
+	}
+	// yaml.v3 counts columns in characters.
+	startByte := lines[node.Line-1] + len(string(runes[:node.Column-1]))
+	rest := string(runes[node.Column-1:])
+
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return blockSpan(data, lines, node, replacement)
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		end := closingDoubleQuote(rest)
+		if end < 0 {
+			return 0, 0, "", fmt.Errorf("quoted values spanning several lines are not supported")
+		}
+		return startByte, startByte + end + 1, strconv.Quote(replacement), nil
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		end := closingSingleQuote(rest)
+		if end < 0 {
+			return 0, 0, "", fmt.Errorf("quoted values spanning several lines are not supported")
+		}
+		if strings.Contains(replacement, "\n") {
+			return startByte, startByte + end + 1, strconv.Quote(replacement), nil
+		}
+		return startByte, startByte + end + 1, "'" + strings.ReplaceAll(replacement, "'", "''") + "'", nil
+	}
+
+	text := rest
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimRight(text, " \t")
+	var value string
+	if err := yaml.Unmarshal([]byte(text), &value); err != nil || value != node.Value {
+		return 0, 0, "", fmt.Errorf("plain values spanning several lines are not supported")
+	}
+	return startByte, startByte + len(text), plainScalar(replacement), nil
+}
+
+// plainScalar renders text unquoted when YAML allows it, and quoted
+// otherwise.
+func plainScalar(text string) string {
+	if strings.Contains(text, "\n") {
+		return strconv.Quote(text)
+	}
+	out, err := yaml.Marshal(text)
+	if err != nil {
+		return strconv.Quote(text)
+	}
+	out = bytes.TrimSuffix(out, []byte("\n"))
+	if bytes.Contains(out, []byte("\n")) {
+		return strconv.Quote(text)
+	}
+	return string(out)
+}
+
+func closingDoubleQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func closingSingleQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			i++
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// blockSpan replaces the content lines of a literal or folded block
+// scalar, keeping its header and indentation.
+func blockSpan(data []byte, lines []int, node *yaml.Node, replacement string) (int, int, string, error) {
+	header := lineAt(data, lines, node.Line)
+	parentIndent := len(header) - len(strings.TrimLeft(header, " "))
+
+	first, last, indent := 0, 0, 0
+	for n := node.Line + 1; n <= len(lines); n++ {
+		line := lineAt(data, lines, n)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if lineIndent <= parentIndent {
+			break
+		}
+		if first == 0 {
+			first, indent = n, lineIndent
+		}
+		last = n
+	}
+	if first == 0 {
+		return 0, 0, "", fmt.Errorf("cannot locate the value in the source")
+	}
+
+	paragraphs := strings.Split(strings.TrimRight(replacement, "\n"), "\n")
+	sep := "\n"
+	if node.Style&yaml.FoldedStyle != 0 {
+		// Single line breaks fold into spaces in a folded block.
+		sep = "\n\n"
+	}
+	prefix := strings.Repeat(" ", indent)
+	for i, p := range paragraphs {
+		if p != "" {
+			paragraphs[i] = prefix + p
+		}
+	}
+	text := strings.Join(paragraphs, sep)
+
+	start := lines[first-1]
+	end := len(data)
+	if last < len(lines) {
+		end = lines[last] - 1
+	}
+	if strings.HasSuffix(string(data[start:end]), "\r") {
+		end--
+	}
+	return start, end, text, nil
+}
@@ -0,0 +1,82 @@
+package resume
+
+import (
+	"strings"
+	"testing"
+)
+
+const editSource = `# Jane's resume
+summary: |
+  Backend engineer.
+  Likes Go.
+experience:
+  positions:
+    # Current role
+    - company: Acme  # lint:ignore tense
+      highlights:
+        - Responsible for the build  # too vague
+        - "Wrote \"docs\""
+        - 'It''s fast'
+`
+
+func TestApplyYAMLEdits(t *testing.T) {
+	edits := []TextEdit{
+		{Path: "summary", Original: "Backend engineer.\nLikes Go.", Replacement: "Backend engineer who ships."},
+		{Path: "experience.positions[0].highlights[0]", Original: "Responsible for the build", Replacement: "Cut build time by 40%: from 10 to 6 minutes"},
+		{Path: "experience.positions[0].highlights[1]", Original: `Wrote "docs"`, Replacement: `Wrote the "runbook"`},
+		{Path: "experience.positions[0].highlights[2]", Original: "It's fast", Replacement: "It's 3x faster"},
+	}
+	out, err := ApplyYAMLEdits([]byte(editSource), edits)
+	if err != nil {
+		t.Fatalf("ApplyYAMLEdits() error = %v", err)
+	}
+	want := `# Jane's resume
+summary: |
+  Backend engineer who ships.
+experience:
+  positions:
+    # Current role
+    - company: Acme  # lint:ignore tense
+      highlights:
+        - 'Cut build time by 40%: from 10 to 6 minutes'  # too vague
+        - "Wrote the \"runbook\""
+        - 'It''s 3x faster'
+`
+	if string(out) != want {
+		t.Errorf("ApplyYAMLEdits() =\n%s\nwant\n%s", out, want)
+	}
+
+	values, err := YAMLScalars(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, edit := range edits {
+		if got := strings.TrimSpace(values[edit.Path]); got != edit.Replacement {
+			t.Errorf("%s = %q, want %q", edit.Path, got, edit.Replacement)
+		}
+	}
+}
+
+func TestApplyYAMLEditsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit TextEdit
+		want string
+	}{
+		{"unknown path", TextEdit{Path: "experience.positions[3].highlights[0]"}, "no text value"},
+		{"stale", TextEdit{Path: "experience.positions[0].company", Original: "Initech", Replacement: "Acme Corp"}, "has changed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyYAMLEdits([]byte(editSource), []TextEdit{tt.edit})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ApplyYAMLEdits() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	multiline := "summary: a long\n  plain value\n"
+	if _, err := ApplyYAMLEdits([]byte(multiline), []TextEdit{{Path: "summary", Original: "a long plain value", Replacement: "short"}}); err == nil {
+		t.Error("ApplyYAMLEdits() of a multi-line plain value succeeded")
+	}
+}
@@ -0,0 +1,342 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+	"gopkg.in/yaml.v3"
+)
+
+// batchSize is how many values are translated per model call; small
+// local models lose track of longer lists.
+const batchSize = 25
+
+// translatable matches the paths of the values written in a human
+// language. Names, technologies, links, dates and settings are left out.
+var translatable = []*regexp.Regexp{
+	regexp.MustCompile(`^summary$`),
+	regexp.MustCompile(`^(skills|experience|projects|education|certifications|languages|references)\.title$`),
+	regexp.MustCompile(`^skills\.categories\[\d+\]\.category$`),
+	regexp.MustCompile(`^experience\.positions\[\d+\]\.(title|notes|highlights\[\d+\]|duties\[\d+\])$`),
+	regexp.MustCompile(`^projects\.projects\[\d+\]\.highlights\[\d+\]$`),
+	regexp.MustCompile(`^education\.institutions\[\d+\]\.(degree|credential)\.(name|descriptions\[\d+\])$`),
+	regexp.MustCompile(`^education\.institutions\[\d+\]\.(specializations\[\d+\]|awards\[\d+\]\.notes)$`),
+	regexp.MustCompile(`^education\.institutions\[\d+\]\.thesis\.(title|description|highlights\[\d+\])$`),
+	regexp.MustCompile(`^certifications\.items\[\d+\]\.notes$`),
+	regexp.MustCompile(`^languages\.languages\[\d+\]\.(name|proficiency)$`),
+	regexp.MustCompile(`^references\.referees\[\d+\]\.title$`),
+	regexp.MustCompile(`^cover_letter\.(salutation|closing|body\[\d+\]|recipient\.title)$`),
+}
+
+// Segment is a value of the resume to translate.
+type Segment struct {
+	Path string
+	Text string
+}
+
+// Segments returns the human-language values of a YAML resume, ordered by
+// path.
+func Segments(data []byte) ([]Segment, error) {
+	values, err := resume.YAMLScalars(data)
+	if err != nil {
+		return nil, err
+	}
+	var out []Segment
+	for path, text := range values {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		for _, re := range translatable {
+			if re.MatchString(path) {
+				out = append(out, Segment{Path: path, Text: text})
+				break
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return pathKey(out[i].Path) < pathKey(out[j].Path) })
+	return out, nil
+}
+
+var index = regexp.MustCompile(`\[(\d+)\]`)
+
+// pathKey pads list indices so that paths sort in document order.
+func pathKey(path string) string {
+	return index.ReplaceAllStringFunc(path, func(m string) string {
+		return fmt.Sprintf("[%08s]", m[1:len(m)-1])
+	})
+}
+
+// Glossary pins the translation of recurring terms. A term that maps to
+// itself is kept as it is.
+type Glossary map[string]string
+
+// LoadGlossary reads a YAML glossary of term: translation pairs.
+func LoadGlossary(path string) (Glossary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var g Glossary
+	if err := yaml.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for term, translation := range g {
+		if strings.TrimSpace(term) == "" || strings.TrimSpace(translation) == "" {
+			return nil, fmt.Errorf("%s: term %q has an empty translation", path, term)
+		}
+	}
+	return g, nil
+}
+
+// ProperNouns returns the names in a resume that must survive translation:
+// the candidate, companies, institutions, projects, certifications and
+// technologies.
+func ProperNouns(r *resume.Resume) []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(names ...string) {
+		for _, name := range names {
+			name = strings.TrimSpace(name)
+			if name != "" && !seen[name] {
+				seen[name] = true
+				out = append(out, name)
+			}
+		}
+	}
+	add(r.Contact.Name)
+	for _, p := range r.Experience.Positions {
+		add(p.Company)
+		add(p.Technologies...)
+	}
+	if r.Projects != nil {
+		for _, p := range r.Projects.Projects {
+			add(p.Name)
+			add(p.Technologies...)
+		}
+	}
+	for _, e := range r.Education.Institutions {
+		add(e.Institution)
+	}
+	if r.Certifications != nil {
+		for _, c := range r.Certifications.Items {
+			add(c.Name, c.Issuer)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Ask sends a prompt to a model and returns its final answer.
+type Ask func(ctx context.Context, prompt string) (string, error)
+
+// Request is a translation of resume values into a language.
+type Request struct {
+	Segments []Segment
+	// Language is the target language as the model should read it, such
+	// as "German".
+	Language string
+	Glossary Glossary
+	// Keep lists names that must appear untranslated wherever they occur.
+	Keep []string
+}
+
+// Result maps each path to its translation. Warnings lists checks that
+// still failed after the retries, such as a name or number that did not
+// carry over.
+type Result struct {
+	Translations map[string]string
+	Warnings     []string
+}
+
+// Run translates the segments in batches. While a batch's answer is
+// malformed or fails the checks, up to retries more times, the model is
+// asked to correct it given the problems.
+func Run(ctx context.Context, ask Ask, req Request, retries int) (*Result, error) {
+	result := &Result{Translations: map[string]string{}}
+	for start := 0; start < len(req.Segments); start += batchSize {
+		batch := req.Segments[start:min(start+batchSize, len(req.Segments))]
+		prompt := Prompt(req, batch)
+		answer, err := ask(ctx, prompt)
+		if err != nil {
+			return nil, err
+		}
+		for attempt := 0; ; attempt++ {
+			translations, problems, err := check(answer, req, batch)
+			if err == nil && len(problems) == 0 {
+				for path, text := range translations {
+					result.Translations[path] = text
+				}
+				break
+			}
+			if attempt >= retries {
+				if err != nil {
+					return nil, fmt.Errorf("malformed translation after %d attempt(s): %w", attempt+1, err)
+				}
+				for path, text := range translations {
+					result.Translations[path] = text
+				}
+				result.Warnings = append(result.Warnings, problems...)
+				break
+			}
+			if err != nil {
+				problems = []string{err.Error()}
+			}
+			if answer, err = ask(ctx, repairPrompt(prompt, answer, problems)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// Prompt asks for the translation of a batch of segments as a JSON object
+// keyed by path.
+func Prompt(req Request, batch []Segment) string {
+	source := make(map[string]string, len(batch))
+	var all strings.Builder
+	for _, s := range batch {
+		source[s.Path] = s.Text
+		all.WriteString(s.Text)
+		all.WriteString("\n")
+	}
+	data, _ := json.MarshalIndent(source, "", "  ")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Translate the values of this JSON object from a resume into %s.\n\n", req.Language)
+	b.WriteString("- Keep every key exactly as it is and translate only the values.\n")
+	b.WriteString("- Keep names of people, companies, institutions, products and technologies, URLs, e-mail addresses, numbers and dates unchanged.\n")
+	b.WriteString("- Use the conventions of a resume in the target language: concise, professional, with the same tense and structure as the source.\n")
+	if keep := present(req.Keep, all.String(), true); len(keep) > 0 {
+		fmt.Fprintf(&b, "- Leave these names exactly as written: %s.\n", strings.Join(keep, ", "))
+	}
+	var terms []string
+	for term := range req.Glossary {
+		if contains(all.String(), term, false) {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) > 0 {
+		sort.Strings(terms)
+		b.WriteString("- Always translate these terms as given:\n")
+		for _, term := range terms {
+			fmt.Fprintf(&b, "  - %s: %s\n", term, req.Glossary[term])
+		}
+	}
+	fmt.Fprintf(&b, "\nRespond with only the translated JSON object.\n\n%s", data)
+	return b.String()
+}
+
+func repairPrompt(prompt, answer string, problems []string) string {
+	return fmt.Sprintf("%s\n\nA previous answer was:\n\n%s\n\nIt has these problems:\n- %s\n\nRespond with only the corrected JSON object.",
+		prompt, answer, strings.Join(problems, "\n- "))
+}
+
+var (
+	thinkBlock = regexp.MustCompile(`(?s)<think>.*?</think>`)
+	urlOrEmail = regexp.MustCompile(`https?://[^\s)]+|[\w.+-]+@[\w-]+\.[\w.-]+`)
+	digits     = regexp.MustCompile(`\d+`)
+)
+
+// check parses an answer and reports the translations that lost a name,
+// link, number or glossary term. The error is set when the answer is not
+// a usable JSON object.
+func check(answer string, req Request, batch []Segment) (map[string]string, []string, error) {
+	answer = thinkBlock.ReplaceAllString(answer, "")
+	start, end := strings.Index(answer, "{"), strings.LastIndex(answer, "}")
+	if start < 0 || end < start {
+		return nil, nil, errors.New("the answer contains no JSON object")
+	}
+	var translations map[string]string
+	if err := json.Unmarshal([]byte(answer[start:end+1]), &translations); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var missing []string
+	for _, s := range batch {
+		if strings.TrimSpace(translations[s.Path]) == "" {
+			missing = append(missing, s.Path)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("no translation of %s", strings.Join(missing, ", "))
+	}
+	if len(translations) != len(batch) {
+		return nil, nil, fmt.Errorf("the answer has %d keys, expected %d", len(translations), len(batch))
+	}
+
+	var problems []string
+	for _, s := range batch {
+		text := translations[s.Path]
+		var lost []string
+		lost = append(lost, absent(urlOrEmail.FindAllString(s.Text, -1), text)...)
+		lost = append(lost, absent(digits.FindAllString(s.Text, -1), text)...)
+		lost = append(lost, absent(present(req.Keep, s.Text, true), text)...)
+		if len(lost) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s must appear unchanged", s.Path, strings.Join(lost, ", ")))
+		}
+		for term, want := range req.Glossary {
+			if contains(s.Text, term, false) && !contains(text, want, false) {
+				problems = append(problems, fmt.Sprintf("%s: %q must be translated as %q", s.Path, term, want))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return translations, problems, nil
+}
+
+// present returns the terms that occur in text as whole words.
+func present(terms []string, text string, caseSensitive bool) []string {
+	var out []string
+	for _, term := range terms {
+		if contains(text, term, caseSensitive) {
+			out = append(out, term)
+		}
+	}
+	return out
+}
+
+// absent returns the tokens that do not occur in text.
+func absent(tokens []string, text string) []string {
+	var out []string
+	for _, token := range tokens {
+		if !strings.Contains(text, token) {
+			out = append(out, token)
+		}
+	}
+	return out
+}
+
+// contains reports whether term occurs in text with no letter or digit
+// directly before or after it.
+func contains(text, term string, caseSensitive bool) bool {
+	if !caseSensitive {
+		text, term = strings.ToLower(text), strings.ToLower(term)
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		i += offset
+		end := i + len(term)
+		if !wordChar(text, i-1) && !wordChar(text, end) {
+			return true
+		}
+		offset = i + 1
+	}
+}
+
+func wordChar(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return false
+	}
+	c := text[i]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
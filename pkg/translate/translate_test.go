@@ -0,0 +1,113 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/urmzd/resume-generator/pkg/resume"
+)
+
+const source = `contact:
+  name: Jane Doe
+  email: jane@example.com
+summary: Backend engineer with on-call experience.
+experience:
+  title: Experience
+  positions:
+    - company: Acme
+      title: Software Engineer
+      technologies: [Go, Kafka]
+      highlights:
+        - Cut Kafka lag by 40% at Acme, see https://acme.dev/blog
+      dates:
+        start: 2021-07-01
+`
+
+func TestSegments(t *testing.T) {
+	segments, err := Segments([]byte(source))
+	if err != nil {
+		t.Fatalf("Segments() error = %v", err)
+	}
+	var paths []string
+	for _, s := range segments {
+		paths = append(paths, s.Path)
+	}
+	want := "experience.positions[0].highlights[0] experience.positions[0].title experience.title summary"
+	if got := strings.Join(paths, " "); got != want {
+		t.Errorf("Segments() paths = %s, want %s", got, want)
+	}
+
+	r, err := resume.LoadResumeFromBytes([]byte(source), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(ProperNouns(r.ToResume()), ","); got != "Acme,Go,Jane Doe,Kafka" {
+		t.Errorf("ProperNouns() = %s", got)
+	}
+}
+
+func TestRun(t *testing.T) {
+	segments, err := Segments([]byte(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := Request{
+		Segments: segments,
+		Language: "German",
+		Glossary: Glossary{"on-call": "Rufbereitschaft"},
+		Keep:     []string{"Acme", "Go", "Kafka"},
+	}
+	good := map[string]string{
+		"summary":                               "Backend-Entwicklerin mit Erfahrung in Rufbereitschaft.",
+		"experience.title":                      "Berufserfahrung",
+		"experience.positions[0].title":         "Softwareentwicklerin",
+		"experience.positions[0].highlights[0]": "Kafka-Verzögerung bei Acme um 40 % gesenkt, siehe https://acme.dev/blog",
+	}
+	bad := map[string]string{}
+	for k, v := range good {
+		bad[k] = v
+	}
+	bad["summary"] = "Backend-Entwicklerin mit Bereitschaftsdienst."
+	bad["experience.positions[0].highlights[0]"] = "Verzögerung um 40 % gesenkt"
+
+	var prompts []string
+	answers := []map[string]string{bad, good}
+	ask := func(ctx context.Context, prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		data, _ := json.Marshal(answers[min(len(prompts), len(answers))-1])
+		return "```json\n" + string(data) + "\n```", nil
+	}
+
+	result, err := Run(context.Background(), ask, req, 1)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(result.Warnings) != 0 || result.Translations["experience.title"] != "Berufserfahrung" {
+		t.Errorf("Run() = %+v", result)
+	}
+	if !strings.Contains(prompts[0], "- on-call: Rufbereitschaft") || !strings.Contains(prompts[0], "exactly as written: Acme, Kafka.") {
+		t.Errorf("prompt does not pin the glossary and names:\n%s", prompts[0])
+	}
+	for _, want := range []string{`summary: "on-call" must be translated as "Rufbereitschaft"`, "highlights[0]: https://acme.dev/blog, Acme, Kafka must appear unchanged"} {
+		if !strings.Contains(prompts[1], want) {
+			t.Errorf("repair prompt does not say %q:\n%s", want, prompts[1])
+		}
+	}
+
+	answers = []map[string]string{bad}
+	prompts = nil
+	result, err = Run(context.Background(), ask, req, 1)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(result.Warnings) != 2 || result.Translations["summary"] != bad["summary"] {
+		t.Errorf("Run() with failing checks = %+v", result)
+	}
+
+	answers = []map[string]string{{"summary": "Nur eins"}}
+	if _, err := Run(context.Background(), ask, req, 1); err == nil || !strings.Contains(err.Error(), "no translation of experience.positions[0].highlights[0]") {
+		t.Errorf("Run() with missing keys error = %v", err)
+	}
+}